@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+/*
+异步任务
+1、任务状态：0 待执行、1 执行中、2 执行成功、3 执行失败(等待重试)、4 重试耗尽、5 已取消。
+2、修改任务时需要传入查询到的 version，任务已被工作线程或其他管理员修改时返回错误，刷新后重试。
+*/
+
+// 查询任务列表
+type ListAsyncTaskReq struct {
+	g.Meta `path:"/admin/async-tasks" method:"get" tags:"Admin/AsyncTask" summary:"List Async Tasks"`
+	model.AuthorRequired
+	model.PageReq
+	TaskType *int   `json:"task_type" dc:"任务类型"`
+	Status   *int   `json:"status" dc:"任务状态"`
+	CustomID string `json:"custom_id" dc:"自定义任务ID"`
+}
+
+type ListAsyncTaskRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*AsyncTask `json:"list" dc:"任务列表"`
+	*model.PageRes
+}
+
+// 查询任务详情
+type GetAsyncTaskReq struct {
+	g.Meta `path:"/admin/async-tasks/{id}" method:"get" tags:"Admin/AsyncTask" summary:"Get Async Task"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#任务ID不能为空" dc:"任务ID"`
+}
+
+type GetAsyncTaskRes struct {
+	g.Meta `mime:"application/json"`
+	*AsyncTask
+}
+
+// 重新执行重试次数耗尽的任务
+type RerunAsyncTaskReq struct {
+	g.Meta `path:"/admin/async-tasks/{id}/rerun" method:"post" tags:"Admin/AsyncTask" summary:"Rerun Dead Async Task"`
+	model.AuthorRequired
+	ID      int64 `p:"id" v:"required#任务ID不能为空" dc:"任务ID"`
+	Version *int  `json:"version" v:"required#版本号不能为空" dc:"任务版本号"`
+}
+
+type RerunAsyncTaskRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 取消待执行或等待重试的任务
+type CancelAsyncTaskReq struct {
+	g.Meta `path:"/admin/async-tasks/{id}/cancel" method:"post" tags:"Admin/AsyncTask" summary:"Cancel Async Task"`
+	model.AuthorRequired
+	ID      int64 `p:"id" v:"required#任务ID不能为空" dc:"任务ID"`
+	Version *int  `json:"version" v:"required#版本号不能为空" dc:"任务版本号"`
+}
+
+type CancelAsyncTaskRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 修改待执行或等待重试的任务的下次处理时间
+type RescheduleAsyncTaskReq struct {
+	g.Meta `path:"/admin/async-tasks/{id}/reschedule" method:"post" tags:"Admin/AsyncTask" summary:"Reschedule Async Task"`
+	model.AuthorRequired
+	ID            int64       `p:"id" v:"required#任务ID不能为空" dc:"任务ID"`
+	Version       *int        `json:"version" v:"required#版本号不能为空" dc:"任务版本号"`
+	NextRetryTime *gtime.Time `json:"next_retry_time" v:"required#下次处理时间不能为空" dc:"下次处理时间"`
+}
+
+type RescheduleAsyncTaskRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 各任务类型的任务数量和积压情况
+type GetAsyncTaskStatsReq struct {
+	g.Meta `path:"/admin/async-tasks/stats" method:"get" tags:"Admin/AsyncTask" summary:"Get Async Task Stats"`
+	model.AuthorRequired
+}
+
+type GetAsyncTaskStatsRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*AsyncTaskStats `json:"list" dc:"统计结果"`
+}
+
+type AsyncTask struct {
+	ID              int64       `json:"id" dc:"任务ID"`
+	CustomID        string      `json:"custom_id" dc:"自定义任务ID"`
+	TaskType        int         `json:"task_type" dc:"任务类型"`
+	TaskTypeName    string      `json:"task_type_name" dc:"任务类型名称"`
+	Status          int         `json:"status" dc:"任务状态"`
+	StatusName      string      `json:"status_name" dc:"任务状态名称"`
+	RetryCount      int         `json:"retry_count" dc:"重试次数"`
+	Content         interface{} `json:"content" dc:"任务内容，无法解析时为原始内容"`
+	Version         int         `json:"version" dc:"任务版本号"`
+	NextRetryTime   *gtime.Time `json:"next_retry_time" dc:"下次处理时间"`
+	LastError       string      `json:"last_error" dc:"最近一次执行失败的错误信息"`
+	LastErrorStack  string      `json:"last_error_stack" dc:"最近一次执行失败的调用栈"`
+	LeaseOwner      string      `json:"lease_owner" dc:"执行中任务的领取者(实例标识)"`
+	LeaseExpireTime *gtime.Time `json:"lease_expire_time" dc:"执行中任务的租约到期时间，到期未续约的任务会被回收重新执行"`
+	CreateTime      *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime      *gtime.Time `json:"update_time" dc:"更新时间"`
+}
+
+type AsyncTaskStats struct {
+	TaskType         int    `json:"task_type" dc:"任务类型"`
+	TaskTypeName     string `json:"task_type_name" dc:"任务类型名称"`
+	Pending          int    `json:"pending" dc:"待执行数量"`
+	Processing       int    `json:"processing" dc:"执行中数量"`
+	Success          int    `json:"success" dc:"执行成功数量"`
+	Failed           int    `json:"failed" dc:"执行失败等待重试数量"`
+	Dead             int    `json:"dead" dc:"重试耗尽数量"`
+	Canceled         int    `json:"canceled" dc:"已取消数量"`
+	OldestPendingAge int64  `json:"oldest_pending_age" dc:"已到处理时间但未执行的任务中，最早的任务已等待的秒数"`
+}
+
+// 查询所有周期任务
+type ListRecurringTaskReq struct {
+	g.Meta `path:"/admin/recurring-tasks" method:"get" tags:"Admin/AsyncTask" summary:"List Recurring Tasks"`
+	model.AuthorRequired
+}
+
+type ListRecurringTaskRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*RecurringTask `json:"list" dc:"周期任务列表"`
+}
+
+// 暂停周期任务，已生成的任务不受影响
+type PauseRecurringTaskReq struct {
+	g.Meta `path:"/admin/recurring-tasks/{name}/pause" method:"post" tags:"Admin/AsyncTask" summary:"Pause Recurring Task"`
+	model.AuthorRequired
+	Name string `p:"name" v:"required#周期任务名称不能为空" dc:"周期任务名称"`
+}
+
+type PauseRecurringTaskRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 恢复周期任务，从当前时间重新计算下次执行时间
+type ResumeRecurringTaskReq struct {
+	g.Meta `path:"/admin/recurring-tasks/{name}/resume" method:"post" tags:"Admin/AsyncTask" summary:"Resume Recurring Task"`
+	model.AuthorRequired
+	Name string `p:"name" v:"required#周期任务名称不能为空" dc:"周期任务名称"`
+}
+
+type ResumeRecurringTaskRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+type RecurringTask struct {
+	ID          int64       `json:"id" dc:"周期任务ID"`
+	Name        string      `json:"name" dc:"周期任务名称，执行时生成的任务的自定义任务ID为 recurring_{名称}"`
+	CronSpec    string      `json:"cron_spec" dc:"cron表达式"`
+	Paused      bool        `json:"paused" dc:"是否暂停"`
+	Registered  bool        `json:"registered" dc:"当前实例是否注册了该周期任务，未注册的周期任务不会执行"`
+	NextRunTime *gtime.Time `json:"next_run_time" dc:"下次执行时间"`
+	LastRunTime *gtime.Time `json:"last_run_time" dc:"最近一次执行时间"`
+	CreateTime  *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime  *gtime.Time `json:"update_time" dc:"更新时间"`
+}
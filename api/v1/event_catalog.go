@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+/*
+领域事件目录
+1、领域事件按 CloudEvents 1.0 JSON 格式发送到消息队列，事件ID(id)用于去重。
+2、事件类型带主版本号，只新增字段时不修改版本号；不兼容的修改使用新的事件类型，迁移期间新旧版本同时发送。
+*/
+
+// 查询事件类型列表和数据格式
+type ListEventTypesReq struct {
+	g.Meta `path:"/admin/event-catalog" method:"get" tags:"Admin/Event" summary:"List Event Types And Schemas"`
+	model.AuthorRequired
+	Type string `json:"type" dc:"事件类型，为空时返回所有事件类型"`
+}
+
+type ListEventTypesRes struct {
+	g.Meta          `mime:"application/json"`
+	SpecVersion     string       `json:"spec_version" dc:"CloudEvents 规范版本"`
+	Source          string       `json:"source" dc:"事件来源(source)"`
+	DataContentType string       `json:"data_content_type" dc:"事件数据格式(datacontenttype)"`
+	List            []*EventType `json:"list" dc:"事件类型列表"`
+}
+
+type EventType struct {
+	Type        string                 `json:"type" dc:"事件类型，带主版本号"`
+	Topic       string                 `json:"topic" dc:"消息队列主题"`
+	Subject     string                 `json:"subject" dc:"subject 格式"`
+	Description string                 `json:"description" dc:"说明"`
+	DataSchema  map[string]interface{} `json:"data_schema" dc:"事件数据(data)的 JSON Schema"`
+}
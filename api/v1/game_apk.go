@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// 获取APK安装包解析结果
+type GetApkInfoReq struct {
+	g.Meta `path:"/games/{game_id}/media-info/apk-info" method:"get" tags:"Game Management/MediaInfo" summary:"Get APK Info"`
+	model.AuthorRequired
+	GameID int64  `p:"game_id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	FileID string `p:"file_id" dc:"文件ID，为空时使用游戏当前下载的安装包"`
+}
+
+type GetApkInfoRes struct {
+	g.Meta `mime:"application/json"`
+	*GameApkInfo
+}
+
+type GameApkInfo struct {
+	FileID          string      `json:"file_id" dc:"文件ID"`
+	Status          int         `json:"status" dc:"解析状态(0:解析中,1:解析成功,2:解析失败)"`
+	StatusText      string      `json:"status_text" dc:"解析状态描述"`
+	PackageName     string      `json:"package_name" dc:"包名"`
+	VersionCode     int64       `json:"version_code" dc:"版本号(versionCode)"`
+	VersionName     string      `json:"version_name" dc:"版本名称(versionName)"`
+	MinSdk          int         `json:"min_sdk" dc:"最低SDK版本"`
+	TargetSdk       int         `json:"target_sdk" dc:"目标SDK版本"`
+	Permissions     []string    `json:"permissions" dc:"申请的权限"`
+	SignatureScheme string      `json:"signature_scheme" dc:"签名方案(v1/v2/v3)"`
+	CertSha256      string      `json:"cert_sha256" dc:"签名证书SHA-256指纹"`
+	CertSubject     string      `json:"cert_subject" dc:"签名证书主题"`
+	Error           string      `json:"error" dc:"解析失败原因"`
+	ApproveTime     *gtime.Time `json:"approve_time" dc:"审核通过时间"`
+	UpdateTime      *gtime.Time `json:"update_time" dc:"更新时间"`
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// 创建构建版本(预上传APK文件)
+type CreateGameBuildReq struct {
+	g.Meta `path:"/games/{id}/builds" method:"post" tags:"Game Management/Build" summary:"Create Game Build"`
+	model.AuthorRequired
+	ID           int64  `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	VersionName  string `json:"version_name" v:"required#版本名称不能为空" dc:"版本名称"`
+	VersionCode  int64  `json:"version_code" v:"required|min:1#版本号不能为空|版本号必须大于0" dc:"版本号"`
+	ReleaseNotes string `json:"release_notes" dc:"更新说明"`
+	FileName     string `json:"file_name" v:"required#文件名称不能为空" dc:"文件名称"`
+	FileSize     int64  `json:"file_size" v:"required#文件大小不能为空" dc:"文件大小"`
+	ContentType  string `json:"content_type" v:"required#文件类型不能为空" dc:"文件类型"`
+	Checksum     string `json:"checksum" dc:"文件校验和"`
+}
+
+type CreateGameBuildRes struct {
+	g.Meta    `mime:"application/json"`
+	BuildID   int64  `json:"build_id" dc:"构建版本ID"`
+	FileID    string `json:"file_id" dc:"文件ID"`
+	UploadURL string `json:"upload_url" dc:"上传URL"`
+}
+
+// 获取构建版本列表
+type ListGameBuildReq struct {
+	g.Meta `path:"/games/{id}/builds" method:"get" tags:"Game Management/Build" summary:"List Game Builds"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	model.PageReq
+}
+
+type ListGameBuildRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*GameBuild `json:"list" dc:"构建版本列表"`
+	*model.PageRes
+}
+
+// 构建版本提交审核
+type SubmitGameBuildForReviewReq struct {
+	g.Meta `path:"/games/{id}/builds/{build_id}/submit-review" method:"post" tags:"Game Management/Build" summary:"Submit Game Build For Review"`
+	model.AuthorRequired
+	ID      int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	BuildID int64 `p:"build_id" v:"required#构建版本ID不能为空" dc:"构建版本ID"`
+}
+
+type SubmitGameBuildForReviewRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 构建版本审核通过
+type ApproveGameBuildReq struct {
+	g.Meta `path:"/games/{id}/builds/{build_id}/approve" method:"post" tags:"Game Management/Build" summary:"Approve Game Build"`
+	model.AuthorRequired
+	ID      int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	BuildID int64 `p:"build_id" v:"required#构建版本ID不能为空" dc:"构建版本ID"`
+}
+
+type ApproveGameBuildRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 构建版本审核拒绝
+type RejectGameBuildReq struct {
+	g.Meta `path:"/games/{id}/builds/{build_id}/reject" method:"post" tags:"Game Management/Build" summary:"Reject Game Build"`
+	model.AuthorRequired
+	ID      int64  `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	BuildID int64  `p:"build_id" v:"required#构建版本ID不能为空" dc:"构建版本ID"`
+	Reason  string `json:"reason" dc:"拒绝原因"`
+}
+
+type RejectGameBuildRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 设置当前版本
+type SetCurrentGameBuildReq struct {
+	g.Meta `path:"/games/{id}/builds/{build_id}/set-current" method:"post" tags:"Game Management/Build" summary:"Set Current Game Build"`
+	model.AuthorRequired
+	ID      int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	BuildID int64 `p:"build_id" v:"required#构建版本ID不能为空" dc:"构建版本ID"`
+}
+
+type SetCurrentGameBuildRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 回滚到之前审核通过的版本
+type RollbackGameBuildReq struct {
+	g.Meta `path:"/games/{id}/builds/rollback" method:"post" tags:"Game Management/Build" summary:"Rollback Game Build"`
+	model.AuthorRequired
+	ID      int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	BuildID int64 `json:"build_id" dc:"回滚的目标版本ID(不传则回滚到上一个审核通过的版本)"`
+}
+
+type RollbackGameBuildRes struct {
+	g.Meta     `mime:"application/json"`
+	*GameBuild `json:"build" dc:"回滚后的当前版本"`
+}
+
+type GameBuild struct {
+	ID           int64       `json:"id" dc:"构建版本ID"`
+	GameID       int64       `json:"game_id" dc:"游戏ID"`
+	VersionName  string      `json:"version_name" dc:"版本名称"`
+	VersionCode  int64       `json:"version_code" dc:"版本号"`
+	ReleaseNotes string      `json:"release_notes" dc:"更新说明"`
+	FileID       string      `json:"file_id" dc:"文件ID"`
+	FileSize     int64       `json:"file_size" dc:"文件大小"`
+	Checksum     string      `json:"checksum" dc:"文件校验和"`
+	FileStatus   int         `json:"file_status" dc:"文件上传状态"`
+	Status       string      `json:"status" dc:"审核状态"`
+	RejectReason string      `json:"reject_reason" dc:"拒绝原因"`
+	IsCurrent    bool        `json:"is_current" dc:"是否为当前版本"`
+	CreateTime   *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime   *gtime.Time `json:"update_time" dc:"更新时间"`
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// 获取游戏草稿
+type GetGameDraftReq struct {
+	g.Meta `path:"/games/{id}/draft" method:"get" tags:"Game Management/Draft" summary:"Get Game Draft"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+}
+
+type GetGameDraftRes struct {
+	g.Meta     `mime:"application/json"`
+	*GameDraft `json:"draft" dc:"游戏草稿"`
+}
+
+// 丢弃游戏草稿
+type DiscardGameDraftReq struct {
+	g.Meta `path:"/games/{id}/draft" method:"delete" tags:"Game Management/Draft" summary:"Discard Game Draft"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+}
+
+type DiscardGameDraftRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 草稿提交审核
+type SubmitGameDraftForReviewReq struct {
+	g.Meta `path:"/games/{id}/draft/submit-review" method:"post" tags:"Game Management/Draft" summary:"Submit Game Draft For Review"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+}
+
+type SubmitGameDraftForReviewRes struct {
+	g.Meta             `mime:"application/json"`
+	ValidationWarnings []*ValidationFinding `json:"validation_warnings" dc:"提交审核检查警告(不影响提交，审核人员可见)"`
+}
+
+// 草稿审核通过
+type ApproveGameDraftReq struct {
+	g.Meta `path:"/games/{id}/draft/approve" method:"post" tags:"Game Management/Draft" summary:"Approve Game Draft"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+}
+
+type ApproveGameDraftRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 草稿审核拒绝
+type RejectGameDraftReq struct {
+	g.Meta `path:"/games/{id}/draft/reject" method:"post" tags:"Game Management/Draft" summary:"Reject Game Draft"`
+	model.AuthorRequired
+	ID     int64  `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	Reason string `json:"reason" dc:"拒绝原因"`
+}
+
+type RejectGameDraftRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 草稿与线上数据的差异
+type DiffGameDraftReq struct {
+	g.Meta `path:"/games/{id}/draft/diff" method:"get" tags:"Game Management/Draft" summary:"Diff Game Draft Against Live"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+}
+
+type DiffGameDraftRes struct {
+	g.Meta       `mime:"application/json"`
+	GameID       int64            `json:"game_id" dc:"游戏ID"`
+	Fields       []*GameFieldDiff `json:"fields" dc:"字段差异"`
+	MediaAdded   []*GameMediaInfo `json:"media_added" dc:"新增的媒体文件"`
+	MediaRemoved []*GameMediaInfo `json:"media_removed" dc:"移除的媒体文件"`
+}
+
+// 获取待审核的草稿列表
+type ListGameDraftInReviewReq struct {
+	g.Meta `path:"/games/drafts/in-review" method:"get" tags:"Game Management/Draft" summary:"List Game Drafts In Review"`
+	model.AuthorRequired
+	model.PageReq
+}
+
+type ListGameDraftInReviewRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*GameDraft `json:"list" dc:"草稿列表"`
+	*model.PageRes
+}
+
+type GameDraft struct {
+	ID             int64         `json:"id" dc:"草稿ID"`
+	GameID         int64         `json:"game_id" dc:"游戏ID"`
+	Name           string        `json:"name" dc:"游戏名称"`
+	DistributeType string        `json:"distribute_type" dc:"游戏分发类型"`
+	Category       *CategoryInfo `json:"category" dc:"游戏分类"`
+	Tags           []*TagInfo    `json:"tags" dc:"游戏标签"`
+	Developer      string        `json:"developer" dc:"游戏开发者"`
+	Publisher      string        `json:"publisher" dc:"游戏发行商"`
+	Description    string        `json:"description" dc:"游戏描述"`
+	Details        string        `json:"details" dc:"游戏详情"`
+
+	Status     string      `json:"status" dc:"草稿状态"`
+	CreateTime *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime *gtime.Time `json:"update_time" dc:"更新时间"`
+
+	MediaInfos []*GameMediaInfo `json:"media_infos" dc:"游戏媒体信息"`
+
+	ValidationWarnings []*ValidationFinding `json:"validation_warnings,omitempty" dc:"提交审核检查警告(待审核列表返回)"`
+}
+
+type GameFieldDiff struct {
+	Field      string      `json:"field" dc:"字段"`
+	LiveValue  interface{} `json:"live_value" dc:"线上值"`
+	DraftValue interface{} `json:"draft_value" dc:"草稿值"`
+}
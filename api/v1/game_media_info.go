@@ -14,6 +14,8 @@ type PreUploadMediaInfoReq struct {
 	FileName    string `json:"file_name" v:"required#文件名称不能为空" dc:"文件名称"`
 	FileSize    int64  `json:"file_size" v:"required#文件大小不能为空" dc:"文件大小"`
 	ContentType string `json:"content_type" v:"required#文件类型不能为空" dc:"文件类型"`
+	Width       int    `json:"width" dc:"宽度(像素)，截图的尺寸在安全扫描时从文件中读取，以服务端读取的为准"`
+	Height      int    `json:"height" dc:"高度(像素)，截图的尺寸在安全扫描时从文件中读取，以服务端读取的为准"`
 }
 
 type PreUploadMediaInfoRes struct {
@@ -38,12 +40,12 @@ type ReportUploadResultRes struct {
 type PreDownloadMediaInfoReq struct {
 	g.Meta `path:"/games/{game_id}/media-info/pre-download" method:"post" tags:"Game Management/MediaInfo" summary:"Pre Download"`
 	model.AuthorRequired
-	GameID int64  `p:"game_id" v:"required#游戏ID不能为空" dc:"游戏ID"`
-	FileID string `json:"fileID" v:"required#文件ID不能为空" dc:"文件ID"`
+	GameID int64 `p:"game_id" v:"required#游戏ID不能为空" dc:"游戏ID"`
 }
 
 type PreDownloadMediaInfoRes struct {
 	g.Meta      `mime:"application/json"`
+	FileID      string `json:"file_id" dc:"文件ID(游戏当前版本)"`
 	DownloadURL string `json:"download_url" dc:"下载URL"`
 	ExpiresAt   string `json:"expires_at" dc:"过期时间"`
 	ExpiresIn   int64  `json:"expires_in" dc:"过期时间"`
@@ -82,10 +84,21 @@ type SaveMediaInfoRes struct {
 	g.Meta `mime:"application/json"`
 }
 
+// 重新扫描文件，用于内容扫描上线前上传的文件，以及扫描长时间未完成的文件
+type RescanMediaFileReq struct {
+	g.Meta `path:"/admin/media-files/{file_id}/rescan" method:"post" tags:"Admin/ContentScan" summary:"Rescan Media File"`
+	model.AuthorRequired
+	FileID string `p:"file_id" v:"required#文件ID不能为空" dc:"文件ID"`
+}
+
+type RescanMediaFileRes struct {
+	g.Meta `mime:"application/json"`
+}
+
 type GameMediaInfo struct {
 	ID        int64  `json:"id" dc:"媒体信息ID"`
 	FileID    string `json:"file_id" dc:"文件ID"`
 	MediaType int    `json:"media_type" dc:"媒体类型"`
 	MediaUrl  string `json:"media_url" dc:"媒体URL"`
-	Status    int    `json:"status" dc:"媒体状态"`
+	Status    int    `json:"status" dc:"媒体状态(1:初始化,2:成功,3:失败,4:安全扫描中,5:存在安全风险,6:安全扫描通过)"`
 }
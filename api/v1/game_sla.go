@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// 游戏状态停留时长统计
+type GetGameSLAStatsReq struct {
+	g.Meta `path:"/admin/sla/stats" method:"get" tags:"Admin/SLA" summary:"Get Game Status SLA Stats"`
+	model.AuthorRequired
+	Status *int `json:"status" dc:"状态(不传则统计所有配置了SLA的状态以及审核中状态)"`
+	Days   int  `json:"days" d:"30" v:"min:1#统计天数必须大于0" dc:"统计窗口(天)"`
+}
+
+type GetGameSLAStatsRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*GameSLAStats `json:"list" dc:"统计结果"`
+}
+
+type GameSLAStats struct {
+	Status         int               `json:"status" dc:"状态"`
+	StatusName     string            `json:"status_name" dc:"状态名称"`
+	SLA            int64             `json:"sla" dc:"SLA(秒)，0表示未配置"`
+	QueueDepth     int               `json:"queue_depth" dc:"当前处于该状态的游戏数量"`
+	CompletedCount int               `json:"completed_count" dc:"统计窗口内离开该状态的次数"`
+	MedianDuration int64             `json:"median_duration" dc:"停留时长中位数(秒)"`
+	P95Duration    int64             `json:"p95_duration" dc:"停留时长P95(秒)"`
+	OverdueGames   []*GameSLAOverdue `json:"overdue_games" dc:"超过SLA的游戏"`
+}
+
+type GameSLAOverdue struct {
+	GameID    int64       `json:"game_id" dc:"游戏ID"`
+	GameName  string      `json:"game_name" dc:"游戏名称"`
+	EnterTime *gtime.Time `json:"enter_time" dc:"进入状态时间"`
+	Elapsed   int64       `json:"elapsed" dc:"已停留时长(秒)"`
+}
@@ -15,7 +15,9 @@ type SubmitGameForReviewReq struct {
 }
 
 type SubmitGameForReviewRes struct {
-	g.Meta `mime:"application/json"`
+	g.Meta             `mime:"application/json"`
+	UnresolvedIssues   []*ReviewIssue       `json:"unresolved_issues" dc:"历史审核中仍未解决的问题"`
+	ValidationWarnings []*ValidationFinding `json:"validation_warnings" dc:"提交审核检查警告(不影响提交，审核人员可见)"`
 }
 
 // 获取待审核游戏列表
@@ -27,10 +29,15 @@ type ListInReviewReq struct {
 
 type ListInReviewRes struct {
 	g.Meta `mime:"application/json"`
-	List   []*Game `json:"list" dc:"游戏列表"`
+	List   []*GameInReview `json:"list" dc:"游戏列表"`
 	model.PageRes
 }
 
+type GameInReview struct {
+	*Game
+	ValidationWarnings []*ValidationFinding `json:"validation_warnings" dc:"提交审核检查警告"`
+}
+
 // 审核通过
 type ApproveGameReq struct {
 	g.Meta `path:"/games/{id}/approve" method:"post" tags:"Game Management/Status" summary:"Approve Game"`
@@ -39,15 +46,17 @@ type ApproveGameReq struct {
 }
 
 type ApproveGameRes struct {
-	g.Meta `mime:"application/json"`
+	g.Meta           `mime:"application/json"`
+	PendingApprovals int `json:"pending_approvals" dc:"还需要的审核通过次数(开启双人审核时大于0表示等待其他审核人员)"`
 }
 
 // 审核拒绝
 type RejectGameReq struct {
 	g.Meta `path:"/games/{id}/reject" method:"post" tags:"Game Management/Status" summary:"Reject Game"`
 	model.AuthorRequired
-	ID     int64  `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
-	Reason string `json:"reason" dc:"拒绝原因"`
+	ID     int64               `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	Reason string              `json:"reason" dc:"拒绝原因"`
+	Issues []*ReviewIssueInput `json:"issues" dc:"审核问题(从拒绝原因目录中选择，可按字段填写审核意见)"`
 }
 
 type RejectGameRes struct {
@@ -102,7 +111,7 @@ type CancelPreRegisterRes struct {
 
 // 更新游戏信息（回到初始状态）
 type UpdateGameInfoReq struct {
-	g.Meta `path:"/games/{id}/update-info" method:"post" tags:"Game Management/Status" summary:"Update Game Info (Reset to Init Status)"`
+	g.Meta `path:"/games/{id}/update-info" method:"post" tags:"Game Management/Status" summary:"Update Game Info (Reset to Init Status or Open Draft)"`
 	model.AuthorRequired
 	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
 }
@@ -113,7 +122,7 @@ type UpdateGameInfoRes struct {
 
 // 更新游戏版本（回到初始状态）
 type UpdateGameVersionReq struct {
-	g.Meta `path:"/games/{id}/update-version" method:"post" tags:"Game Management/Status" summary:"Update Game Version (Reset to Init Status)"`
+	g.Meta `path:"/games/{id}/update-version" method:"post" tags:"Game Management/Status" summary:"Update Game Version (Open Draft)"`
 	model.AuthorRequired
 	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
 }
@@ -122,6 +131,36 @@ type UpdateGameVersionRes struct {
 	g.Meta `mime:"application/json"`
 }
 
+// 获取游戏状态变更历史
+type ListGameStatusHistoryReq struct {
+	g.Meta `path:"/games/{id}/status-history" method:"get" tags:"Game Management/Status" summary:"List Game Status History"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	model.PageReq
+}
+
+type ListGameStatusHistoryRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*GameStatusHistory `json:"list" dc:"状态变更历史"`
+	*model.PageRes
+}
+
+// 游戏状态变更历史
+type GameStatusHistory struct {
+	ID             int64            `json:"id" dc:"ID"`
+	FromStatus     model.GameStatus `json:"from_status" dc:"变更前状态"`
+	FromStatusName string           `json:"from_status_name" dc:"变更前状态名称"`
+	ToStatus       model.GameStatus `json:"to_status" dc:"变更后状态"`
+	ToStatusName   string           `json:"to_status_name" dc:"变更后状态名称"`
+	Event          model.GameEvent  `json:"event" dc:"事件代码"`
+	EventName      string           `json:"event_name" dc:"事件名称"`
+	OperatorID     int64            `json:"operator_id" dc:"操作人ID(0:系统)"`
+	OperatorName   string           `json:"operator_name" dc:"操作人名称"`
+	Reason         string           `json:"reason" dc:"原因"`
+	EventData      string           `json:"event_data" dc:"事件数据"`
+	CreateTime     *gtime.Time      `json:"create_time" dc:"变更时间"`
+}
+
 // 获取游戏可用事件列表
 type GetAvailableEventsReq struct {
 	g.Meta `path:"/games/{id}/available-events" method:"get" tags:"Game Management/Status" summary:"Get Available Events for Game"`
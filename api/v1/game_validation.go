@@ -0,0 +1,10 @@
+package v1
+
+// 提交审核检查发现的问题
+type ValidationFinding struct {
+	Validator string `json:"validator" dc:"检查项"`
+	Severity  string `json:"severity" dc:"严重程度(警告/阻断)"`
+	Field     string `json:"field" dc:"问题字段"`
+	FieldName string `json:"field_name" dc:"问题字段名称"`
+	Message   string `json:"message" dc:"问题描述"`
+}
@@ -0,0 +1,216 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+/*
+审核反馈
+1、拒绝原因目录由管理员维护，审核人员拒绝时从中选择。
+2、审核问题按字段记录，审核人员和开发者都可以回复，开发者修复后标记为已解决。
+*/
+
+// 创建拒绝原因
+type CreateRejectCodeReq struct {
+	g.Meta `path:"/review/reject-codes" method:"post" tags:"Review/RejectCode" summary:"Create Reject Code"`
+	model.AuthorRequired
+	Code        string `json:"code" v:"required|length:1,64#拒绝原因编码不能为空|拒绝原因编码长度不能超过64个字符" dc:"拒绝原因编码"`
+	Title       string `json:"title" v:"required#标题不能为空" dc:"标题"`
+	Description string `json:"description" dc:"描述"`
+	Field       string `json:"field" dc:"默认关联字段(name,description,details,category,tags,icon,screenshot,video,apk,h5_link,other)"`
+	Enabled     bool   `json:"enabled" d:"true" dc:"是否启用"`
+}
+
+type CreateRejectCodeRes struct {
+	g.Meta `mime:"application/json"`
+	ID     int64 `json:"id" dc:"拒绝原因ID"`
+}
+
+// 更新拒绝原因
+type UpdateRejectCodeReq struct {
+	g.Meta `path:"/review/reject-codes/{id}" method:"put" tags:"Review/RejectCode" summary:"Update Reject Code"`
+	model.AuthorRequired
+	ID          int64  `p:"id" v:"required#拒绝原因ID不能为空" dc:"拒绝原因ID"`
+	Title       string `json:"title" v:"required#标题不能为空" dc:"标题"`
+	Description string `json:"description" dc:"描述"`
+	Field       string `json:"field" dc:"默认关联字段"`
+	Enabled     bool   `json:"enabled" dc:"是否启用"`
+}
+
+type UpdateRejectCodeRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 删除拒绝原因
+type DeleteRejectCodeReq struct {
+	g.Meta `path:"/review/reject-codes/{id}" method:"delete" tags:"Review/RejectCode" summary:"Delete Reject Code"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#拒绝原因ID不能为空" dc:"拒绝原因ID"`
+}
+
+type DeleteRejectCodeRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 获取拒绝原因列表
+type ListRejectCodeReq struct {
+	g.Meta `path:"/review/reject-codes" method:"get" tags:"Review/RejectCode" summary:"List Reject Codes"`
+	model.AuthorRequired
+	OnlyEnabled bool `json:"only_enabled" dc:"只返回启用的拒绝原因"`
+}
+
+type ListRejectCodeRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*RejectCode `json:"list" dc:"拒绝原因列表"`
+}
+
+// 获取游戏的审核问题
+type ListGameReviewIssueReq struct {
+	g.Meta `path:"/games/{id}/review-issues" method:"get" tags:"Review/Issue" summary:"List Game Review Issues"`
+	model.AuthorRequired
+	ID       int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	OnlyOpen bool  `json:"only_open" dc:"只返回未解决的问题"`
+}
+
+type ListGameReviewIssueRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*ReviewIssue `json:"list" dc:"审核问题列表"`
+}
+
+// 回复审核问题
+type AddReviewCommentReq struct {
+	g.Meta `path:"/games/{id}/review-issues/{issue_id}/comments" method:"post" tags:"Review/Issue" summary:"Add Review Comment"`
+	model.AuthorRequired
+	ID      int64  `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	IssueID int64  `p:"issue_id" v:"required#问题ID不能为空" dc:"问题ID"`
+	Content string `json:"content" v:"required#回复内容不能为空" dc:"回复内容"`
+}
+
+type AddReviewCommentRes struct {
+	g.Meta `mime:"application/json"`
+	ID     int64 `json:"id" dc:"回复ID"`
+}
+
+// 标记审核问题为已解决
+type ResolveReviewIssueReq struct {
+	g.Meta `path:"/games/{id}/review-issues/{issue_id}/resolve" method:"post" tags:"Review/Issue" summary:"Resolve Review Issue"`
+	model.AuthorRequired
+	ID      int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	IssueID int64 `p:"issue_id" v:"required#问题ID不能为空" dc:"问题ID"`
+}
+
+type ResolveReviewIssueRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+type RejectCode struct {
+	ID          int64       `json:"id" dc:"拒绝原因ID"`
+	Code        string      `json:"code" dc:"拒绝原因编码"`
+	Title       string      `json:"title" dc:"标题"`
+	Description string      `json:"description" dc:"描述"`
+	Field       string      `json:"field" dc:"默认关联字段"`
+	Enabled     bool        `json:"enabled" dc:"是否启用"`
+	CreateTime  *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime  *gtime.Time `json:"update_time" dc:"更新时间"`
+}
+
+// 审核拒绝时提交的问题
+type ReviewIssueInput struct {
+	Code    string `json:"code" v:"required#拒绝原因编码不能为空" dc:"拒绝原因编码"`
+	Field   string `json:"field" dc:"问题字段(不传则使用拒绝原因的默认字段)"`
+	Comment string `json:"comment" dc:"审核意见"`
+}
+
+type ReviewIssue struct {
+	ID           int64            `json:"id" dc:"问题ID"`
+	GameID       int64            `json:"game_id" dc:"游戏ID"`
+	Code         string           `json:"code" dc:"拒绝原因编码"`
+	Field        string           `json:"field" dc:"问题字段"`
+	FieldName    string           `json:"field_name" dc:"问题字段名称"`
+	Comment      string           `json:"comment" dc:"审核意见"`
+	Status       string           `json:"status" dc:"问题状态"`
+	ReviewerID   int64            `json:"reviewer_id" dc:"审核人ID"`
+	ReviewerName string           `json:"reviewer_name" dc:"审核人名称"`
+	ResolveTime  *gtime.Time      `json:"resolve_time" dc:"解决时间"`
+	CreateTime   *gtime.Time      `json:"create_time" dc:"创建时间"`
+	Comments     []*ReviewComment `json:"comments" dc:"讨论记录"`
+}
+
+type ReviewComment struct {
+	ID         int64       `json:"id" dc:"回复ID"`
+	UserID     int64       `json:"user_id" dc:"用户ID"`
+	UserName   string      `json:"user_name" dc:"用户名"`
+	Content    string      `json:"content" dc:"内容"`
+	CreateTime *gtime.Time `json:"create_time" dc:"创建时间"`
+}
+
+// 领取审核队列中的下一个游戏
+type ClaimNextReviewReq struct {
+	g.Meta `path:"/review/claims/next" method:"post" tags:"Review/Claim" summary:"Claim Next Game In Review"`
+	model.AuthorRequired
+}
+
+type ClaimNextReviewRes struct {
+	g.Meta       `mime:"application/json"`
+	*ReviewClaim `json:"claim" dc:"领取记录"`
+}
+
+// 领取指定游戏的审核任务，已领取时续期
+type ClaimGameReviewReq struct {
+	g.Meta `path:"/games/{id}/review-claim" method:"post" tags:"Review/Claim" summary:"Claim Or Renew Game Review"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+}
+
+type ClaimGameReviewRes struct {
+	g.Meta       `mime:"application/json"`
+	*ReviewClaim `json:"claim" dc:"领取记录"`
+}
+
+// 释放审核任务
+type ReleaseGameReviewReq struct {
+	g.Meta `path:"/games/{id}/review-claim" method:"delete" tags:"Review/Claim" summary:"Release Game Review"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+}
+
+type ReleaseGameReviewRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 转交审核任务
+type HandoffGameReviewReq struct {
+	g.Meta `path:"/games/{id}/review-claim/handoff" method:"post" tags:"Review/Claim" summary:"Handoff Game Review"`
+	model.AuthorRequired
+	ID           int64  `p:"id" v:"required#游戏ID不能为空" dc:"游戏ID"`
+	ReviewerID   int64  `json:"reviewer_id" v:"required#审核人ID不能为空" dc:"接收的审核人ID"`
+	ReviewerName string `json:"reviewer_name" v:"required#审核人名称不能为空" dc:"接收的审核人名称"`
+}
+
+type HandoffGameReviewRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 我领取的审核任务
+type ListMyReviewClaimReq struct {
+	g.Meta `path:"/review/claims/mine" method:"get" tags:"Review/Claim" summary:"List My Review Claims"`
+	model.AuthorRequired
+	model.PageReq
+}
+
+type ListMyReviewClaimRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*ReviewClaim `json:"list" dc:"领取记录列表"`
+	*model.PageRes
+}
+
+type ReviewClaim struct {
+	GameID          int64       `json:"game_id" dc:"游戏ID"`
+	GameName        string      `json:"game_name" dc:"游戏名称"`
+	ReviewerID      int64       `json:"reviewer_id" dc:"审核人ID"`
+	ReviewerName    string      `json:"reviewer_name" dc:"审核人名称"`
+	LeaseExpireTime *gtime.Time `json:"lease_expire_time" dc:"租约到期时间"`
+}
@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+/*
+游戏搜索
+1、索引保存在各实例的内存中，游戏创建、修改、状态变更后立即更新本实例的索引，其他实例定期同步。
+2、重建索引只作用于收到请求的实例，其他实例按 search.rebuildInterval 定期重建。
+3、筛选条件中同一条件的多个值满足任一即可(如 category_ids[]=1&category_ids[]=2)，不同条件需要同时满足。
+4、分类、标签、分发类型的游戏数量按除自身以外的条件统计，选中某个分类后仍返回其他分类的数量，用于多选筛选。
+*/
+
+// 搜索游戏(可预约、已上架)
+type SearchGamesReq struct {
+	g.Meta `path:"/games/search" method:"get" tags:"Game Management" summary:"Search Games With Filters"`
+	GameSearchFilter
+	model.PageReq
+}
+
+type SearchGamesRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*Game           `json:"list" dc:"游戏列表"`
+	Facets *GameSearchFacets `json:"facets" dc:"筛选项统计"`
+	*model.PageRes
+}
+
+// 搜索所有状态的游戏
+type AdminSearchGamesReq struct {
+	g.Meta `path:"/admin/games/search" method:"get" tags:"Admin/Search" summary:"Search Games In All Statuses"`
+	model.AuthorRequired
+	GameSearchFilter
+	Statuses []int `json:"statuses" dc:"游戏状态(0:初始状态,1:审核中,2:审核通过,3:可预约,4:已上架,5:已下架)，不传则不限制"`
+	model.PageReq
+}
+
+type AdminSearchGamesRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*Game           `json:"list" dc:"游戏列表"`
+	Facets *GameSearchFacets `json:"facets" dc:"筛选项统计"`
+	*model.PageRes
+}
+
+type GameSearchFilter struct {
+	Keyword         string      `json:"keyword" v:"max-length:100#搜索关键词不能超过100个字符" dc:"搜索关键词，不传则返回所有符合条件的游戏"`
+	CategoryIDs     []int64     `json:"category_ids" dc:"分类ID"`
+	TagIDs          []int64     `json:"tag_ids" dc:"标签ID"`
+	DistributeTypes []int       `json:"distribute_types" dc:"分发类型(1:APK,2:H5)"`
+	MinRating       float64     `json:"min_rating" v:"min:0|max:5#平均评分下限必须在0-5之间|平均评分下限必须在0-5之间" dc:"平均评分下限"`
+	MaxRating       float64     `json:"max_rating" v:"min:0|max:5#平均评分上限必须在0-5之间|平均评分上限必须在0-5之间" dc:"平均评分上限"`
+	PublishFrom     *gtime.Time `json:"publish_from" dc:"发布时间下限"`
+	PublishTo       *gtime.Time `json:"publish_to" dc:"发布时间上限"`
+	Developer       string      `json:"developer" dc:"开发商(忽略大小写完全匹配)"`
+	Sort            string      `json:"sort" d:"relevance" v:"in:relevance,newest,rating,downloads,hot#排序方式不正确" dc:"排序方式(relevance:相关度,newest:最新发布,rating:评分最高,downloads:下载最多,hot:最热门)"`
+}
+
+// 搜索建议
+type SuggestSearchReq struct {
+	g.Meta `path:"/games/search/suggest" method:"get" tags:"Game Management" summary:"Search Suggestions"`
+	Q      string `json:"q" v:"max-length:100#输入内容不能超过100个字符" dc:"已输入的内容，按前缀匹配"`
+	Limit  int    `json:"limit" d:"10" v:"between:1,20#返回数量必须在1-20之间" dc:"返回数量"`
+}
+
+type SuggestSearchRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*SearchSuggestion `json:"list" dc:"搜索建议"`
+}
+
+type SearchSuggestion struct {
+	Text   string `json:"text" dc:"建议的搜索词"`
+	Type   string `json:"type" dc:"来源(game:游戏名称,developer:开发商,query:热门搜索词)"`
+	GameID int64  `json:"game_id" dc:"游戏ID，来源为游戏名称时有效"`
+}
+
+type GameSearchFacets struct {
+	Categories      []*GameSearchFacet `json:"categories" dc:"分类"`
+	Tags            []*GameSearchFacet `json:"tags" dc:"标签"`
+	DistributeTypes []*GameSearchFacet `json:"distribute_types" dc:"分发类型"`
+}
+
+type GameSearchFacet struct {
+	Value int64  `json:"value" dc:"分类ID、标签ID或分发类型"`
+	Name  string `json:"name" dc:"名称"`
+	Count int    `json:"count" dc:"游戏数量"`
+}
+
+// 重建搜索索引
+type RebuildSearchIndexReq struct {
+	g.Meta `path:"/admin/search/rebuild" method:"post" tags:"Admin/Search" summary:"Rebuild Search Index"`
+	model.AuthorRequired
+}
+
+type RebuildSearchIndexRes struct {
+	g.Meta `mime:"application/json"`
+	*SearchIndexStats
+}
+
+// 查询搜索索引状态
+type GetSearchIndexStatsReq struct {
+	g.Meta `path:"/admin/search/stats" method:"get" tags:"Admin/Search" summary:"Get Search Index Stats"`
+	model.AuthorRequired
+}
+
+type GetSearchIndexStatsRes struct {
+	g.Meta `mime:"application/json"`
+	*SearchIndexStats
+}
+
+type SearchIndexStats struct {
+	Documents   int         `json:"documents" dc:"已索引的游戏数量"`
+	Terms       int         `json:"terms" dc:"词项数量"`
+	Dictionary  int         `json:"dictionary" dc:"分词词典的词数"`
+	BuildTime   *gtime.Time `json:"build_time" dc:"最近一次重建时间"`
+	Duration    string      `json:"duration" dc:"最近一次重建耗时"`
+	Suggestions int         `json:"suggestions" dc:"搜索建议的数量"`
+}
@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"GameEngine/internal/model"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+/*
+敏感词
+1、词库由管理员维护，修改后立即生效；多实例部署时其他实例定期检查变更后热更新。
+2、匹配前统一全角/半角、大小写、繁体/简体，并忽略夹在敏感词中间的空白和标点。
+*/
+
+// 创建敏感词
+type CreateSensitiveWordReq struct {
+	g.Meta `path:"/admin/sensitive-words" method:"post" tags:"Admin/SensitiveWord" summary:"Create Sensitive Word"`
+	model.AuthorRequired
+	Word     string `json:"word" v:"required|length:1,64#敏感词不能为空|敏感词长度不能超过64个字符" dc:"敏感词"`
+	Category string `json:"category" v:"length:0,32#分类长度不能超过32个字符" dc:"分类"`
+	Enabled  bool   `json:"enabled" d:"true" dc:"是否启用"`
+}
+
+type CreateSensitiveWordRes struct {
+	g.Meta `mime:"application/json"`
+	ID     int64 `json:"id" dc:"敏感词ID"`
+}
+
+// 更新敏感词
+type UpdateSensitiveWordReq struct {
+	g.Meta `path:"/admin/sensitive-words/{id}" method:"put" tags:"Admin/SensitiveWord" summary:"Update Sensitive Word"`
+	model.AuthorRequired
+	ID       int64  `p:"id" v:"required#敏感词ID不能为空" dc:"敏感词ID"`
+	Category string `json:"category" v:"length:0,32#分类长度不能超过32个字符" dc:"分类"`
+	Enabled  bool   `json:"enabled" dc:"是否启用"`
+}
+
+type UpdateSensitiveWordRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 删除敏感词
+type DeleteSensitiveWordReq struct {
+	g.Meta `path:"/admin/sensitive-words/{id}" method:"delete" tags:"Admin/SensitiveWord" summary:"Delete Sensitive Word"`
+	model.AuthorRequired
+	ID int64 `p:"id" v:"required#敏感词ID不能为空" dc:"敏感词ID"`
+}
+
+type DeleteSensitiveWordRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 获取敏感词列表
+type ListSensitiveWordReq struct {
+	g.Meta `path:"/admin/sensitive-words" method:"get" tags:"Admin/SensitiveWord" summary:"List Sensitive Words"`
+	model.AuthorRequired
+	model.PageReq
+	Keyword string `json:"keyword" dc:"关键词"`
+}
+
+type ListSensitiveWordRes struct {
+	g.Meta `mime:"application/json"`
+	List   []*SensitiveWord `json:"list" dc:"敏感词列表"`
+	*model.PageRes
+}
+
+// 重新加载敏感词库
+type ReloadSensitiveWordReq struct {
+	g.Meta `path:"/admin/sensitive-words/reload" method:"post" tags:"Admin/SensitiveWord" summary:"Reload Sensitive Words"`
+	model.AuthorRequired
+}
+
+type ReloadSensitiveWordRes struct {
+	g.Meta `mime:"application/json"`
+}
+
+// 检测文本，用于验证词库效果
+type CheckSensitiveWordReq struct {
+	g.Meta `path:"/admin/sensitive-words/check" method:"post" tags:"Admin/SensitiveWord" summary:"Check Text"`
+	model.AuthorRequired
+	Text string `json:"text" v:"required#文本不能为空" dc:"待检测文本"`
+}
+
+type CheckSensitiveWordRes struct {
+	g.Meta `mime:"application/json"`
+	Hits   []string `json:"hits" dc:"命中的敏感词"`
+	Masked string   `json:"masked" dc:"打码后的文本"`
+}
+
+type SensitiveWord struct {
+	ID         int64       `json:"id" dc:"敏感词ID"`
+	Word       string      `json:"word" dc:"敏感词"`
+	Category   string      `json:"category" dc:"分类"`
+	Enabled    bool        `json:"enabled" dc:"是否启用"`
+	CreateTime *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime *gtime.Time `json:"update_time" dc:"更新时间"`
+}
@@ -20,6 +20,9 @@ CREATE TABLE IF NOT EXISTS `t_tag` (
     UNIQUE KEY `idx_name` (`name`)
 ) ENGINE=InnoDB COMMENT='标签表';
 
+ALTER TABLE `t_tag` ADD COLUMN `pinyin` VARCHAR(2048) NOT NULL DEFAULT '' COMMENT '标签名全拼(多音字的各种读法以空格分隔)' AFTER `name`;
+ALTER TABLE `t_tag` ADD COLUMN `initials` VARCHAR(2048) NOT NULL DEFAULT '' COMMENT '标签名拼音首字母(以空格分隔)' AFTER `pinyin`;
+
 CREATE TABLE IF NOT EXISTS `t_game` (
     `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
     `name` VARCHAR(255) NOT NULL COMMENT '游戏名称',
@@ -47,6 +50,10 @@ CREATE TABLE IF NOT EXISTS `t_game` (
 ) ENGINE=InnoDB COMMENT='游戏表';
 
 ALTER TABLE `t_game` ADD COLUMN `version` INT(11) DEFAULT 0 COMMENT '并发版本控制' AFTER `download_count`;
+ALTER TABLE `t_game` ADD COLUMN `name_pinyin` VARCHAR(2048) NOT NULL DEFAULT '' COMMENT '名称全拼(多音字的各种读法以空格分隔)' AFTER `details`;
+ALTER TABLE `t_game` ADD COLUMN `name_initials` VARCHAR(2048) NOT NULL DEFAULT '' COMMENT '名称拼音首字母(以空格分隔)' AFTER `name_pinyin`;
+ALTER TABLE `t_game` ADD COLUMN `developer_pinyin` VARCHAR(2048) NOT NULL DEFAULT '' COMMENT '开发商全拼(以空格分隔)' AFTER `name_initials`;
+ALTER TABLE `t_game` ADD COLUMN `developer_initials` VARCHAR(2048) NOT NULL DEFAULT '' COMMENT '开发商拼音首字母(以空格分隔)' AFTER `developer_pinyin`;
 
 CREATE TABLE IF NOT EXISTS `t_game_media_info` (
     `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
@@ -54,7 +61,10 @@ CREATE TABLE IF NOT EXISTS `t_game_media_info` (
     `file_id` VARCHAR(255) NOT NULL COMMENT '文件ID',
     `media_type` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '媒体类型',
     `media_url` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '媒体URL',
-    `status` TINYINT(1) NOT NULL COMMENT '状态',
+    `file_size` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '文件大小(字节)',
+    `width` INT(11) NOT NULL DEFAULT 0 COMMENT '宽度(像素，图片/视频)',
+    `height` INT(11) NOT NULL DEFAULT 0 COMMENT '高度(像素，图片/视频)',
+    `status` TINYINT(1) NOT NULL COMMENT '状态(1:初始化,2:成功,3:失败,4:安全扫描中,5:存在安全风险,6:安全扫描通过)',
     `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     PRIMARY KEY (`id`),
@@ -62,6 +72,10 @@ CREATE TABLE IF NOT EXISTS `t_game_media_info` (
     UNIQUE KEY `idx_file_id` (`file_id`)
 ) ENGINE=InnoDB COMMENT='游戏媒体信息表';
 
+ALTER TABLE `t_game_media_info` ADD COLUMN `file_size` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '文件大小(字节)' AFTER `media_url`;
+ALTER TABLE `t_game_media_info` ADD COLUMN `width` INT(11) NOT NULL DEFAULT 0 COMMENT '宽度(像素，图片/视频)' AFTER `file_size`;
+ALTER TABLE `t_game_media_info` ADD COLUMN `height` INT(11) NOT NULL DEFAULT 0 COMMENT '高度(像素，图片/视频)' AFTER `width`;
+
 CREATE TABLE IF NOT EXISTS `t_game_category` (
     `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
     `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
@@ -131,8 +145,10 @@ CREATE TABLE IF NOT EXISTS `t_user_behavior` (
     PRIMARY KEY (`id`),
     KEY `idx_user_id_type` (`user_id`, `behavior_type`),
     KEY `idx_game_id` (`game_id`),
-    KEY `idx_behavior_time` (`behavior_time`)
+    KEY `idx_behavior_time` (`behavior_time`),
+    KEY `idx_type_time` (`behavior_type`, `behavior_time`)
 ) ENGINE=InnoDB COMMENT='用户行为记录表';
+ALTER TABLE `t_user_behavior` ADD KEY `idx_type_time` (`behavior_type`, `behavior_time`);
 
 INSERT INTO `t_game` (`name`, `distribute_type`, `developer`, `publisher`, `description`, `details`) VALUES ('测试游戏1', 1, '测试开发商1', '测试发行商1', '测试描述', '测试详情');
 INSERT INTO `t_game` (`name`, `distribute_type`, `developer`, `publisher`, `description`, `details`) VALUES ('测试游戏2', 1, '测试开发商2', '测试发行商2', '测试描述', '测试详情');
@@ -183,15 +199,300 @@ CREATE TABLE IF NOT EXISTS `t_async_task` (
   `id` BIGINT(20) AUTO_INCREMENT NOT NULL COMMENT '主键ID',
   `custom_id` VARCHAR(40) DEFAULT '' COMMENT '自定义任务ID',
   `task_type` TINYINT(1) NOT NULL COMMENT '任务类型',
-  `status` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '任务状态',
+  `status` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '任务状态(0:待执行,1:执行中,2:执行成功,3:执行失败等待重试,4:重试耗尽,5:已取消)',
   `retry_count` INT(11) NOT NULL DEFAULT 0 COMMENT '重试次数',
   `content` TEXT NOT NULL COMMENT '任务内容',
   `version` INT(11) NOT NULL DEFAULT 0 COMMENT '版本标识',
   `next_retry_time` BIGINT(20) NOT NULL COMMENT '下次处理时间(默认等于创建时间)',
+  `last_error` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '最近一次执行失败的错误信息',
+  `last_error_stack` TEXT COMMENT '最近一次执行失败的调用栈',
+  `lease_owner` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '领取任务的实例和领取标识',
+  `lease_expire_time` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '租约到期时间，执行中的任务到期未续约时被回收',
+  `active_custom_id` VARCHAR(40) GENERATED ALWAYS AS (IF(`status` IN (0, 3) AND `custom_id` <> '', `custom_id`, NULL)) STORED COMMENT '等待执行(待执行、执行失败等待重试)时等于自定义任务ID，用于保证同一自定义任务ID只有一个等待执行的任务',
   `create_time` BIGINT(20) NOT NULL COMMENT '创建时间',
   `update_time` BIGINT(20) NOT NULL COMMENT '更新时间',
   PRIMARY KEY (`id`),
   KEY `idx_custom_id` (`custom_id`),
   KEY `idx_type_status_time` (`task_type`, `status`, `next_retry_time`),
-  KEY `idx_status_time` (`status`, `next_retry_time`)
-) ENGINE=InnoDB COMMENT='异步任务表';
\ No newline at end of file
+  KEY `idx_status_time` (`status`, `next_retry_time`),
+  KEY `idx_status_lease` (`status`, `lease_expire_time`),
+  UNIQUE KEY `uk_type_active_custom_id` (`task_type`, `active_custom_id`)
+) ENGINE=InnoDB COMMENT='异步任务表';
+
+ALTER TABLE `t_async_task` ADD COLUMN `last_error` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '最近一次执行失败的错误信息' AFTER `next_retry_time`;
+ALTER TABLE `t_async_task` ADD COLUMN `last_error_stack` TEXT COMMENT '最近一次执行失败的调用栈' AFTER `last_error`;
+ALTER TABLE `t_async_task` ADD COLUMN `lease_owner` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '领取任务的实例和领取标识' AFTER `last_error_stack`;
+ALTER TABLE `t_async_task` ADD COLUMN `lease_expire_time` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '租约到期时间，执行中的任务到期未续约时被回收' AFTER `lease_owner`;
+ALTER TABLE `t_async_task` ADD KEY `idx_status_lease` (`status`, `lease_expire_time`);
+-- 旧版本创建的自动发布任务没有自定义任务ID，补齐后取消重复预约产生的多余任务，再添加唯一索引
+UPDATE `t_async_task` SET `custom_id` = CONCAT('game_auto_publish_', CAST(JSON_EXTRACT(`content`, '$.game_id') AS UNSIGNED))
+  WHERE `task_type` = 1 AND `custom_id` = '' AND `status` IN (0, 3);
+UPDATE `t_async_task` t JOIN (
+    SELECT `task_type`, `custom_id`, MAX(`id`) AS `max_id` FROM `t_async_task`
+    WHERE `status` IN (0, 3) AND `custom_id` <> '' GROUP BY `task_type`, `custom_id` HAVING COUNT(1) > 1
+  ) d ON t.`task_type` = d.`task_type` AND t.`custom_id` = d.`custom_id`
+  SET t.`status` = 5, t.`version` = t.`version` + 1
+  WHERE t.`status` IN (0, 3) AND t.`id` < d.`max_id`;
+ALTER TABLE `t_async_task` ADD COLUMN `active_custom_id` VARCHAR(40) GENERATED ALWAYS AS (IF(`status` IN (0, 3) AND `custom_id` <> '', `custom_id`, NULL)) STORED COMMENT '等待执行(待执行、执行失败等待重试)时等于自定义任务ID，用于保证同一自定义任务ID只有一个等待执行的任务' AFTER `lease_expire_time`;
+ALTER TABLE `t_async_task` ADD UNIQUE KEY `uk_type_active_custom_id` (`task_type`, `active_custom_id`);
+
+CREATE TABLE IF NOT EXISTS `t_recurring_task` (
+  `id` BIGINT(20) AUTO_INCREMENT NOT NULL COMMENT '主键ID',
+  `name` VARCHAR(30) NOT NULL COMMENT '名称，与代码中注册的名称一致',
+  `cron_spec` VARCHAR(64) NOT NULL COMMENT 'cron表达式',
+  `paused` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否暂停(0:否,1:是)',
+  `next_run_time` BIGINT(20) NOT NULL COMMENT '下次执行时间',
+  `last_run_time` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '最近一次执行时间',
+  `version` INT(11) NOT NULL DEFAULT 0 COMMENT '版本标识',
+  `create_time` BIGINT(20) NOT NULL COMMENT '创建时间',
+  `update_time` BIGINT(20) NOT NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_name` (`name`)
+) ENGINE=InnoDB COMMENT='周期任务表，每次执行生成一条异步任务';
+
+-- SLA检查改为周期任务(game_sla_check)，取消旧版本自行安排的SLA检查任务
+UPDATE `t_async_task` SET `status` = 5, `version` = `version` + 1 WHERE `task_type` = 4 AND `status` IN (0, 1, 3);
+
+CREATE TABLE IF NOT EXISTS `t_outbox` (
+  `id` BIGINT(20) AUTO_INCREMENT NOT NULL COMMENT '主键ID',
+  `topic` VARCHAR(128) NOT NULL COMMENT '消息队列主题',
+  `event_type` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '领域事件类型，非领域事件的消息为空',
+  `dedup_key` VARCHAR(128) NOT NULL COMMENT '去重键，同一事件只写入一次，发送时作为消息ID供消费方去重',
+  `payload` TEXT NOT NULL COMMENT '消息体(JSON)',
+  `status` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '状态(0:待发送,1:已发送,2:重试耗尽)',
+  `retry_count` INT(11) NOT NULL DEFAULT 0 COMMENT '发送失败次数',
+  `next_retry_time` BIGINT(20) NOT NULL COMMENT '下次发送时间',
+  `last_error` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '最近一次发送失败的错误信息',
+  `lease_owner` VARCHAR(64) NOT NULL DEFAULT '' COMMENT '领取消息的实例和领取标识',
+  `lease_expire_time` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '租约到期时间，到期未发送完成的消息可以被重新领取',
+  `publish_time` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '发送成功时间',
+  `create_time` BIGINT(20) NOT NULL COMMENT '创建时间',
+  `update_time` BIGINT(20) NOT NULL COMMENT '更新时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_dedup_key` (`dedup_key`),
+  KEY `idx_status_time` (`status`, `next_retry_time`),
+  KEY `idx_lease_owner` (`lease_owner`),
+  KEY `idx_status_publish_time` (`status`, `publish_time`)
+) ENGINE=InnoDB COMMENT='事务性发件箱，与业务数据在同一事务中写入，由发送线程发送到消息队列';
+
+CREATE TABLE IF NOT EXISTS `t_mq_consumed_message` (
+  `id` BIGINT(20) AUTO_INCREMENT NOT NULL COMMENT '主键ID',
+  `consumer` VARCHAR(128) NOT NULL COMMENT '消费者(channel:主题)',
+  `message_id` VARCHAR(128) NOT NULL COMMENT '消息ID，超长时为MD5',
+  `create_time` BIGINT(20) NOT NULL COMMENT '处理时间',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_consumer_message_id` (`consumer`, `message_id`),
+  KEY `idx_create_time` (`create_time`)
+) ENGINE=InnoDB COMMENT='已处理的消息，与处理结果在同一事务中写入，用于消息去重';
+
+CREATE TABLE IF NOT EXISTS `t_game_status_history` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `from_status` TINYINT(1) NOT NULL COMMENT '变更前状态',
+    `to_status` TINYINT(1) NOT NULL COMMENT '变更后状态',
+    `event` TINYINT(1) NOT NULL COMMENT '触发事件',
+    `operator_id` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '操作人ID(0:系统)',
+    `operator_name` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '操作人名称',
+    `reason` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '原因(审核拒绝原因、下架原因等)',
+    `event_data` TEXT COMMENT '事件数据',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_game_id_create_time` (`game_id`, `create_time`)
+) ENGINE=InnoDB COMMENT='游戏状态变更历史表';
+
+-- 已上架/可预约游戏的修改先写入草稿，审核通过后再原子地覆盖线上数据
+CREATE TABLE IF NOT EXISTS `t_game_draft` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `name` VARCHAR(255) NOT NULL COMMENT '游戏名称',
+    `distribute_type` TINYINT(1) NOT NULL COMMENT '游戏分发类型',
+    `developer` VARCHAR(255) NOT NULL COMMENT '开发商',
+    `publisher` VARCHAR(255) NOT NULL COMMENT '发行商',
+    `description` TEXT COMMENT '游戏描述',
+    `details` TEXT COMMENT '游戏详情',
+    `category_id` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '分类ID',
+    `tag_ids` VARCHAR(1024) NOT NULL DEFAULT '[]' COMMENT '标签ID列表(JSON)',
+    `status` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '草稿状态(0:编辑中,1:审核中)',
+    `version` INT(11) NOT NULL DEFAULT 0 COMMENT '并发版本控制',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_game_id` (`game_id`),
+    KEY `idx_status` (`status`)
+) ENGINE=InnoDB COMMENT='游戏草稿表';
+
+CREATE TABLE IF NOT EXISTS `t_game_draft_media_info` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `file_id` VARCHAR(255) NOT NULL COMMENT '文件ID',
+    `media_type` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '媒体类型',
+    `media_url` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '媒体URL',
+    `file_size` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '文件大小(字节)',
+    `width` INT(11) NOT NULL DEFAULT 0 COMMENT '宽度(像素，图片/视频)',
+    `height` INT(11) NOT NULL DEFAULT 0 COMMENT '高度(像素，图片/视频)',
+    `status` TINYINT(1) NOT NULL COMMENT '状态(1:初始化,2:成功,3:失败,4:安全扫描中,5:存在安全风险,6:安全扫描通过)',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_game_id_type` (`game_id`, `media_type`),
+    KEY `idx_file_id` (`file_id`)
+) ENGINE=InnoDB COMMENT='游戏草稿媒体信息表';
+
+-- APK游戏的构建版本，每个版本有独立的审核状态，支持切换当前版本和回滚
+CREATE TABLE IF NOT EXISTS `t_game_build` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `version_name` VARCHAR(64) NOT NULL COMMENT '版本名称',
+    `version_code` BIGINT(20) NOT NULL COMMENT '版本号',
+    `release_notes` TEXT COMMENT '更新说明',
+    `file_id` VARCHAR(255) NOT NULL COMMENT '文件ID',
+    `file_size` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '文件大小',
+    `checksum` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '文件校验和',
+    `media_url` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '文件URL',
+    `file_status` TINYINT(1) NOT NULL DEFAULT 1 COMMENT '文件上传状态(1:初始化,2:成功,3:失败,4:安全扫描中,5:存在安全风险,6:安全扫描通过)',
+    `status` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '审核状态(0:待提交,1:审核中,2:审核通过,3:审核失败)',
+    `reject_reason` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '拒绝原因',
+    `is_current` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否为当前版本',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_game_id_version_code` (`game_id`, `version_code`),
+    KEY `idx_file_id` (`file_id`)
+) ENGINE=InnoDB COMMENT='游戏构建版本表';
+
+-- 审核拒绝原因目录，由管理员维护，审核人员拒绝时从中选择
+CREATE TABLE IF NOT EXISTS `t_review_reject_code` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `code` VARCHAR(64) NOT NULL COMMENT '拒绝原因编码',
+    `title` VARCHAR(255) NOT NULL COMMENT '标题',
+    `description` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '描述',
+    `field` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '默认关联字段',
+    `enabled` TINYINT(1) NOT NULL DEFAULT 1 COMMENT '是否启用',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_code` (`code`)
+) ENGINE=InnoDB COMMENT='审核拒绝原因表';
+
+-- 审核问题，审核拒绝时按字段记录，开发者修复后标记为已解决
+CREATE TABLE IF NOT EXISTS `t_game_review_issue` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `code` VARCHAR(64) NOT NULL COMMENT '拒绝原因编码',
+    `field` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '问题字段',
+    `comment` TEXT COMMENT '审核意见',
+    `status` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '问题状态(0:未解决,1:已解决)',
+    `reviewer_id` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '审核人ID',
+    `reviewer_name` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '审核人名称',
+    `resolved_by` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '解决人ID',
+    `resolve_time` DATETIME DEFAULT NULL COMMENT '解决时间',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_game_id_status` (`game_id`, `status`)
+) ENGINE=InnoDB COMMENT='游戏审核问题表';
+
+-- 审核问题的讨论记录，审核人员和开发者都可以回复
+CREATE TABLE IF NOT EXISTS `t_game_review_comment` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `issue_id` BIGINT(20) NOT NULL COMMENT '问题ID',
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `user_id` BIGINT(20) NOT NULL COMMENT '用户ID',
+    `user_name` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '用户名',
+    `content` TEXT NOT NULL COMMENT '内容',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_issue_id` (`issue_id`),
+    KEY `idx_game_id` (`game_id`)
+) ENGINE=InnoDB COMMENT='游戏审核问题讨论表';
+
+-- 审核领取记录，审核人员领取游戏后在租约期内独占审核，租约到期由异步任务自动释放
+CREATE TABLE IF NOT EXISTS `t_game_review_claim` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `reviewer_id` BIGINT(20) NOT NULL COMMENT '审核人ID',
+    `reviewer_name` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '审核人名称',
+    `lease_expire_time` DATETIME NOT NULL COMMENT '租约到期时间',
+    `version` INT(11) NOT NULL DEFAULT 0 COMMENT '并发版本控制',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_game_id` (`game_id`),
+    KEY `idx_reviewer_id` (`reviewer_id`)
+) ENGINE=InnoDB COMMENT='游戏审核领取表';
+
+-- 本轮审核中已通过的审核人员，开启双人审核时需要两名不同的审核人员通过
+CREATE TABLE IF NOT EXISTS `t_game_review_approval` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `reviewer_id` BIGINT(20) NOT NULL COMMENT '审核人ID',
+    `reviewer_name` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '审核人名称',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_game_id_reviewer_id` (`game_id`, `reviewer_id`)
+) ENGINE=InnoDB COMMENT='游戏审核通过记录表';
+
+-- 游戏在每个状态的停留区间，用于统计审核时长和SLA超时告警
+CREATE TABLE IF NOT EXISTS `t_game_status_period` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `status` TINYINT(1) NOT NULL COMMENT '状态',
+    `enter_time` DATETIME NOT NULL COMMENT '进入状态时间',
+    `leave_time` DATETIME DEFAULT NULL COMMENT '离开状态时间(NULL表示仍处于该状态)',
+    `duration` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '停留时长(秒)',
+    `overdue_alerted` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否已发送超时告警',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_game_id_leave_time` (`game_id`, `leave_time`),
+    KEY `idx_status_leave_time` (`status`, `leave_time`)
+) ENGINE=InnoDB COMMENT='游戏状态停留区间表';
+
+-- 提交审核时自动检查的结果，只保存警告，阻断性问题直接拒绝提交
+CREATE TABLE IF NOT EXISTS `t_game_validation_finding` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `draft` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否为草稿提交',
+    `validator` VARCHAR(64) NOT NULL COMMENT '检查项',
+    `severity` TINYINT(1) NOT NULL COMMENT '严重程度(1:警告,2:阻断)',
+    `field` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '问题字段',
+    `message` VARCHAR(512) NOT NULL DEFAULT '' COMMENT '问题描述',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    PRIMARY KEY (`id`),
+    KEY `idx_game_id_draft` (`game_id`, `draft`)
+) ENGINE=InnoDB COMMENT='游戏提交审核检查结果表';
+
+-- 敏感词库，由管理员维护，服务定期检查变更并热更新
+CREATE TABLE IF NOT EXISTS `t_sensitive_word` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `word` VARCHAR(64) NOT NULL COMMENT '敏感词',
+    `category` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '分类(如涉政、色情、赌博、广告)',
+    `enabled` TINYINT(1) NOT NULL DEFAULT 1 COMMENT '是否启用',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_word` (`word`)
+) ENGINE=InnoDB COMMENT='敏感词表';
+
+-- APK安装包解析结果，文件上传成功后异步解析，提交审核时校验包名和签名证书
+CREATE TABLE IF NOT EXISTS `t_game_apk_info` (
+    `id` BIGINT(20) NOT NULL AUTO_INCREMENT,
+    `game_id` BIGINT(20) NOT NULL COMMENT '游戏ID',
+    `file_id` VARCHAR(255) NOT NULL COMMENT '文件ID',
+    `status` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '解析状态(0:解析中,1:解析成功,2:解析失败)',
+    `package_name` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '包名',
+    `version_code` BIGINT(20) NOT NULL DEFAULT 0 COMMENT '版本号(versionCode)',
+    `version_name` VARCHAR(128) NOT NULL DEFAULT '' COMMENT '版本名称(versionName)',
+    `min_sdk` INT(11) NOT NULL DEFAULT 0 COMMENT '最低SDK版本',
+    `target_sdk` INT(11) NOT NULL DEFAULT 0 COMMENT '目标SDK版本',
+    `permissions` TEXT COMMENT '申请的权限(JSON数组)',
+    `signature_scheme` VARCHAR(8) NOT NULL DEFAULT '' COMMENT '签名方案(v1/v2/v3)',
+    `cert_sha256` CHAR(64) NOT NULL DEFAULT '' COMMENT '签名证书SHA-256指纹',
+    `cert_subject` VARCHAR(512) NOT NULL DEFAULT '' COMMENT '签名证书主题',
+    `error` VARCHAR(512) NOT NULL DEFAULT '' COMMENT '解析失败原因',
+    `approve_time` DATETIME DEFAULT NULL COMMENT '审核通过时间(NULL表示未审核通过)，最近审核通过的安装包作为后续版本的比对基准',
+    `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_file_id` (`file_id`),
+    KEY `idx_game_id_approve_time` (`game_id`, `approve_time`)
+) ENGINE=InnoDB COMMENT='APK安装包解析结果表';
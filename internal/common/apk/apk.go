@@ -0,0 +1,92 @@
+// Package apk 解析APK安装包：二进制 AndroidManifest.xml 中的包信息，以及 v1/v2/v3 签名证书
+package apk
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	SignatureSchemeV1 = "v1"
+	SignatureSchemeV2 = "v2"
+	SignatureSchemeV3 = "v3"
+)
+
+var (
+	ErrManifestNotFound  = errors.New("AndroidManifest.xml不存在")
+	ErrSignatureNotFound = errors.New("未找到签名信息")
+)
+
+// maxManifestSize AndroidManifest.xml 解压后的大小上限，防止压缩炸弹
+const maxManifestSize = 8 << 20
+
+// Info APK解析结果
+type Info struct {
+	PackageName      string
+	VersionCode      int64
+	VersionName      string
+	MinSdkVersion    int
+	TargetSdkVersion int
+	Permissions      []string
+	SignatureScheme  string // 证书来源的签名方案，同时存在多个方案时优先使用 v2/v3
+	Certificate      *Certificate
+}
+
+// Certificate 签名证书，只提取证书用于比对，不校验签名本身
+type Certificate struct {
+	SHA256  string // DER编码证书的SHA-256指纹(小写十六进制)
+	Subject string
+}
+
+// Parse 解析APK文件
+func Parse(r io.ReaderAt, size int64) (info *Info, err error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("不是有效的APK文件: %w", err)
+	}
+
+	info, err = parseManifestFile(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先查找APK签名块(v2/v3)，没有时再读取 META-INF 下的 v1 签名
+	scheme, cert, err := parseSigningBlock(r, size)
+	if err != nil && !errors.Is(err, ErrSignatureNotFound) {
+		return nil, err
+	}
+	if cert == nil {
+		scheme, cert, err = parseV1Signature(zr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	info.SignatureScheme = scheme
+	info.Certificate = cert
+	return info, nil
+}
+
+func parseManifestFile(zr *zip.Reader) (info *Info, err error) {
+	for _, f := range zr.File {
+		if f.Name != "AndroidManifest.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("读取AndroidManifest.xml失败: %w", err)
+		}
+		defer rc.Close()
+
+		data, err := io.ReadAll(io.LimitReader(rc, maxManifestSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("读取AndroidManifest.xml失败: %w", err)
+		}
+		if len(data) > maxManifestSize {
+			return nil, fmt.Errorf("AndroidManifest.xml过大")
+		}
+		return parseManifest(data)
+	}
+	return nil, ErrManifestNotFound
+}
@@ -0,0 +1,264 @@
+package apk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf16"
+)
+
+// 二进制XML(AXML)的块类型，参见 AOSP frameworks/base/libs/androidfw/include/androidfw/ResourceTypes.h
+const (
+	chunkStringPool   = 0x0001
+	chunkXML          = 0x0003
+	chunkXMLStartElem = 0x0102
+	chunkXMLResMap    = 0x0180
+
+	stringPoolUTF8Flag = 1 << 8
+	noEntry            = 0xFFFFFFFF
+)
+
+// Res_value 的数据类型
+const (
+	valueTypeReference = 0x01
+	valueTypeString    = 0x03
+	valueTypeIntDec    = 0x10
+	valueTypeIntHex    = 0x11
+)
+
+// android 命名空间下属性的资源ID，加固或混淆后的APK可能去掉属性名，只能通过资源ID识别
+const (
+	attrName             = 0x01010003
+	attrMinSdkVersion    = 0x0101020c
+	attrVersionCode      = 0x0101021b
+	attrVersionName      = 0x0101021c
+	attrTargetSdkVersion = 0x01010270
+)
+
+var attrNamesByResID = map[uint32]string{
+	attrName:             "name",
+	attrMinSdkVersion:    "minSdkVersion",
+	attrVersionCode:      "versionCode",
+	attrVersionName:      "versionName",
+	attrTargetSdkVersion: "targetSdkVersion",
+}
+
+var errMalformedManifest = errors.New("AndroidManifest.xml格式错误")
+
+// attrValue 属性值，字符串类型时 str 有效，整数类型时 num 有效
+type attrValue struct {
+	str   string
+	num   int64
+	isNum bool
+}
+
+type manifestParser struct {
+	strings []string
+	resIDs  []uint32
+}
+
+func parseManifest(data []byte) (info *Info, err error) {
+	if len(data) < 8 || binary.LittleEndian.Uint16(data) != chunkXML {
+		return nil, errMalformedManifest
+	}
+
+	p := &manifestParser{}
+	info = &Info{}
+	seenPermissions := make(map[string]bool)
+	foundManifest := false
+
+	offset := int(binary.LittleEndian.Uint16(data[2:]))
+	for offset+8 <= len(data) {
+		chunkType := binary.LittleEndian.Uint16(data[offset:])
+		headerSize := int(binary.LittleEndian.Uint16(data[offset+2:]))
+		chunkSize := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		if chunkSize < 8 || headerSize > chunkSize || offset+chunkSize > len(data) {
+			return nil, errMalformedManifest
+		}
+		chunk := data[offset : offset+chunkSize]
+
+		switch chunkType {
+		case chunkStringPool:
+			if err = p.parseStringPool(chunk); err != nil {
+				return nil, err
+			}
+		case chunkXMLResMap:
+			for i := headerSize; i+4 <= len(chunk); i += 4 {
+				p.resIDs = append(p.resIDs, binary.LittleEndian.Uint32(chunk[i:]))
+			}
+		case chunkXMLStartElem:
+			name, attrs, err := p.parseStartElement(chunk, headerSize)
+			if err != nil {
+				return nil, err
+			}
+			switch name {
+			case "manifest":
+				foundManifest = true
+				info.PackageName = attrs["package"].str
+				info.VersionCode = attrs["versionCode"].num
+				info.VersionName = attrs["versionName"].str
+			case "uses-sdk":
+				info.MinSdkVersion = int(attrs["minSdkVersion"].num)
+				info.TargetSdkVersion = int(attrs["targetSdkVersion"].num)
+			case "uses-permission", "uses-permission-sdk-23":
+				permission := attrs["name"].str
+				if permission != "" && !seenPermissions[permission] {
+					seenPermissions[permission] = true
+					info.Permissions = append(info.Permissions, permission)
+				}
+			}
+		}
+		offset += chunkSize
+	}
+
+	if !foundManifest || info.PackageName == "" {
+		return nil, fmt.Errorf("AndroidManifest.xml中缺少包名")
+	}
+	// 未声明 targetSdkVersion 时与 minSdkVersion 相同
+	if info.TargetSdkVersion == 0 {
+		info.TargetSdkVersion = info.MinSdkVersion
+	}
+	return info, nil
+}
+
+func (p *manifestParser) parseStringPool(chunk []byte) (err error) {
+	if len(chunk) < 28 {
+		return errMalformedManifest
+	}
+	headerSize := int(binary.LittleEndian.Uint16(chunk[2:]))
+	count := int(binary.LittleEndian.Uint32(chunk[8:]))
+	flags := binary.LittleEndian.Uint32(chunk[16:])
+	stringsStart := int(binary.LittleEndian.Uint32(chunk[20:]))
+	if headerSize+count*4 > len(chunk) || stringsStart > len(chunk) {
+		return errMalformedManifest
+	}
+
+	p.strings = make([]string, count)
+	for i := 0; i < count; i++ {
+		start := stringsStart + int(binary.LittleEndian.Uint32(chunk[headerSize+i*4:]))
+		if start >= len(chunk) {
+			return errMalformedManifest
+		}
+		if flags&stringPoolUTF8Flag != 0 {
+			p.strings[i], err = decodeUTF8String(chunk[start:])
+		} else {
+			p.strings[i], err = decodeUTF16String(chunk[start:])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeUTF8String 格式：字符数(1或2字节)、字节数(1或2字节)、内容
+func decodeUTF8String(b []byte) (string, error) {
+	_, n := decodeUTF8Length(b)
+	if n == 0 {
+		return "", errMalformedManifest
+	}
+	size, m := decodeUTF8Length(b[n:])
+	if m == 0 || n+m+size > len(b) {
+		return "", errMalformedManifest
+	}
+	return string(b[n+m : n+m+size]), nil
+}
+
+func decodeUTF8Length(b []byte) (length, n int) {
+	if len(b) < 1 {
+		return 0, 0
+	}
+	if b[0]&0x80 == 0 {
+		return int(b[0]), 1
+	}
+	if len(b) < 2 {
+		return 0, 0
+	}
+	return int(b[0]&0x7F)<<8 | int(b[1]), 2
+}
+
+// decodeUTF16String 格式：字符数(2或4字节)、UTF-16LE内容
+func decodeUTF16String(b []byte) (string, error) {
+	if len(b) < 2 {
+		return "", errMalformedManifest
+	}
+	length := int(binary.LittleEndian.Uint16(b))
+	n := 2
+	if length&0x8000 != 0 {
+		if len(b) < 4 {
+			return "", errMalformedManifest
+		}
+		length = (length&0x7FFF)<<16 | int(binary.LittleEndian.Uint16(b[2:]))
+		n = 4
+	}
+	if n+length*2 > len(b) {
+		return "", errMalformedManifest
+	}
+	units := make([]uint16, length)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[n+i*2:])
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+func (p *manifestParser) parseStartElement(chunk []byte, headerSize int) (name string, attrs map[string]attrValue, err error) {
+	if headerSize+20 > len(chunk) {
+		return "", nil, errMalformedManifest
+	}
+	ext := chunk[headerSize:]
+	name = p.getString(binary.LittleEndian.Uint32(ext[4:]))
+	attrStart := int(binary.LittleEndian.Uint16(ext[8:]))
+	attrSize := int(binary.LittleEndian.Uint16(ext[10:]))
+	attrCount := int(binary.LittleEndian.Uint16(ext[12:]))
+	if attrSize < 20 || attrStart+attrSize*attrCount > len(ext) {
+		return "", nil, errMalformedManifest
+	}
+
+	attrs = make(map[string]attrValue, attrCount)
+	for i := 0; i < attrCount; i++ {
+		attr := ext[attrStart+i*attrSize:]
+		nameIndex := binary.LittleEndian.Uint32(attr[4:])
+		rawValue := binary.LittleEndian.Uint32(attr[8:])
+		dataType := attr[15]
+		data := binary.LittleEndian.Uint32(attr[16:])
+
+		key := p.getString(nameIndex)
+		if int(nameIndex) < len(p.resIDs) {
+			if known, ok := attrNamesByResID[p.resIDs[nameIndex]]; ok {
+				key = known
+			}
+		}
+
+		var value attrValue
+		switch {
+		case rawValue != noEntry:
+			value.str = p.getString(rawValue)
+		case dataType == valueTypeString:
+			value.str = p.getString(data)
+		case dataType == valueTypeIntDec || dataType == valueTypeIntHex:
+			value.num = int64(int32(data))
+			value.isNum = true
+			value.str = strconv.FormatInt(value.num, 10)
+		case dataType == valueTypeReference:
+			// 引用资源(如 @string/version_name)，不解析 resources.arsc，保留资源ID
+			value.str = fmt.Sprintf("@0x%08x", data)
+		}
+		// versionCode 等整数属性偶尔以字符串形式写入
+		if !value.isNum && value.str != "" {
+			if num, parseErr := strconv.ParseInt(value.str, 10, 64); parseErr == nil {
+				value.num = num
+				value.isNum = true
+			}
+		}
+		attrs[key] = value
+	}
+	return name, attrs, nil
+}
+
+func (p *manifestParser) getString(index uint32) string {
+	if index == noEntry || int(index) >= len(p.strings) {
+		return ""
+	}
+	return p.strings[index]
+}
@@ -0,0 +1,183 @@
+package apk
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+// axmlAttr 测试用的属性，raw 为原始字符串下标(noEntry 表示没有)，typ/data 为 Res_value
+type axmlAttr struct {
+	name uint32
+	raw  uint32
+	typ  byte
+	data uint32
+}
+
+type axmlElement struct {
+	name  uint32
+	attrs []axmlAttr
+}
+
+// buildManifest 按 AXML 格式拼装 AndroidManifest.xml：文件头、UTF-8 字符串池、资源ID表、开始标签
+func buildManifest(strs []string, resIDs []uint32, elements []axmlElement) []byte {
+	le := binary.LittleEndian
+
+	var data []byte
+	for _, s := range strs {
+		data = append(data, byte(len(s)), byte(len(s)))
+		data = append(data, s...)
+		data = append(data, 0)
+	}
+	pool := make([]byte, 28+len(strs)*4)
+	le.PutUint16(pool, chunkStringPool)
+	le.PutUint16(pool[2:], 28)
+	le.PutUint32(pool[8:], uint32(len(strs)))
+	le.PutUint32(pool[16:], stringPoolUTF8Flag)
+	le.PutUint32(pool[20:], uint32(len(pool)))
+	offset := 0
+	for i, s := range strs {
+		le.PutUint32(pool[28+i*4:], uint32(offset))
+		offset += len(s) + 3
+	}
+	pool = append(pool, data...)
+	le.PutUint32(pool[4:], uint32(len(pool)))
+
+	resMap := make([]byte, 8+len(resIDs)*4)
+	le.PutUint16(resMap, chunkXMLResMap)
+	le.PutUint16(resMap[2:], 8)
+	le.PutUint32(resMap[4:], uint32(len(resMap)))
+	for i, id := range resIDs {
+		le.PutUint32(resMap[8+i*4:], id)
+	}
+
+	body := append(pool, resMap...)
+	for _, element := range elements {
+		chunk := make([]byte, 16+20+len(element.attrs)*20)
+		le.PutUint16(chunk, chunkXMLStartElem)
+		le.PutUint16(chunk[2:], 16)
+		le.PutUint32(chunk[4:], uint32(len(chunk)))
+		le.PutUint32(chunk[12:], noEntry)
+		ext := chunk[16:]
+		le.PutUint32(ext, noEntry)
+		le.PutUint32(ext[4:], element.name)
+		le.PutUint16(ext[8:], 20)
+		le.PutUint16(ext[10:], 20)
+		le.PutUint16(ext[12:], uint16(len(element.attrs)))
+		for i, attr := range element.attrs {
+			a := ext[20+i*20:]
+			le.PutUint32(a, noEntry)
+			le.PutUint32(a[4:], attr.name)
+			le.PutUint32(a[8:], attr.raw)
+			le.PutUint16(a[12:], 8)
+			a[15] = attr.typ
+			le.PutUint32(a[16:], attr.data)
+		}
+		body = append(body, chunk...)
+	}
+
+	header := make([]byte, 8)
+	le.PutUint16(header, chunkXML)
+	le.PutUint16(header[2:], 8)
+	le.PutUint32(header[4:], uint32(8+len(body)))
+	return append(header, body...)
+}
+
+func intAttr(name uint32, value uint32) axmlAttr {
+	return axmlAttr{name: name, raw: noEntry, typ: valueTypeIntDec, data: value}
+}
+
+func stringAttr(name uint32, value uint32) axmlAttr {
+	return axmlAttr{name: name, raw: value, typ: valueTypeString, data: value}
+}
+
+// validManifest 字符串下标 0-4 为属性名，与资源ID表一一对应
+func validManifest(attrNames ...string) []byte {
+	strs := append(attrNames,
+		"manifest", "package", "com.example.game", "1.2.0", "uses-sdk",
+		"uses-permission", "android.permission.INTERNET")
+	resIDs := []uint32{attrVersionCode, attrVersionName, attrMinSdkVersion, attrTargetSdkVersion, attrName}
+	return buildManifest(strs, resIDs, []axmlElement{
+		{name: 5, attrs: []axmlAttr{stringAttr(6, 7), intAttr(0, 120), stringAttr(1, 8)}},
+		{name: 9, attrs: []axmlAttr{intAttr(2, 21), intAttr(3, 34)}},
+		{name: 10, attrs: []axmlAttr{stringAttr(4, 11)}},
+		{name: 10, attrs: []axmlAttr{stringAttr(4, 11)}},
+	})
+}
+
+func TestParseManifest(t *testing.T) {
+	want := &Info{
+		PackageName:      "com.example.game",
+		VersionCode:      120,
+		VersionName:      "1.2.0",
+		MinSdkVersion:    21,
+		TargetSdkVersion: 34,
+		Permissions:      []string{"android.permission.INTERNET"},
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want *Info
+	}{
+		{"正常解析并去除重复权限", validManifest("versionCode", "versionName", "minSdkVersion", "targetSdkVersion", "name"), want},
+		{"混淆后的属性名通过资源ID识别", validManifest("a", "b", "c", "d", "e"), want},
+		{"未声明targetSdkVersion时与minSdkVersion相同", buildManifest(
+			[]string{"minSdkVersion", "manifest", "package", "com.example.game", "uses-sdk"},
+			[]uint32{attrMinSdkVersion},
+			[]axmlElement{
+				{name: 1, attrs: []axmlAttr{stringAttr(2, 3)}},
+				{name: 4, attrs: []axmlAttr{intAttr(0, 24)}},
+			}),
+			&Info{PackageName: "com.example.game", MinSdkVersion: 24, TargetSdkVersion: 24}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseManifest(tt.data)
+			if err != nil {
+				t.Fatalf("parseManifest error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseManifest = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseManifestMalformed(t *testing.T) {
+	valid := validManifest("versionCode", "versionName", "minSdkVersion", "targetSdkVersion", "name")
+
+	withUint32 := func(offset int, value uint32) []byte {
+		data := append([]byte{}, valid...)
+		binary.LittleEndian.PutUint32(data[offset:], value)
+		return data
+	}
+	// 第一个块(字符串池)从文件头之后开始
+	const pool = 8
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"空文件", nil},
+		{"不是二进制XML", []byte("<manifest package=\"com.example.game\"/>")},
+		{"文件被截断", valid[:len(valid)-10]},
+		{"块大小小于块头", withUint32(pool+4, 4)},
+		{"字符串数量超出块大小", withUint32(pool+8, 1<<20)},
+		{"字符串偏移超出块大小", withUint32(pool+28, 1<<20)},
+		{"属性数量超出块大小", func() []byte {
+			data := buildManifest([]string{"manifest"}, nil, []axmlElement{{name: 0}})
+			// 最后一个块是没有属性的开始标签：块头16字节，属性数量在扩展头的第12字节
+			binary.LittleEndian.PutUint16(data[len(data)-20+12:], 10)
+			return data
+		}()},
+		{"缺少manifest标签", buildManifest([]string{"application"}, nil, []axmlElement{{name: 0}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if info, err := parseManifest(tt.data); err == nil {
+				t.Errorf("parseManifest = %+v, want error", info)
+			}
+		})
+	}
+}
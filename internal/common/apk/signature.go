@@ -0,0 +1,237 @@
+package apk
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// APK签名块，参见 https://source.android.com/docs/security/features/apksigning/v2
+const (
+	eocdSignature      = 0x06054b50
+	eocdMinSize        = 22
+	eocdMaxCommentSize = 0xFFFF
+
+	signingBlockMagic   = "APK Sig Block 42"
+	signingBlockMaxSize = 32 << 20
+
+	signatureSchemeV2BlockID = 0x7109871a
+	signatureSchemeV3BlockID = 0xf05368c0
+
+	maxV1SignatureFileSize = 1 << 20
+)
+
+var errMalformedSignature = errors.New("签名信息格式错误")
+
+// parseSigningBlock 从APK签名块中提取 v2(优先) 或 v3 签名证书
+// v3 支持密钥轮换，轮换后的证书与原证书不同，因此优先使用 v2 证书作为应用身份
+func parseSigningBlock(r io.ReaderAt, size int64) (scheme string, cert *Certificate, err error) {
+	cdOffset, err := findCentralDirectoryOffset(r, size)
+	if err != nil {
+		return "", nil, err
+	}
+	if cdOffset < 24 {
+		return "", nil, ErrSignatureNotFound
+	}
+
+	// 签名块紧挨在中央目录之前，结尾是 块大小(uint64) + 魔数
+	footer := make([]byte, 24)
+	if _, err = r.ReadAt(footer, cdOffset-24); err != nil {
+		return "", nil, fmt.Errorf("读取APK签名块失败: %w", err)
+	}
+	if string(footer[8:]) != signingBlockMagic {
+		return "", nil, ErrSignatureNotFound
+	}
+	blockSize := int64(binary.LittleEndian.Uint64(footer))
+	if blockSize < 24 || blockSize > signingBlockMaxSize || blockSize+8 > cdOffset {
+		return "", nil, errMalformedSignature
+	}
+
+	block := make([]byte, blockSize+8)
+	if _, err = r.ReadAt(block, cdOffset-blockSize-8); err != nil {
+		return "", nil, fmt.Errorf("读取APK签名块失败: %w", err)
+	}
+	if int64(binary.LittleEndian.Uint64(block)) != blockSize {
+		return "", nil, errMalformedSignature
+	}
+
+	// 签名块内容是 长度(uint64) + ID(uint32) + 值 的序列
+	blocks := make(map[uint32][]byte)
+	pairs := block[8 : len(block)-24]
+	for len(pairs) > 0 {
+		if len(pairs) < 12 {
+			return "", nil, errMalformedSignature
+		}
+		pairLen := binary.LittleEndian.Uint64(pairs)
+		if pairLen < 4 || pairLen > uint64(len(pairs)-8) {
+			return "", nil, errMalformedSignature
+		}
+		blocks[binary.LittleEndian.Uint32(pairs[8:])] = pairs[12 : 8+pairLen]
+		pairs = pairs[8+pairLen:]
+	}
+
+	if value, ok := blocks[signatureSchemeV2BlockID]; ok {
+		cert, err = parseSchemeBlockCertificate(value)
+		return SignatureSchemeV2, cert, err
+	}
+	if value, ok := blocks[signatureSchemeV3BlockID]; ok {
+		cert, err = parseSchemeBlockCertificate(value)
+		return SignatureSchemeV3, cert, err
+	}
+	return "", nil, ErrSignatureNotFound
+}
+
+// findCentralDirectoryOffset 从ZIP文件末尾的 End of Central Directory 记录中读取中央目录偏移
+func findCentralDirectoryOffset(r io.ReaderAt, size int64) (offset int64, err error) {
+	tailSize := min(size, eocdMinSize+eocdMaxCommentSize)
+	tail := make([]byte, tailSize)
+	if _, err = r.ReadAt(tail, size-tailSize); err != nil {
+		return 0, fmt.Errorf("读取APK文件失败: %w", err)
+	}
+
+	for i := len(tail) - eocdMinSize; i >= 0; i-- {
+		if binary.LittleEndian.Uint32(tail[i:]) != eocdSignature {
+			continue
+		}
+		commentSize := int(binary.LittleEndian.Uint16(tail[i+20:]))
+		if i+eocdMinSize+commentSize != len(tail) {
+			continue
+		}
+		return int64(binary.LittleEndian.Uint32(tail[i+16:])), nil
+	}
+	return 0, errMalformedSignature
+}
+
+// parseSchemeBlockCertificate 取第一个签名者的第一个证书
+// v2/v3 结构：signers[ signer{ signed data{ digests, certificates, ... }, ... } ]，每一层都有 uint32 长度前缀
+func parseSchemeBlockCertificate(value []byte) (cert *Certificate, err error) {
+	signers, _, err := readLengthPrefixed(value)
+	if err != nil {
+		return nil, err
+	}
+	signer, _, err := readLengthPrefixed(signers)
+	if err != nil {
+		return nil, err
+	}
+	signedData, _, err := readLengthPrefixed(signer)
+	if err != nil {
+		return nil, err
+	}
+	_, rest, err := readLengthPrefixed(signedData) // digests
+	if err != nil {
+		return nil, err
+	}
+	certificates, _, err := readLengthPrefixed(rest)
+	if err != nil {
+		return nil, err
+	}
+	der, _, err := readLengthPrefixed(certificates)
+	if err != nil {
+		return nil, err
+	}
+	return newCertificate(der), nil
+}
+
+func readLengthPrefixed(b []byte) (value, rest []byte, err error) {
+	if len(b) < 4 {
+		return nil, nil, errMalformedSignature
+	}
+	size := binary.LittleEndian.Uint32(b)
+	if uint64(size) > uint64(len(b)-4) {
+		return nil, nil, errMalformedSignature
+	}
+	return b[4 : 4+size], b[4+size:], nil
+}
+
+// PKCS#7 SignedData 中提取证书需要的最小结构
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms asn1.RawValue
+	ContentInfo      asn1.RawValue
+	Certificates     pkcs7RawCertificates `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue        `asn1:"optional,tag:1"`
+	SignerInfos      asn1.RawValue
+}
+
+type pkcs7RawCertificates struct {
+	Raw asn1.RawContent
+}
+
+// parseV1Signature 读取 META-INF 下的签名文件(.RSA/.DSA/.EC)，取其中的第一个证书
+func parseV1Signature(zr *zip.Reader) (scheme string, cert *Certificate, err error) {
+	for _, f := range zr.File {
+		dir, name := path.Split(f.Name)
+		if dir != "META-INF/" {
+			continue
+		}
+		switch strings.ToUpper(path.Ext(name)) {
+		case ".RSA", ".DSA", ".EC":
+		default:
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return "", nil, fmt.Errorf("读取%s失败: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxV1SignatureFileSize))
+		rc.Close()
+		if err != nil {
+			return "", nil, fmt.Errorf("读取%s失败: %w", f.Name, err)
+		}
+
+		der, err := parsePKCS7Certificate(data)
+		if err != nil {
+			return "", nil, fmt.Errorf("解析%s失败: %w", f.Name, err)
+		}
+		return SignatureSchemeV1, newCertificate(der), nil
+	}
+	return "", nil, ErrSignatureNotFound
+}
+
+func parsePKCS7Certificate(data []byte) (der []byte, err error) {
+	var info pkcs7ContentInfo
+	if _, err = asn1.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	var signedData pkcs7SignedData
+	if _, err = asn1.Unmarshal(info.Content.Bytes, &signedData); err != nil {
+		return nil, err
+	}
+	if len(signedData.Certificates.Raw) == 0 {
+		return nil, ErrSignatureNotFound
+	}
+
+	var set asn1.RawValue
+	if _, err = asn1.Unmarshal(signedData.Certificates.Raw, &set); err != nil {
+		return nil, err
+	}
+	var first asn1.RawValue
+	if _, err = asn1.Unmarshal(set.Bytes, &first); err != nil {
+		return nil, err
+	}
+	return first.FullBytes, nil
+}
+
+// newCertificate 指纹基于证书原始DER编码，部分旧证书不符合 x509 规范无法解析，此时主题留空
+func newCertificate(der []byte) *Certificate {
+	sum := sha256.Sum256(der)
+	cert := &Certificate{SHA256: hex.EncodeToString(sum[:])}
+	if parsed, err := x509.ParseCertificate(der); err == nil {
+		cert.Subject = parsed.Subject.String()
+	}
+	return cert
+}
@@ -0,0 +1,187 @@
+// Package cron 解析 cron 表达式并计算下次执行时间
+//
+// 支持标准5段格式：分 时 日 月 周，每段支持 *、数字、范围(a-b)、步长(*/n、a-b/n)、列表(a,b)，周的 0 和 7 都表示周日；
+// 日和周同时限定时满足其一即可，以 * 开头(包括 */n)的段视为未限定(与 crontab 一致)；永远不会执行的表达式(如 0 0 31 2 *)解析失败。
+// 另外支持 @yearly、@monthly、@weekly、@daily、@hourly，以及 @every <时长>(如 @every 10m)。
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 执行计划
+type Schedule interface {
+	// Next 返回晚于 t 的下次执行时间
+	Next(t time.Time) time.Time
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// fieldBounds 各段的取值范围
+var fieldBounds = [5]struct {
+	name     string
+	min, max int
+}{
+	{"分", 0, 59},
+	{"时", 0, 23},
+	{"日", 1, 31},
+	{"月", 1, 12},
+	{"周", 0, 7},
+}
+
+// Parse 解析 cron 表达式
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("cron表达式[%s]的时长格式错误: %v", spec, err)
+		}
+		if interval < time.Second {
+			return nil, fmt.Errorf("cron表达式[%s]的间隔不能小于1秒", spec)
+		}
+		return &everySchedule{interval: interval}, nil
+	}
+	if expanded, ok := descriptors[spec]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(fieldBounds) {
+		return nil, fmt.Errorf("cron表达式[%s]需要5段(分 时 日 月 周)", spec)
+	}
+
+	var bits [5]uint64
+	for i, field := range fields {
+		value, err := parseField(field, fieldBounds[i].min, fieldBounds[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("cron表达式[%s]的%s格式错误: %v", spec, fieldBounds[i].name, err)
+		}
+		bits[i] = value
+	}
+	// 周日可以写作 0 或 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	schedule := &specSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		// 与 crontab 一致，以 * 开头(包括 */n)时视为未限定
+		domStarred: strings.HasPrefix(fields[2], "*"),
+		dowStarred: strings.HasPrefix(fields[4], "*"),
+	}
+	// 如 0 0 31 2 *，Next 返回零值，调度时会被当作已到期反复执行
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("cron表达式[%s]永远不会执行", spec)
+	}
+	return schedule, nil
+}
+
+// parseField 解析一段，返回取值的位图
+func parseField(field string, min, max int) (bits uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if rangePart, stepPart, ok := strings.Cut(part, "/"); ok {
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("步长[%s]无效", stepPart)
+			}
+			part = rangePart
+		}
+
+		start, end := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			startPart, endPart, _ := strings.Cut(part, "-")
+			if start, err = strconv.Atoi(startPart); err != nil {
+				return 0, fmt.Errorf("[%s]不是数字", startPart)
+			}
+			if end, err = strconv.Atoi(endPart); err != nil {
+				return 0, fmt.Errorf("[%s]不是数字", endPart)
+			}
+		default:
+			if start, err = strconv.Atoi(part); err != nil {
+				return 0, fmt.Errorf("[%s]不是数字", part)
+			}
+			// 单个值带步长(如 5/15)表示从该值开始到最大值
+			end = start
+			if step > 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("[%s]超出范围%d-%d", part, min, max)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+type specSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStarred, dowStarred        bool
+}
+
+// Next 逐级向后查找满足条件的月、日、时、分，最多查找5年
+func (s *specSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + 5
+
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *specSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStarred || s.dowStarred {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+type everySchedule struct {
+	interval time.Duration
+}
+
+func (s *everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(time.Second).Add(s.interval)
+}
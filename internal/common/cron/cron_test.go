@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNext(t *testing.T) {
+	// 2026-01-01 是周四
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"分钟步长", "*/15 * * * *", base, time.Date(2026, 1, 1, 0, 15, 0, 0, time.UTC)},
+		{"单个值带步长", "5/15 * * * *", base, time.Date(2026, 1, 1, 0, 5, 0, 0, time.UTC)},
+		{"范围和列表", "0 9-10,14 * * *", base, time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{"日步长", "0 0 */2 * *", base, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"日步长和周同时限定时都要满足", "0 0 */2 * 1", base, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"日步长和周同时限定时跳过偶数日的周一", "0 0 */2 * 1", time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"周步长", "0 0 * * */3", base, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"日和周都限定时满足其一", "0 0 1 * 1", base, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"周日写作7", "0 0 * * 7", base, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"跨月", "0 0 1 * *", time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"闰日", "0 0 29 2 *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"hourly", "@hourly", base, time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{"weekly", "@weekly", base, time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"every", "@every 10m", base.Add(30 * time.Second), time.Date(2026, 1, 1, 0, 10, 30, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.spec, err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Parse(%q).Next(%v) = %v, want %v", tt.spec, tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"段数不足", "* * *"},
+		{"超出范围", "60 * * * *"},
+		{"范围颠倒", "5-1 * * * *"},
+		{"不是数字", "a * * * *"},
+		{"步长为0", "*/0 * * * *"},
+		{"未知的描述符", "@often"},
+		{"时长格式错误", "@every ten"},
+		{"间隔小于1秒", "@every 500ms"},
+		{"2月31日永远不会执行", "0 0 31 2 *"},
+		{"2月30日永远不会执行", "0 0 30 2 *"},
+		{"小月31日永远不会执行", "0 0 31 4,6,9,11 *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.spec); err == nil {
+				t.Errorf("Parse(%q) error = nil, want error", tt.spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package pinyin 把中文转换为全拼和首字母，用于拼音搜索
+//
+// 多音字生成各种读法的组合，如“重庆”生成 chongqing、zhongqing；常用读法的组合排在前面，组合数量最多 MaxVariants 个。
+// 字母和数字转为小写后原样保留(首字母中也保留完整的字母数字串，如“PUBG刺激战场”的首字母为 pubgcjzc)，其他字符忽略。
+package pinyin
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	gopinyin "github.com/mozillazg/go-pinyin"
+)
+
+const (
+	// MaxVariants 每种形式最多生成的组合数量
+	MaxVariants = 8
+	// maxReadings 每个多音字最多使用的读法数量
+	maxReadings = 3
+	// separator 保存到数据库时多个组合之间的分隔符
+	separator = " "
+	// MaxStoredLength 保存到数据库的最大长度，与字段长度一致
+	MaxStoredLength = 2048
+)
+
+var args = gopinyin.Args{
+	Style:     gopinyin.Normal,
+	Heteronym: true,
+	Fallback: func(r rune, a gopinyin.Args) []string {
+		return nil
+	},
+}
+
+// Forms 文本的拼音形式
+type Forms struct {
+	Full     []string // 全拼，如 wangzherongyao
+	Initials []string // 首字母，如 wzry
+}
+
+// Convert 生成文本的全拼和首字母，不包含中文时为空
+func Convert(text string) (forms *Forms) {
+	forms = &Forms{}
+	if !ContainsHan(text) {
+		return
+	}
+
+	full, initials := []string{""}, []string{""}
+	var run strings.Builder
+	flush := func() {
+		if run.Len() > 0 {
+			full = combine(full, []string{run.String()})
+			initials = combine(initials, []string{run.String()})
+			run.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			flush()
+			readings := Readings(r)
+			if len(readings) == 0 {
+				continue
+			}
+			firstLetters := make([]string, 0, len(readings))
+			for _, reading := range readings {
+				if letter := reading[:1]; !slices.Contains(firstLetters, letter) {
+					firstLetters = append(firstLetters, letter)
+				}
+			}
+			full = combine(full, readings)
+			initials = combine(initials, firstLetters)
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			run.WriteRune(r)
+		}
+	}
+	flush()
+
+	forms.Full = slices.DeleteFunc(full, func(s string) bool { return s == "" })
+	forms.Initials = slices.DeleteFunc(initials, func(s string) bool { return s == "" })
+	return
+}
+
+// Readings 汉字的不带声调的读法，常用读法在前
+func Readings(r rune) (readings []string) {
+	for _, reading := range gopinyin.SinglePinyin(r, args) {
+		if reading != "" && !slices.Contains(readings, reading) {
+			readings = append(readings, reading)
+		}
+		if len(readings) >= maxReadings {
+			break
+		}
+	}
+	return
+}
+
+// combine 把已有组合与当前字的读法组合，超过 MaxVariants 时保留前面的组合
+func combine(prefixes []string, readings []string) (out []string) {
+	out = make([]string, 0, min(len(prefixes)*len(readings), MaxVariants))
+	for _, prefix := range prefixes {
+		for _, reading := range readings {
+			if len(out) >= MaxVariants {
+				return
+			}
+			out = append(out, prefix+reading)
+		}
+	}
+	return
+}
+
+// ContainsHan 文本是否包含汉字
+func ContainsHan(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool {
+		return unicode.Is(unicode.Han, r)
+	}) >= 0
+}
+
+// Join 把多个组合拼接为一个字符串保存，超过 MaxStoredLength 时舍弃后面的组合
+func Join(variants []string) string {
+	var builder strings.Builder
+	for _, variant := range variants {
+		if builder.Len()+len(separator)+len(variant) > MaxStoredLength {
+			break
+		}
+		if builder.Len() > 0 {
+			builder.WriteString(separator)
+		}
+		builder.WriteString(variant)
+	}
+	return builder.String()
+}
+
+// Split 解析 Join 保存的字符串
+func Split(stored string) []string {
+	return strings.Fields(stored)
+}
@@ -0,0 +1,68 @@
+package pinyin
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name         string
+		text         string
+		wantFull     []string // 需要包含的全拼
+		wantInitials []string // 需要包含的首字母
+	}{
+		{"全拼和首字母", "王者荣耀", []string{"wangzherongyao"}, []string{"wzry"}},
+		{"多音字生成各种读法", "重庆", []string{"chongqing", "zhongqing"}, []string{"cq", "zq"}},
+		{"保留字母数字串并转小写", "PUBG刺激战场", []string{"pubgcijizhanchang"}, []string{"pubgcjzc"}},
+		{"忽略标点", "王者·荣耀", []string{"wangzherongyao"}, []string{"wzry"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forms := Convert(tt.text)
+			for _, full := range tt.wantFull {
+				if !slices.Contains(forms.Full, full) {
+					t.Errorf("Convert(%q).Full = %v, want to contain %q", tt.text, forms.Full, full)
+				}
+			}
+			for _, initials := range tt.wantInitials {
+				if !slices.Contains(forms.Initials, initials) {
+					t.Errorf("Convert(%q).Initials = %v, want to contain %q", tt.text, forms.Initials, initials)
+				}
+			}
+			if len(forms.Full) > MaxVariants || len(forms.Initials) > MaxVariants {
+				t.Errorf("Convert(%q) = %d/%d variants, want at most %d", tt.text, len(forms.Full), len(forms.Initials), MaxVariants)
+			}
+		})
+	}
+}
+
+func TestConvertWithoutHan(t *testing.T) {
+	forms := Convert("PUBG Mobile")
+	if len(forms.Full) != 0 || len(forms.Initials) != 0 {
+		t.Errorf("Convert = %+v, want empty", forms)
+	}
+}
+
+func TestConvertVariantsLimit(t *testing.T) {
+	// 每个字都是多音字，组合数量超过上限
+	forms := Convert("重行长乐都")
+	if len(forms.Full) != MaxVariants || len(forms.Initials) > MaxVariants {
+		t.Errorf("Convert = %d/%d variants, want %d/at most %d", len(forms.Full), len(forms.Initials), MaxVariants, MaxVariants)
+	}
+}
+
+func TestJoinSplit(t *testing.T) {
+	variants := []string{"chongqing", "zhongqing"}
+	if got := Split(Join(variants)); !reflect.DeepEqual(got, variants) {
+		t.Errorf("Split(Join(%v)) = %v", variants, got)
+	}
+
+	long := strings.Repeat("a", MaxStoredLength-4)
+	stored := Join([]string{long, "abcd"})
+	if len(stored) > MaxStoredLength || stored != long {
+		t.Errorf("Join over limit = %d bytes, want the first variant only", len(stored))
+	}
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+var (
+	AsyncTaskController = &asyncTask{}
+)
+
+// asyncTask 异步任务管理控制器
+type asyncTask struct{}
+
+// 查询任务列表
+func (c *asyncTask) ListAsyncTask(ctx context.Context, req *v1.ListAsyncTaskReq) (res *v1.ListAsyncTaskRes, err error) {
+	filter := &model.AsyncTaskFilter{
+		CustomID: req.CustomID,
+	}
+	if req.TaskType != nil {
+		taskType := model.AsyncTaskType(*req.TaskType)
+		filter.TaskType = &taskType
+	}
+	if req.Status != nil {
+		status := model.AsyncTaskStatus(*req.Status)
+		filter.Status = &status
+	}
+
+	tasks, pageRes, err := service.AsyncTask().ListTasks(ctx, filter, &req.PageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListAsyncTaskRes{
+		List:    make([]*v1.AsyncTask, 0, len(tasks)),
+		PageRes: pageRes,
+	}
+	for _, task := range tasks {
+		res.List = append(res.List, c.convertAsyncTaskModelToResponse(task))
+	}
+	return
+}
+
+// 查询任务详情
+func (c *asyncTask) GetAsyncTask(ctx context.Context, req *v1.GetAsyncTaskReq) (res *v1.GetAsyncTaskRes, err error) {
+	task, err := service.AsyncTask().GetTask(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetAsyncTaskRes{
+		AsyncTask: c.convertAsyncTaskModelToResponse(task),
+	}
+	return
+}
+
+// 重新执行重试次数耗尽的任务
+func (c *asyncTask) RerunAsyncTask(ctx context.Context, req *v1.RerunAsyncTaskReq) (res *v1.RerunAsyncTaskRes, err error) {
+	err = service.AsyncTask().RerunTask(ctx, req.ID, *req.Version)
+	return
+}
+
+// 取消任务
+func (c *asyncTask) CancelAsyncTask(ctx context.Context, req *v1.CancelAsyncTaskReq) (res *v1.CancelAsyncTaskRes, err error) {
+	err = service.AsyncTask().CancelTask(ctx, req.ID, *req.Version)
+	return
+}
+
+// 修改任务的下次处理时间
+func (c *asyncTask) RescheduleAsyncTask(ctx context.Context, req *v1.RescheduleAsyncTaskReq) (res *v1.RescheduleAsyncTaskRes, err error) {
+	err = service.AsyncTask().RescheduleTask(ctx, req.ID, *req.Version, req.NextRetryTime)
+	return
+}
+
+// 各任务类型的任务数量和积压情况
+func (c *asyncTask) GetAsyncTaskStats(ctx context.Context, req *v1.GetAsyncTaskStatsReq) (res *v1.GetAsyncTaskStatsRes, err error) {
+	statsList, err := service.AsyncTask().GetTaskStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetAsyncTaskStatsRes{
+		List: make([]*v1.AsyncTaskStats, 0, len(statsList)),
+	}
+	for _, stats := range statsList {
+		res.List = append(res.List, &v1.AsyncTaskStats{
+			TaskType:         int(stats.TaskType),
+			TaskTypeName:     model.GetAsyncTaskType(stats.TaskType),
+			Pending:          stats.StatusCounts[model.AsyncTaskStatusPending],
+			Processing:       stats.StatusCounts[model.AsyncTaskStatusProcessing],
+			Success:          stats.StatusCounts[model.AsyncTaskStatusSuccess],
+			Failed:           stats.StatusCounts[model.AsyncTaskStatusFailed],
+			Dead:             stats.StatusCounts[model.AsyncTaskStatusDead],
+			Canceled:         stats.StatusCounts[model.AsyncTaskStatusCanceled],
+			OldestPendingAge: stats.OldestPendingAge,
+		})
+	}
+	return
+}
+
+// 查询所有周期任务
+func (c *asyncTask) ListRecurringTask(ctx context.Context, req *v1.ListRecurringTaskReq) (res *v1.ListRecurringTaskRes, err error) {
+	recurringTasks, err := service.AsyncTask().ListRecurringTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListRecurringTaskRes{
+		List: make([]*v1.RecurringTask, 0, len(recurringTasks)),
+	}
+	for _, recurringTask := range recurringTasks {
+		item := &v1.RecurringTask{
+			ID:          recurringTask.ID,
+			Name:        recurringTask.Name,
+			CronSpec:    recurringTask.CronSpec,
+			Paused:      recurringTask.Paused,
+			Registered:  recurringTask.Registered,
+			NextRunTime: gtime.NewFromTimeStamp(recurringTask.NextRunTime),
+			CreateTime:  gtime.NewFromTimeStamp(recurringTask.CreateTime),
+			UpdateTime:  gtime.NewFromTimeStamp(recurringTask.UpdateTime),
+		}
+		if recurringTask.LastRunTime > 0 {
+			item.LastRunTime = gtime.NewFromTimeStamp(recurringTask.LastRunTime)
+		}
+		res.List = append(res.List, item)
+	}
+	return
+}
+
+// 暂停周期任务
+func (c *asyncTask) PauseRecurringTask(ctx context.Context, req *v1.PauseRecurringTaskReq) (res *v1.PauseRecurringTaskRes, err error) {
+	err = service.AsyncTask().PauseRecurringTask(ctx, req.Name)
+	return
+}
+
+// 恢复周期任务
+func (c *asyncTask) ResumeRecurringTask(ctx context.Context, req *v1.ResumeRecurringTaskReq) (res *v1.ResumeRecurringTaskRes, err error) {
+	err = service.AsyncTask().ResumeRecurringTask(ctx, req.Name)
+	return
+}
+
+func (c *asyncTask) convertAsyncTaskModelToResponse(in *model.AsyncTask) (out *v1.AsyncTask) {
+	return &v1.AsyncTask{
+		ID:              in.ID,
+		CustomID:        in.CustomID,
+		TaskType:        int(in.TaskType),
+		TaskTypeName:    model.GetAsyncTaskType(in.TaskType),
+		Status:          int(in.Status),
+		StatusName:      model.GetAsyncTaskStatusText(in.Status),
+		RetryCount:      in.RetryCount,
+		Content:         in.Content,
+		Version:         in.Version,
+		NextRetryTime:   gtime.NewFromTimeStamp(in.NextRetryTime),
+		LastError:       in.LastError,
+		LastErrorStack:  in.LastErrorStack,
+		LeaseOwner:      in.LeaseOwner,
+		LeaseExpireTime: gtime.NewFromTimeStamp(in.LeaseExpireTime),
+		CreateTime:      gtime.NewFromTimeStamp(in.CreateTime),
+		UpdateTime:      gtime.NewFromTimeStamp(in.UpdateTime),
+	}
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model/events"
+	"context"
+	"fmt"
+)
+
+var (
+	EventCatalogController = &eventCatalog{}
+)
+
+// eventCatalog 领域事件目录控制器
+type eventCatalog struct{}
+
+// 查询事件类型列表和数据格式
+func (c *eventCatalog) ListEventTypes(ctx context.Context, req *v1.ListEventTypesReq) (res *v1.ListEventTypesRes, err error) {
+	definitions := events.Definitions()
+	if req.Type != "" {
+		definition, ok := events.Lookup(req.Type)
+		if !ok {
+			return nil, fmt.Errorf("事件类型不存在")
+		}
+		definitions = []*events.Definition{definition}
+	}
+
+	res = &v1.ListEventTypesRes{
+		SpecVersion:     events.SpecVersion,
+		Source:          events.Source,
+		DataContentType: events.DataContentType,
+		List:            make([]*v1.EventType, 0, len(definitions)),
+	}
+	for _, definition := range definitions {
+		res.List = append(res.List, &v1.EventType{
+			Type:        definition.Type,
+			Topic:       definition.Topic,
+			Subject:     definition.Subject,
+			Description: definition.Description,
+			DataSchema:  definition.DataSchema,
+		})
+	}
+	return
+}
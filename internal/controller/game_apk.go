@@ -0,0 +1,53 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+)
+
+// 获取APK安装包解析结果，未指定文件时使用游戏当前下载的安装包
+func (c *gameController) GetApkInfo(ctx context.Context, req *v1.GetApkInfoReq) (res *v1.GetApkInfoRes, err error) {
+	fileID := req.FileID
+	if fileID == "" {
+		fileID, err = service.Game().ResolveDownloadFileID(ctx, req.GameID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := service.Game().GetApkInfo(ctx, req.GameID, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetApkInfoRes{
+		GameApkInfo: c.convertApkInfoModelToResponse(out),
+	}
+	return
+}
+
+func (c *gameController) convertApkInfoModelToResponse(in *model.GameApkInfo) (out *v1.GameApkInfo) {
+	out = &v1.GameApkInfo{
+		FileID:          in.FileID,
+		Status:          int(in.Status),
+		StatusText:      model.GetGameApkParseStatusText(in.Status),
+		PackageName:     in.PackageName,
+		VersionCode:     in.VersionCode,
+		VersionName:     in.VersionName,
+		MinSdk:          in.MinSdk,
+		TargetSdk:       in.TargetSdk,
+		Permissions:     in.Permissions,
+		SignatureScheme: in.SignatureScheme,
+		CertSha256:      in.CertSha256,
+		CertSubject:     in.CertSubject,
+		Error:           in.Error,
+		ApproveTime:     in.ApproveTime,
+		UpdateTime:      in.UpdateTime,
+	}
+	if out.Permissions == nil {
+		out.Permissions = make([]string, 0)
+	}
+	return
+}
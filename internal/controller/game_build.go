@@ -0,0 +1,116 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+)
+
+// 创建构建版本，APK文件上传到私有存储桶
+func (c *gameController) CreateGameBuild(ctx context.Context, req *v1.CreateGameBuildReq) (res *v1.CreateGameBuildRes, err error) {
+	out, err := service.FileEngine().PreUpload(ctx, &model.PreUploadReq{
+		BucketID:    "private-bucket",
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		Size:        req.FileSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buildID, err := service.Game().CreateBuild(ctx, &model.GameBuild{
+		GameID:       req.ID,
+		VersionName:  req.VersionName,
+		VersionCode:  req.VersionCode,
+		ReleaseNotes: req.ReleaseNotes,
+		FileID:       out.ID,
+		FileSize:     req.FileSize,
+		Checksum:     req.Checksum,
+		MediaUrl:     out.VisitURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.CreateGameBuildRes{
+		BuildID:   buildID,
+		FileID:    out.ID,
+		UploadURL: out.UploadURL,
+	}
+	return
+}
+
+// 获取构建版本列表
+func (c *gameController) ListGameBuild(ctx context.Context, req *v1.ListGameBuildReq) (res *v1.ListGameBuildRes, err error) {
+	builds, pageRes, err := service.Game().ListBuilds(ctx, req.ID, &req.PageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListGameBuildRes{
+		List:    make([]*v1.GameBuild, 0, len(builds)),
+		PageRes: pageRes,
+	}
+	for _, build := range builds {
+		res.List = append(res.List, c.convertBuildModelToResponse(build))
+	}
+	return
+}
+
+// 构建版本提交审核
+func (c *gameController) SubmitGameBuildForReview(ctx context.Context, req *v1.SubmitGameBuildForReviewReq) (res *v1.SubmitGameBuildForReviewRes, err error) {
+	err = service.Game().SubmitBuildForReview(ctx, req.ID, req.BuildID)
+	return
+}
+
+// 构建版本审核通过
+func (c *gameController) ApproveGameBuild(ctx context.Context, req *v1.ApproveGameBuildReq) (res *v1.ApproveGameBuildRes, err error) {
+	err = service.Game().ApproveBuild(ctx, req.ID, req.BuildID)
+	return
+}
+
+// 构建版本审核拒绝
+func (c *gameController) RejectGameBuild(ctx context.Context, req *v1.RejectGameBuildReq) (res *v1.RejectGameBuildRes, err error) {
+	err = service.Game().RejectBuild(ctx, req.ID, req.BuildID, req.Reason)
+	return
+}
+
+// 设置当前版本
+func (c *gameController) SetCurrentGameBuild(ctx context.Context, req *v1.SetCurrentGameBuildReq) (res *v1.SetCurrentGameBuildRes, err error) {
+	err = service.Game().SetCurrentBuild(ctx, req.ID, req.BuildID)
+	return
+}
+
+// 回滚版本
+func (c *gameController) RollbackGameBuild(ctx context.Context, req *v1.RollbackGameBuildReq) (res *v1.RollbackGameBuildRes, err error) {
+	build, err := service.Game().RollbackBuild(ctx, req.ID, req.BuildID)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.RollbackGameBuildRes{
+		GameBuild: c.convertBuildModelToResponse(build),
+	}
+	return
+}
+
+func (c *gameController) convertBuildModelToResponse(in *model.GameBuild) (out *v1.GameBuild) {
+	out = &v1.GameBuild{
+		ID:           in.ID,
+		GameID:       in.GameID,
+		VersionName:  in.VersionName,
+		VersionCode:  in.VersionCode,
+		ReleaseNotes: in.ReleaseNotes,
+		FileID:       in.FileID,
+		FileSize:     in.FileSize,
+		Checksum:     in.Checksum,
+		FileStatus:   int(in.FileStatus),
+		Status:       model.GetGameBuildStatusText(in.Status),
+		RejectReason: in.RejectReason,
+		IsCurrent:    in.IsCurrent,
+		CreateTime:   in.CreateTime,
+		UpdateTime:   in.UpdateTime,
+	}
+	return
+}
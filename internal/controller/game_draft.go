@@ -0,0 +1,167 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// 获取游戏草稿
+func (c *gameController) GetGameDraft(ctx context.Context, req *v1.GetGameDraftReq) (res *v1.GetGameDraftRes, err error) {
+	draft, err := service.Game().GetDraft(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetGameDraftRes{}
+	res.GameDraft, err = c.getDraftDetails(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// 丢弃游戏草稿
+func (c *gameController) DiscardGameDraft(ctx context.Context, req *v1.DiscardGameDraftReq) (res *v1.DiscardGameDraftRes, err error) {
+	err = service.Game().DiscardDraft(ctx, req.ID)
+	return
+}
+
+// 草稿提交审核
+func (c *gameController) SubmitGameDraftForReview(ctx context.Context, req *v1.SubmitGameDraftForReviewReq) (res *v1.SubmitGameDraftForReviewRes, err error) {
+	err = service.Game().SubmitDraftForReview(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提交已经成功，查询失败时只记录日志，避免客户端对已进入审核中的草稿重试提交
+	warnings, listErr := service.Game().ListValidationWarnings(ctx, []int64{req.ID}, true)
+	if listErr != nil {
+		g.Log().Errorf(ctx, "[Review] 查询草稿提交审核检查警告失败: game_id=%d, err=%v", req.ID, listErr)
+	}
+	res = &v1.SubmitGameDraftForReviewRes{
+		ValidationWarnings: c.convertValidationFindingsToResponse(warnings[req.ID]),
+	}
+	return
+}
+
+// 草稿审核通过
+func (c *gameController) ApproveGameDraft(ctx context.Context, req *v1.ApproveGameDraftReq) (res *v1.ApproveGameDraftRes, err error) {
+	err = service.Game().ApproveDraft(ctx, req.ID)
+	return
+}
+
+// 草稿审核拒绝
+func (c *gameController) RejectGameDraft(ctx context.Context, req *v1.RejectGameDraftReq) (res *v1.RejectGameDraftRes, err error) {
+	err = service.Game().RejectDraft(ctx, req.ID, req.Reason)
+	return
+}
+
+// 草稿与线上数据的差异
+func (c *gameController) DiffGameDraft(ctx context.Context, req *v1.DiffGameDraftReq) (res *v1.DiffGameDraftRes, err error) {
+	diff, err := service.Game().DiffDraft(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.DiffGameDraftRes{
+		GameID:       diff.GameID,
+		Fields:       make([]*v1.GameFieldDiff, 0, len(diff.Fields)),
+		MediaAdded:   make([]*v1.GameMediaInfo, 0, len(diff.MediaAdded)),
+		MediaRemoved: make([]*v1.GameMediaInfo, 0, len(diff.MediaRemoved)),
+	}
+	for _, field := range diff.Fields {
+		res.Fields = append(res.Fields, &v1.GameFieldDiff{
+			Field:      field.Field,
+			LiveValue:  field.LiveValue,
+			DraftValue: field.DraftValue,
+		})
+	}
+	for _, mediaInfo := range diff.MediaAdded {
+		res.MediaAdded = append(res.MediaAdded, c.convertMediaInfoModelToResponse(mediaInfo))
+	}
+	for _, mediaInfo := range diff.MediaRemoved {
+		res.MediaRemoved = append(res.MediaRemoved, c.convertMediaInfoModelToResponse(mediaInfo))
+	}
+	return
+}
+
+// 获取待审核的草稿
+func (c *gameController) ListGameDraftInReview(ctx context.Context, req *v1.ListGameDraftInReviewReq) (res *v1.ListGameDraftInReviewRes, err error) {
+	drafts, pageRes, err := service.Game().ListDraftInReview(ctx, &req.PageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	gameIDs := make([]int64, 0, len(drafts))
+	for _, draft := range drafts {
+		gameIDs = append(gameIDs, draft.GameID)
+	}
+	warnings, err := service.Game().ListValidationWarnings(ctx, gameIDs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListGameDraftInReviewRes{
+		List:    make([]*v1.GameDraft, 0, len(drafts)),
+		PageRes: pageRes,
+	}
+	for _, draft := range drafts {
+		v, err := c.getDraftDetails(ctx, draft)
+		if err != nil {
+			return nil, err
+		}
+		v.ValidationWarnings = c.convertValidationFindingsToResponse(warnings[draft.GameID])
+		res.List = append(res.List, v)
+	}
+	return
+}
+
+func (c *gameController) getDraftDetails(ctx context.Context, in *model.GameDraft) (out *v1.GameDraft, err error) {
+	out = &v1.GameDraft{
+		ID:             in.ID,
+		GameID:         in.GameID,
+		Name:           in.Name,
+		DistributeType: model.GetGameDistributeTypeText(in.DistributeType),
+		Developer:      in.Developer,
+		Publisher:      in.Publisher,
+		Description:    in.Description,
+		Details:        in.Details,
+
+		Status:     model.GetGameDraftStatusText(in.Status),
+		CreateTime: in.CreateTime,
+		UpdateTime: in.UpdateTime,
+	}
+
+	// 获取草稿媒体信息
+	mediaInfos, err := service.Game().GetDraftMediaInfo(ctx, in.GameID)
+	if err != nil {
+		return nil, err
+	}
+	out.MediaInfos = make([]*v1.GameMediaInfo, 0, len(mediaInfos))
+	for _, mediaInfo := range mediaInfos {
+		out.MediaInfos = append(out.MediaInfos, c.convertMediaInfoModelToResponse(mediaInfo))
+	}
+
+	// 获取草稿分类
+	if in.CategoryID > 0 {
+		category, err := service.Metadata().GetCategoryByID(ctx, in.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		out.Category = MetadataController.convertCategoryModelToResponse(category)
+	}
+
+	// 获取草稿标签
+	for _, tagID := range in.TagIDs {
+		tag, err := service.Metadata().GetTagByID(ctx, tagID)
+		if err != nil {
+			return nil, err
+		}
+		out.Tags = append(out.Tags, MetadataController.convertTagModelToResponse(tag))
+	}
+	return
+}
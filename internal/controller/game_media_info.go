@@ -31,6 +31,9 @@ func (c *gameController) PreUploadMediaInfo(ctx context.Context, req *v1.PreUplo
 		FileID:    out.ID,
 		MediaType: model.GameMediaType(req.Type),
 		MediaUrl:  out.VisitURL,
+		FileSize:  req.FileSize,
+		Width:     req.Width,
+		Height:    req.Height,
 		Status:    model.GameMediaStatusInit,
 	}
 	err = service.Game().AddMediaInfo(ctx, mediaInfo)
@@ -52,11 +55,8 @@ func (c *gameController) ReportUploadResult(ctx context.Context, req *v1.ReportU
 		return
 	}
 
-	status := model.GameMediaStatusSuccess
-	if !req.Success {
-		status = model.GameMediaStatusFailed
-	}
-	err = service.Game().UpdateMediaInfoStatusByFileID(ctx, req.FileID, status)
+	// 上传成功的文件需要先通过内容安全扫描；文件引擎的上传结果消息可能先到达，已更新过的文件不再更新
+	_, err = service.Game().ApplyUploadResult(ctx, req.FileID, req.Success)
 	if err != nil {
 		return
 	}
@@ -70,12 +70,18 @@ func (c *gameController) PreDownloadMediaInfo(ctx context.Context, req *v1.PreDo
 		return nil, err
 	}
 
+	// 下载的文件由服务端根据游戏当前版本确定，不信任客户端传入的文件ID
+	fileID, err := service.Game().ResolveDownloadFileID(ctx, req.GameID)
+	if err != nil {
+		return nil, err
+	}
+
 	err = service.Game().Download(ctx, req.GameID, userInfo.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := service.FileEngine().PreDownload(ctx, req.FileID)
+	out, err := service.FileEngine().PreDownload(ctx, fileID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +89,7 @@ func (c *gameController) PreDownloadMediaInfo(ctx context.Context, req *v1.PreDo
 	service.UserBehavior().RecordBehavior(ctx, userInfo.ID, req.GameID, model.BehaviorDownload, "", "download")
 
 	res = &v1.PreDownloadMediaInfoRes{
+		FileID:      fileID,
 		DownloadURL: out.DownloadURL,
 		ExpiresAt:   out.ExpiresAt,
 		ExpiresIn:   out.ExpiresIn,
@@ -119,6 +126,15 @@ func (c *gameController) SaveMediaInfo(ctx context.Context, req *v1.SaveMediaInf
 	return
 }
 
+// 重新扫描文件，不修改文件状态
+func (c *gameController) RescanMediaFile(ctx context.Context, req *v1.RescanMediaFileReq) (res *v1.RescanMediaFileRes, err error) {
+	err = service.Game().RescanMediaFile(ctx, req.FileID)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
 func (c *gameController) convertMediaInfoModelToResponse(in *model.GameMediaInfo) (out *v1.GameMediaInfo) {
 	out = &v1.GameMediaInfo{
 		ID:        in.ID,
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+	"fmt"
+)
+
+// 游戏状态停留时长统计
+func (c *gameController) GetGameSLAStats(ctx context.Context, req *v1.GetGameSLAStatsReq) (res *v1.GetGameSLAStatsRes, err error) {
+	var status *model.GameStatus
+	if req.Status != nil {
+		s := model.GameStatus(*req.Status)
+		if model.GetGameStatusKey(s) == "" {
+			return nil, fmt.Errorf("无效的游戏状态: %d", *req.Status)
+		}
+		status = &s
+	}
+
+	stats, err := service.Game().GetSLAStats(ctx, status, req.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.GetGameSLAStatsRes{
+		List: make([]*v1.GameSLAStats, 0, len(stats)),
+	}
+	for _, stat := range stats {
+		v := &v1.GameSLAStats{
+			Status:         int(stat.Status),
+			StatusName:     model.GetGameStatusText(stat.Status),
+			SLA:            stat.SLA,
+			QueueDepth:     stat.QueueDepth,
+			CompletedCount: stat.CompletedCount,
+			MedianDuration: stat.MedianDuration,
+			P95Duration:    stat.P95Duration,
+			OverdueGames:   make([]*v1.GameSLAOverdue, 0, len(stat.OverdueGames)),
+		}
+		for _, overdue := range stat.OverdueGames {
+			v.OverdueGames = append(v.OverdueGames, &v1.GameSLAOverdue{
+				GameID:    overdue.GameID,
+				GameName:  overdue.GameName,
+				EnterTime: overdue.EnterTime,
+				Elapsed:   overdue.Elapsed,
+			})
+		}
+		res.List = append(res.List, v)
+	}
+	return
+}
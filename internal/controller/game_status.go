@@ -2,9 +2,9 @@ package controller
 
 import (
 	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
 	"GameEngine/internal/service"
 	"context"
-	"fmt"
 
 	"github.com/gogf/gf/v2/frame/g"
 )
@@ -12,64 +12,58 @@ import (
 // 提交审核
 func (c *gameController) SubmitGameForReview(ctx context.Context, req *v1.SubmitGameForReviewReq) (res *v1.SubmitGameForReviewRes, err error) {
 	err = service.Game().SubmitForReview(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 提示开发者历史审核中仍未解决的问题
+	// 提交已经成功，查询失败时只记录日志，避免客户端对已进入审核中的游戏重试提交
+	issues, listErr := service.Review().ListIssues(ctx, req.ID, true)
+	if listErr != nil {
+		g.Log().Errorf(ctx, "[Review] 查询未解决的审核问题失败: game_id=%d, err=%v", req.ID, listErr)
+	}
+	warnings, listErr := service.Game().ListValidationWarnings(ctx, []int64{req.ID}, false)
+	if listErr != nil {
+		g.Log().Errorf(ctx, "[Review] 查询提交审核检查警告失败: game_id=%d, err=%v", req.ID, listErr)
+	}
+	res = &v1.SubmitGameForReviewRes{
+		UnresolvedIssues:   ReviewController.convertIssueModelsToResponse(issues),
+		ValidationWarnings: c.convertValidationFindingsToResponse(warnings[req.ID]),
+	}
 	return
 }
 
 // 审核通过
 func (c *gameController) ApproveGame(ctx context.Context, req *v1.ApproveGameReq) (res *v1.ApproveGameRes, err error) {
-	err = service.Game().Approve(ctx, req.ID)
+	pendingApprovals, err := service.Game().Approve(ctx, req.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	res = &v1.ApproveGameRes{
+		PendingApprovals: pendingApprovals,
+	}
 	return
 }
 
 // 拒绝游戏
 func (c *gameController) RejectGame(ctx context.Context, req *v1.RejectGameReq) (res *v1.RejectGameRes, err error) {
-	err = service.Game().Reject(ctx, req.ID)
+	issues := make([]*model.ReviewIssue, 0, len(req.Issues))
+	for _, issue := range req.Issues {
+		issues = append(issues, &model.ReviewIssue{
+			Code:    issue.Code,
+			Field:   model.ReviewField(issue.Field),
+			Comment: issue.Comment,
+		})
+	}
+	err = service.Game().Reject(ctx, req.ID, req.Reason, issues)
 	return
 }
 
 // 立即上架游戏
 func (c *gameController) PublishGameImmediately(ctx context.Context, req *v1.PublishGameImmediatelyReq) (res *v1.PublishGameImmediatelyRes, err error) {
+	// 预约用户由游戏上架时添加的异步任务通知
 	err = service.Game().PublishGameImmediately(ctx, req.ID)
-	// 通知游戏预约者，游戏发布了。
-	go func() {
-		gameInfo, err := service.Game().GetGameByID(context.Background(), req.ID)
-		if err != nil {
-			g.Log().Errorf(ctx, "get game by id error: %v", err)
-			return
-		}
-
-		users, err := service.Reservation().GetGameReservations(context.Background(), req.ID)
-		if err != nil {
-			g.Log().Errorf(ctx, "get game reservations error: %v", err)
-			return
-		}
-		if len(users) == 0 {
-			return
-		}
-
-		var body map[string]interface{} = make(map[string]interface{})
-		var userIDs []string = make([]string, 0, len(users))
-		for _, reservation := range users {
-			userIDs = append(userIDs, fmt.Sprintf("%d", reservation.UserID))
-		}
-		body["user_ids"] = userIDs
-		body["content"] = map[string]interface{}{
-			"title":     "游戏已发布",
-			"game_id":   gameInfo.ID,
-			"game_name": gameInfo.Name,
-			"message":   "游戏已发布，请登录游戏引擎查看",
-		}
-
-		err = service.MQ().Publish(context.Background(), "core.push.users", body)
-		if err != nil {
-			g.Log().Errorf(ctx, "publish game published error: %v", err)
-			return
-		}
-	}()
 	return
 }
 
@@ -93,13 +87,29 @@ func (c *gameController) ListInReview(ctx context.Context, req *v1.ListInReviewR
 	}
 	res = &v1.ListInReviewRes{
 		PageRes: *pageRes,
+		List:    make([]*v1.GameInReview, 0, len(games)),
 	}
 
-	res.List, err = c.getGameDetails(ctx, games)
+	details, err := c.getGameDetails(ctx, games)
 	if err != nil {
 		return nil, err
 	}
 
+	// 提交审核检查警告，提示审核人员重点关注
+	gameIDs := make([]int64, 0, len(games))
+	for _, game := range games {
+		gameIDs = append(gameIDs, game.ID)
+	}
+	warnings, err := service.Game().ListValidationWarnings(ctx, gameIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, detail := range details {
+		res.List = append(res.List, &v1.GameInReview{
+			Game:               detail,
+			ValidationWarnings: c.convertValidationFindingsToResponse(warnings[detail.ID]),
+		})
+	}
 	return
 }
 
@@ -120,3 +130,38 @@ func (c *gameController) UpdateGameVersion(ctx context.Context, req *v1.UpdateGa
 	err = service.Game().UpdateGameVersion(ctx, req.ID)
 	return
 }
+
+// 获取游戏状态变更历史
+func (c *gameController) ListGameStatusHistory(ctx context.Context, req *v1.ListGameStatusHistoryReq) (res *v1.ListGameStatusHistoryRes, err error) {
+	outs, pageRes, err := service.Game().ListStatusHistory(ctx, req.ID, &req.PageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListGameStatusHistoryRes{
+		List:    make([]*v1.GameStatusHistory, 0, len(outs)),
+		PageRes: pageRes,
+	}
+	for _, out := range outs {
+		res.List = append(res.List, c.convertStatusHistoryModelToResponse(out))
+	}
+	return
+}
+
+func (c *gameController) convertStatusHistoryModelToResponse(in *model.GameStatusHistory) (out *v1.GameStatusHistory) {
+	out = &v1.GameStatusHistory{
+		ID:             in.ID,
+		FromStatus:     in.FromStatus,
+		FromStatusName: model.GetGameStatusText(in.FromStatus),
+		ToStatus:       in.ToStatus,
+		ToStatusName:   model.GetGameStatusText(in.ToStatus),
+		Event:          in.Event,
+		EventName:      model.GetGameEventText(in.Event),
+		OperatorID:     in.OperatorID,
+		OperatorName:   in.OperatorName,
+		Reason:         in.Reason,
+		EventData:      in.EventData,
+		CreateTime:     in.CreateTime,
+	}
+	return
+}
@@ -0,0 +1,20 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+)
+
+func (c *gameController) convertValidationFindingsToResponse(in []*model.ValidationFinding) (out []*v1.ValidationFinding) {
+	out = make([]*v1.ValidationFinding, 0, len(in))
+	for _, finding := range in {
+		out = append(out, &v1.ValidationFinding{
+			Validator: finding.Validator,
+			Severity:  model.GetValidationSeverityText(finding.Severity),
+			Field:     string(finding.Field),
+			FieldName: model.GetReviewFieldText(finding.Field),
+			Message:   finding.Message,
+		})
+	}
+	return
+}
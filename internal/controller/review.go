@@ -0,0 +1,236 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+)
+
+var (
+	ReviewController = &review{}
+)
+
+// review 审核反馈控制器
+type review struct{}
+
+// 创建拒绝原因
+func (c *review) CreateRejectCode(ctx context.Context, req *v1.CreateRejectCodeReq) (res *v1.CreateRejectCodeRes, err error) {
+	id, err := service.Review().CreateRejectCode(ctx, &model.ReviewRejectCode{
+		Code:        req.Code,
+		Title:       req.Title,
+		Description: req.Description,
+		Field:       model.ReviewField(req.Field),
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.CreateRejectCodeRes{
+		ID: id,
+	}
+	return
+}
+
+// 更新拒绝原因
+func (c *review) UpdateRejectCode(ctx context.Context, req *v1.UpdateRejectCodeReq) (res *v1.UpdateRejectCodeRes, err error) {
+	err = service.Review().UpdateRejectCode(ctx, &model.ReviewRejectCode{
+		ID:          req.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Field:       model.ReviewField(req.Field),
+		Enabled:     req.Enabled,
+	})
+	return
+}
+
+// 删除拒绝原因
+func (c *review) DeleteRejectCode(ctx context.Context, req *v1.DeleteRejectCodeReq) (res *v1.DeleteRejectCodeRes, err error) {
+	err = service.Review().DeleteRejectCode(ctx, req.ID)
+	return
+}
+
+// 获取拒绝原因列表
+func (c *review) ListRejectCode(ctx context.Context, req *v1.ListRejectCodeReq) (res *v1.ListRejectCodeRes, err error) {
+	rejectCodes, err := service.Review().ListRejectCodes(ctx, req.OnlyEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListRejectCodeRes{
+		List: make([]*v1.RejectCode, 0, len(rejectCodes)),
+	}
+	for _, rejectCode := range rejectCodes {
+		res.List = append(res.List, &v1.RejectCode{
+			ID:          rejectCode.ID,
+			Code:        rejectCode.Code,
+			Title:       rejectCode.Title,
+			Description: rejectCode.Description,
+			Field:       string(rejectCode.Field),
+			Enabled:     rejectCode.Enabled,
+			CreateTime:  rejectCode.CreateTime,
+			UpdateTime:  rejectCode.UpdateTime,
+		})
+	}
+	return
+}
+
+// 获取游戏的审核问题
+func (c *review) ListGameReviewIssue(ctx context.Context, req *v1.ListGameReviewIssueReq) (res *v1.ListGameReviewIssueRes, err error) {
+	err = service.Game().AssertExists(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := service.Review().ListIssues(ctx, req.ID, req.OnlyOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListGameReviewIssueRes{
+		List: c.convertIssueModelsToResponse(issues),
+	}
+	return
+}
+
+// 回复审核问题
+func (c *review) AddReviewComment(ctx context.Context, req *v1.AddReviewCommentReq) (res *v1.AddReviewCommentRes, err error) {
+	id, err := service.Review().AddComment(ctx, req.ID, req.IssueID, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.AddReviewCommentRes{
+		ID: id,
+	}
+	return
+}
+
+// 标记审核问题为已解决
+func (c *review) ResolveReviewIssue(ctx context.Context, req *v1.ResolveReviewIssueReq) (res *v1.ResolveReviewIssueRes, err error) {
+	err = service.Review().ResolveIssue(ctx, req.ID, req.IssueID)
+	return
+}
+
+func (c *review) convertIssueModelsToResponse(in []*model.ReviewIssue) (out []*v1.ReviewIssue) {
+	out = make([]*v1.ReviewIssue, 0, len(in))
+	for _, issue := range in {
+		v := &v1.ReviewIssue{
+			ID:           issue.ID,
+			GameID:       issue.GameID,
+			Code:         issue.Code,
+			Field:        string(issue.Field),
+			FieldName:    model.GetReviewFieldText(issue.Field),
+			Comment:      issue.Comment,
+			Status:       model.GetReviewIssueStatusText(issue.Status),
+			ReviewerID:   issue.ReviewerID,
+			ReviewerName: issue.ReviewerName,
+			ResolveTime:  issue.ResolveTime,
+			CreateTime:   issue.CreateTime,
+			Comments:     make([]*v1.ReviewComment, 0, len(issue.Comments)),
+		}
+		for _, comment := range issue.Comments {
+			v.Comments = append(v.Comments, &v1.ReviewComment{
+				ID:         comment.ID,
+				UserID:     comment.UserID,
+				UserName:   comment.UserName,
+				Content:    comment.Content,
+				CreateTime: comment.CreateTime,
+			})
+		}
+		out = append(out, v)
+	}
+	return
+}
+
+// 领取审核队列中的下一个游戏
+func (c *review) ClaimNextReview(ctx context.Context, req *v1.ClaimNextReviewReq) (res *v1.ClaimNextReviewRes, err error) {
+	claim, err := service.Review().ClaimNext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := c.convertClaimModelsToResponse(ctx, []*model.ReviewClaim{claim})
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.ClaimNextReviewRes{
+		ReviewClaim: claims[0],
+	}
+	return
+}
+
+// 领取指定游戏的审核任务
+func (c *review) ClaimGameReview(ctx context.Context, req *v1.ClaimGameReviewReq) (res *v1.ClaimGameReviewRes, err error) {
+	claim, err := service.Review().Claim(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := c.convertClaimModelsToResponse(ctx, []*model.ReviewClaim{claim})
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.ClaimGameReviewRes{
+		ReviewClaim: claims[0],
+	}
+	return
+}
+
+// 释放审核任务
+func (c *review) ReleaseGameReview(ctx context.Context, req *v1.ReleaseGameReviewReq) (res *v1.ReleaseGameReviewRes, err error) {
+	err = service.Review().ReleaseClaim(ctx, req.ID)
+	return
+}
+
+// 转交审核任务
+func (c *review) HandoffGameReview(ctx context.Context, req *v1.HandoffGameReviewReq) (res *v1.HandoffGameReviewRes, err error) {
+	err = service.Review().HandoffClaim(ctx, req.ID, req.ReviewerID, req.ReviewerName)
+	return
+}
+
+// 我领取的审核任务
+func (c *review) ListMyReviewClaim(ctx context.Context, req *v1.ListMyReviewClaimReq) (res *v1.ListMyReviewClaimRes, err error) {
+	claims, pageRes, err := service.Review().ListMyClaims(ctx, &req.PageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.convertClaimModelsToResponse(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	res = &v1.ListMyReviewClaimRes{
+		List:    list,
+		PageRes: pageRes,
+	}
+	return
+}
+
+func (c *review) convertClaimModelsToResponse(ctx context.Context, in []*model.ReviewClaim) (out []*v1.ReviewClaim, err error) {
+	gameIDs := make([]int64, 0, len(in))
+	for _, claim := range in {
+		gameIDs = append(gameIDs, claim.GameID)
+	}
+	games, err := service.Game().GetGamesByIDs(ctx, gameIDs)
+	if err != nil {
+		return nil, err
+	}
+	gameNames := make(map[int64]string, len(games))
+	for _, game := range games {
+		gameNames[game.ID] = game.Name
+	}
+
+	out = make([]*v1.ReviewClaim, 0, len(in))
+	for _, claim := range in {
+		out = append(out, &v1.ReviewClaim{
+			GameID:          claim.GameID,
+			GameName:        gameNames[claim.GameID],
+			ReviewerID:      claim.ReviewerID,
+			ReviewerName:    claim.ReviewerName,
+			LeaseExpireTime: claim.LeaseExpireTime,
+		})
+	}
+	return
+}
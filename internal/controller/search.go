@@ -0,0 +1,159 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+)
+
+var (
+	SearchController = &search{}
+)
+
+// search 游戏搜索控制器
+type search struct{}
+
+// 搜索游戏(可预约、已上架)
+func (c *search) SearchGames(ctx context.Context, req *v1.SearchGamesReq) (res *v1.SearchGamesRes, err error) {
+	in := c.convertGameSearchFilter(&req.GameSearchFilter, &req.PageReq)
+	in.Statuses = []model.GameStatus{model.GameStatusPreRegister, model.GameStatusPublished}
+	outs, pageRes, facets, err := service.Game().SearchGames(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.SearchGamesRes{
+		Facets:  c.convertGameSearchFacets(facets),
+		PageRes: pageRes,
+	}
+	res.List, err = GameController.getGameDetails(ctx, outs)
+	if err != nil {
+		return nil, err
+	}
+	// 登录用户：补充是否已预约和是否已收藏标记
+	err = GameController.setUserGameStatus(ctx, res.List)
+	if err != nil {
+		return nil, err
+	}
+	value := ctx.Value(model.UserInfoKey)
+	if value != nil && req.Keyword != "" {
+		service.UserBehavior().RecordBehavior(ctx, value.(model.User).ID, 0, model.BehaviorSearch, "", req.Keyword)
+	}
+	return
+}
+
+// 搜索所有状态的游戏
+func (c *search) AdminSearchGames(ctx context.Context, req *v1.AdminSearchGamesReq) (res *v1.AdminSearchGamesRes, err error) {
+	in := c.convertGameSearchFilter(&req.GameSearchFilter, &req.PageReq)
+	for _, status := range req.Statuses {
+		in.Statuses = append(in.Statuses, model.GameStatus(status))
+	}
+	outs, pageRes, facets, err := service.Game().SearchGames(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.AdminSearchGamesRes{
+		Facets:  c.convertGameSearchFacets(facets),
+		PageRes: pageRes,
+	}
+	res.List, err = GameController.getGameDetails(ctx, outs)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// 搜索建议
+func (c *search) SuggestSearch(ctx context.Context, req *v1.SuggestSearchReq) (res *v1.SuggestSearchRes, err error) {
+	suggestions, err := service.Search().Suggest(ctx, req.Q, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.SuggestSearchRes{
+		List: make([]*v1.SearchSuggestion, 0, len(suggestions)),
+	}
+	for _, suggestion := range suggestions {
+		res.List = append(res.List, &v1.SearchSuggestion{
+			Text:   suggestion.Text,
+			Type:   string(suggestion.Type),
+			GameID: suggestion.GameID,
+		})
+	}
+	return
+}
+
+// 重建搜索索引
+func (c *search) RebuildSearchIndex(ctx context.Context, req *v1.RebuildSearchIndexReq) (res *v1.RebuildSearchIndexRes, err error) {
+	stats, err := service.Search().Rebuild(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.RebuildSearchIndexRes{
+		SearchIndexStats: c.convertSearchIndexStats(stats),
+	}
+	return
+}
+
+// 查询搜索索引状态
+func (c *search) GetSearchIndexStats(ctx context.Context, req *v1.GetSearchIndexStatsReq) (res *v1.GetSearchIndexStatsRes, err error) {
+	res = &v1.GetSearchIndexStatsRes{
+		SearchIndexStats: c.convertSearchIndexStats(service.Search().Stats(ctx)),
+	}
+	return
+}
+
+func (c *search) convertSearchIndexStats(in *model.SearchIndexStats) (out *v1.SearchIndexStats) {
+	out = &v1.SearchIndexStats{
+		Documents:   in.Documents,
+		Terms:       in.Terms,
+		Dictionary:  in.Dictionary,
+		BuildTime:   in.BuildTime,
+		Duration:    in.Duration,
+		Suggestions: in.Suggestions,
+	}
+	return
+}
+
+func (c *search) convertGameSearchFilter(in *v1.GameSearchFilter, page *model.PageReq) (out *model.GameSearchQuery) {
+	out = &model.GameSearchQuery{
+		Keyword:     in.Keyword,
+		CategoryIDs: in.CategoryIDs,
+		TagIDs:      in.TagIDs,
+		MinRating:   in.MinRating,
+		MaxRating:   in.MaxRating,
+		PublishFrom: in.PublishFrom,
+		PublishTo:   in.PublishTo,
+		Developer:   in.Developer,
+		Sort:        model.GameSearchSort(in.Sort),
+		Page:        page.Page,
+		Size:        page.Size,
+	}
+	for _, distributeType := range in.DistributeTypes {
+		out.DistributeTypes = append(out.DistributeTypes, model.GameDistributeType(distributeType))
+	}
+	return
+}
+
+func (c *search) convertGameSearchFacets(in *model.GameSearchFacets) (out *v1.GameSearchFacets) {
+	convert := func(facets []*model.GameSearchFacet) []*v1.GameSearchFacet {
+		list := make([]*v1.GameSearchFacet, 0, len(facets))
+		for _, facet := range facets {
+			list = append(list, &v1.GameSearchFacet{
+				Value: facet.Value,
+				Name:  facet.Name,
+				Count: facet.Count,
+			})
+		}
+		return list
+	}
+	out = &v1.GameSearchFacets{
+		Categories:      convert(in.Categories),
+		Tags:            convert(in.Tags),
+		DistributeTypes: convert(in.DistributeTypes),
+	}
+	return
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+)
+
+var (
+	SensitiveWordController = &sensitiveWord{}
+)
+
+// sensitiveWord 敏感词控制器
+type sensitiveWord struct{}
+
+// 创建敏感词
+func (c *sensitiveWord) CreateSensitiveWord(ctx context.Context, req *v1.CreateSensitiveWordReq) (res *v1.CreateSensitiveWordRes, err error) {
+	id, err := service.SensitiveWord().CreateWord(ctx, &model.SensitiveWord{
+		Word:     req.Word,
+		Category: req.Category,
+		Enabled:  req.Enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.CreateSensitiveWordRes{
+		ID: id,
+	}
+	return
+}
+
+// 更新敏感词
+func (c *sensitiveWord) UpdateSensitiveWord(ctx context.Context, req *v1.UpdateSensitiveWordReq) (res *v1.UpdateSensitiveWordRes, err error) {
+	err = service.SensitiveWord().UpdateWord(ctx, &model.SensitiveWord{
+		ID:       req.ID,
+		Category: req.Category,
+		Enabled:  req.Enabled,
+	})
+	return
+}
+
+// 删除敏感词
+func (c *sensitiveWord) DeleteSensitiveWord(ctx context.Context, req *v1.DeleteSensitiveWordReq) (res *v1.DeleteSensitiveWordRes, err error) {
+	err = service.SensitiveWord().DeleteWord(ctx, req.ID)
+	return
+}
+
+// 获取敏感词列表
+func (c *sensitiveWord) ListSensitiveWord(ctx context.Context, req *v1.ListSensitiveWordReq) (res *v1.ListSensitiveWordRes, err error) {
+	words, pageRes, err := service.SensitiveWord().ListWords(ctx, req.Keyword, &req.PageReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &v1.ListSensitiveWordRes{
+		List:    make([]*v1.SensitiveWord, 0, len(words)),
+		PageRes: pageRes,
+	}
+	for _, word := range words {
+		res.List = append(res.List, &v1.SensitiveWord{
+			ID:         word.ID,
+			Word:       word.Word,
+			Category:   word.Category,
+			Enabled:    word.Enabled,
+			CreateTime: word.CreateTime,
+			UpdateTime: word.UpdateTime,
+		})
+	}
+	return
+}
+
+// 重新加载敏感词库
+func (c *sensitiveWord) ReloadSensitiveWord(ctx context.Context, req *v1.ReloadSensitiveWordReq) (res *v1.ReloadSensitiveWordRes, err error) {
+	err = service.SensitiveWord().Reload(ctx)
+	return
+}
+
+// 检测文本
+func (c *sensitiveWord) CheckSensitiveWord(ctx context.Context, req *v1.CheckSensitiveWordReq) (res *v1.CheckSensitiveWordRes, err error) {
+	res = &v1.CheckSensitiveWordRes{
+		Hits:   service.SensitiveWord().Match(ctx, req.Text),
+		Masked: service.SensitiveWord().Mask(ctx, req.Text),
+	}
+	if res.Hits == nil {
+		res.Hits = make([]string, 0)
+	}
+	return
+}
@@ -16,30 +16,38 @@ type AsyncTaskDao struct {
 
 // AsyncTaskColumns defines and stores column names for table t_async_task.
 type AsyncTaskColumns struct {
-	ID            string // 主键
-	CustomID      string // 自定义任务ID
-	TaskType      string // 任务类型
-	Status        string // 任务状态
-	RetryCount    string // 重试次数
-	Content       string // 任务内容
-	Version       string // 版本标识
-	NextRetryTime string // 下次处理时间
-	CreateTime    string // 创建时间
-	UpdateTime    string // 更新时间
+	ID              string // 主键
+	CustomID        string // 自定义任务ID
+	TaskType        string // 任务类型
+	Status          string // 任务状态
+	RetryCount      string // 重试次数
+	Content         string // 任务内容
+	Version         string // 版本标识
+	NextRetryTime   string // 下次处理时间
+	LastError       string // 最近一次执行失败的错误信息
+	LastErrorStack  string // 最近一次执行失败的调用栈
+	LeaseOwner      string // 领取任务的实例和领取标识
+	LeaseExpireTime string // 租约到期时间
+	CreateTime      string // 创建时间
+	UpdateTime      string // 更新时间
 }
 
 // asyncTaskColumns holds the columns for table t_async_task.
 var asyncTaskColumns = AsyncTaskColumns{
-	ID:            "id",
-	CustomID:      "custom_id",
-	TaskType:      "task_type",
-	Status:        "status",
-	RetryCount:    "retry_count",
-	Content:       "content",
-	Version:       "version",
-	NextRetryTime: "next_retry_time",
-	CreateTime:    "create_time",
-	UpdateTime:    "update_time",
+	ID:              "id",
+	CustomID:        "custom_id",
+	TaskType:        "task_type",
+	Status:          "status",
+	RetryCount:      "retry_count",
+	Content:         "content",
+	Version:         "version",
+	NextRetryTime:   "next_retry_time",
+	LastError:       "last_error",
+	LastErrorStack:  "last_error_stack",
+	LeaseOwner:      "lease_owner",
+	LeaseExpireTime: "lease_expire_time",
+	CreateTime:      "create_time",
+	UpdateTime:      "update_time",
 }
 
 // NewAsyncTaskDao creates and returns a new DAO object for table data access.
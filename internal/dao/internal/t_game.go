@@ -24,6 +24,11 @@ type GameColumns struct {
 	Description    string // 游戏描述
 	Details        string // 游戏详情
 
+	NamePinyin        string // 名称全拼
+	NameInitials      string // 名称拼音首字母
+	DeveloperPinyin   string // 开发商全拼
+	DeveloperInitials string // 开发商拼音首字母
+
 	Status       string // 状态
 	PublishTime  string // 发布时间
 	ReserveCount string // 预约次数
@@ -48,6 +53,11 @@ var gameColumns = GameColumns{
 	Description:    "description",
 	Details:        "details",
 
+	NamePinyin:        "name_pinyin",
+	NameInitials:      "name_initials",
+	DeveloperPinyin:   "developer_pinyin",
+	DeveloperInitials: "developer_initials",
+
 	Status:       "status",
 	PublishTime:  "publish_time",
 	ReserveCount: "reserve_count",
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameApkInfoDao is the data access object for table t_game_apk_info.
+type GameApkInfoDao struct {
+	table   string             // table is the underlying table name of the DAO.
+	group   string             // group is the database configuration group name of current DAO.
+	columns GameApkInfoColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameApkInfoColumns defines and stores column names for table t_game_apk_info.
+type GameApkInfoColumns struct {
+	ID              string // 主键
+	GameID          string // 游戏ID
+	FileID          string // 文件ID
+	Status          string // 解析状态
+	PackageName     string // 包名
+	VersionCode     string // 版本号
+	VersionName     string // 版本名称
+	MinSdk          string // 最低SDK版本
+	TargetSdk       string // 目标SDK版本
+	Permissions     string // 申请的权限
+	SignatureScheme string // 签名方案
+	CertSha256      string // 签名证书SHA-256指纹
+	CertSubject     string // 签名证书主题
+	Error           string // 解析失败原因
+	ApproveTime     string // 审核通过时间
+	CreateTime      string // 创建时间
+	UpdateTime      string // 更新时间
+}
+
+// gameApkInfoColumns holds the columns for table t_game_apk_info.
+var gameApkInfoColumns = GameApkInfoColumns{
+	ID:              "id",
+	GameID:          "game_id",
+	FileID:          "file_id",
+	Status:          "status",
+	PackageName:     "package_name",
+	VersionCode:     "version_code",
+	VersionName:     "version_name",
+	MinSdk:          "min_sdk",
+	TargetSdk:       "target_sdk",
+	Permissions:     "permissions",
+	SignatureScheme: "signature_scheme",
+	CertSha256:      "cert_sha256",
+	CertSubject:     "cert_subject",
+	Error:           "error",
+	ApproveTime:     "approve_time",
+	CreateTime:      "create_time",
+	UpdateTime:      "update_time",
+}
+
+// NewGameApkInfoDao creates and returns a new DAO object for table data access.
+func NewGameApkInfoDao() *GameApkInfoDao {
+	return &GameApkInfoDao{
+		group:   "default",
+		table:   "t_game_apk_info",
+		columns: gameApkInfoColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameApkInfoDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameApkInfoDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameApkInfoDao) Columns() GameApkInfoColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameApkInfoDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameApkInfoDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameApkInfoDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameBuildDao is the data access object for table t_game_build.
+type GameBuildDao struct {
+	table   string           // table is the underlying table name of the DAO.
+	group   string           // group is the database configuration group name of current DAO.
+	columns GameBuildColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameBuildColumns defines and stores column names for table t_game_build.
+type GameBuildColumns struct {
+	ID           string // 主键
+	GameID       string // 游戏ID
+	VersionName  string // 版本名称
+	VersionCode  string // 版本号
+	ReleaseNotes string // 更新说明
+	FileID       string // 文件ID
+	FileSize     string // 文件大小
+	Checksum     string // 文件校验和
+	MediaUrl     string // 文件URL
+	FileStatus   string // 文件上传状态
+	Status       string // 审核状态
+	RejectReason string // 拒绝原因
+	IsCurrent    string // 是否为当前版本
+	CreateTime   string // 创建时间
+	UpdateTime   string // 更新时间
+}
+
+// gameBuildColumns holds the columns for table t_game_build.
+var gameBuildColumns = GameBuildColumns{
+	ID:           "id",
+	GameID:       "game_id",
+	VersionName:  "version_name",
+	VersionCode:  "version_code",
+	ReleaseNotes: "release_notes",
+	FileID:       "file_id",
+	FileSize:     "file_size",
+	Checksum:     "checksum",
+	MediaUrl:     "media_url",
+	FileStatus:   "file_status",
+	Status:       "status",
+	RejectReason: "reject_reason",
+	IsCurrent:    "is_current",
+	CreateTime:   "create_time",
+	UpdateTime:   "update_time",
+}
+
+// NewGameBuildDao creates and returns a new DAO object for table data access.
+func NewGameBuildDao() *GameBuildDao {
+	return &GameBuildDao{
+		group:   "default",
+		table:   "t_game_build",
+		columns: gameBuildColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameBuildDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameBuildDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameBuildDao) Columns() GameBuildColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameBuildDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameBuildDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameBuildDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
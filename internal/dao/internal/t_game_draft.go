@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameDraftDao is the data access object for table t_game_draft.
+type GameDraftDao struct {
+	table   string           // table is the underlying table name of the DAO.
+	group   string           // group is the database configuration group name of current DAO.
+	columns GameDraftColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameDraftColumns defines and stores column names for table t_game_draft.
+type GameDraftColumns struct {
+	ID             string // 主键
+	GameID         string // 游戏ID
+	Name           string // 游戏名称
+	DistributeType string // 游戏分发类型
+	Developer      string // 开发商
+	Publisher      string // 发行商
+	Description    string // 游戏描述
+	Details        string // 游戏详情
+	CategoryID     string // 分类ID
+	TagIDs         string // 标签ID列表
+	Status         string // 草稿状态
+	Version        string // 版本
+	CreateTime     string // 创建时间
+	UpdateTime     string // 更新时间
+}
+
+// gameDraftColumns holds the columns for table t_game_draft.
+var gameDraftColumns = GameDraftColumns{
+	ID:             "id",
+	GameID:         "game_id",
+	Name:           "name",
+	DistributeType: "distribute_type",
+	Developer:      "developer",
+	Publisher:      "publisher",
+	Description:    "description",
+	Details:        "details",
+	CategoryID:     "category_id",
+	TagIDs:         "tag_ids",
+	Status:         "status",
+	Version:        "version",
+	CreateTime:     "create_time",
+	UpdateTime:     "update_time",
+}
+
+// NewGameDraftDao creates and returns a new DAO object for table data access.
+func NewGameDraftDao() *GameDraftDao {
+	return &GameDraftDao{
+		group:   "default",
+		table:   "t_game_draft",
+		columns: gameDraftColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameDraftDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameDraftDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameDraftDao) Columns() GameDraftColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameDraftDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameDraftDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameDraftDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
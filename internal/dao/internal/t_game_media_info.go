@@ -21,6 +21,9 @@ type GameMediaColumns struct {
 	FileID     string // 文件ID
 	MediaType  string // 媒体类型
 	MediaUrl   string // 媒体URL
+	FileSize   string // 文件大小(字节)
+	Width      string // 宽度(像素)
+	Height     string // 高度(像素)
 	Status     string // 状态
 	CreateTime string // 创建时间
 	UpdateTime string // 更新时间
@@ -33,6 +36,9 @@ var gameMediaColumns = GameMediaColumns{
 	FileID:     "file_id",
 	MediaType:  "media_type",
 	MediaUrl:   "media_url",
+	FileSize:   "file_size",
+	Width:      "width",
+	Height:     "height",
 	Status:     "status",
 	CreateTime: "create_time",
 	UpdateTime: "update_time",
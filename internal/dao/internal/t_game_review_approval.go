@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameReviewApprovalDao is the data access object for table t_game_review_approval.
+type GameReviewApprovalDao struct {
+	table   string                    // table is the underlying table name of the DAO.
+	group   string                    // group is the database configuration group name of current DAO.
+	columns GameReviewApprovalColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameReviewApprovalColumns defines and stores column names for table t_game_review_approval.
+type GameReviewApprovalColumns struct {
+	ID           string // 主键
+	GameID       string // 游戏ID
+	ReviewerID   string // 审核人ID
+	ReviewerName string // 审核人名称
+	CreateTime   string // 创建时间
+}
+
+// gameReviewApprovalColumns holds the columns for table t_game_review_approval.
+var gameReviewApprovalColumns = GameReviewApprovalColumns{
+	ID:           "id",
+	GameID:       "game_id",
+	ReviewerID:   "reviewer_id",
+	ReviewerName: "reviewer_name",
+	CreateTime:   "create_time",
+}
+
+// NewGameReviewApprovalDao creates and returns a new DAO object for table data access.
+func NewGameReviewApprovalDao() *GameReviewApprovalDao {
+	return &GameReviewApprovalDao{
+		group:   "default",
+		table:   "t_game_review_approval",
+		columns: gameReviewApprovalColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameReviewApprovalDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameReviewApprovalDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameReviewApprovalDao) Columns() GameReviewApprovalColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameReviewApprovalDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameReviewApprovalDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameReviewApprovalDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameReviewClaimDao is the data access object for table t_game_review_claim.
+type GameReviewClaimDao struct {
+	table   string                 // table is the underlying table name of the DAO.
+	group   string                 // group is the database configuration group name of current DAO.
+	columns GameReviewClaimColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameReviewClaimColumns defines and stores column names for table t_game_review_claim.
+type GameReviewClaimColumns struct {
+	ID              string // 主键
+	GameID          string // 游戏ID
+	ReviewerID      string // 审核人ID
+	ReviewerName    string // 审核人名称
+	LeaseExpireTime string // 租约到期时间
+	Version         string // 并发版本控制
+	CreateTime      string // 创建时间
+	UpdateTime      string // 更新时间
+}
+
+// gameReviewClaimColumns holds the columns for table t_game_review_claim.
+var gameReviewClaimColumns = GameReviewClaimColumns{
+	ID:              "id",
+	GameID:          "game_id",
+	ReviewerID:      "reviewer_id",
+	ReviewerName:    "reviewer_name",
+	LeaseExpireTime: "lease_expire_time",
+	Version:         "version",
+	CreateTime:      "create_time",
+	UpdateTime:      "update_time",
+}
+
+// NewGameReviewClaimDao creates and returns a new DAO object for table data access.
+func NewGameReviewClaimDao() *GameReviewClaimDao {
+	return &GameReviewClaimDao{
+		group:   "default",
+		table:   "t_game_review_claim",
+		columns: gameReviewClaimColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameReviewClaimDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameReviewClaimDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameReviewClaimDao) Columns() GameReviewClaimColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameReviewClaimDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameReviewClaimDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameReviewClaimDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameReviewCommentDao is the data access object for table t_game_review_comment.
+type GameReviewCommentDao struct {
+	table   string                   // table is the underlying table name of the DAO.
+	group   string                   // group is the database configuration group name of current DAO.
+	columns GameReviewCommentColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameReviewCommentColumns defines and stores column names for table t_game_review_comment.
+type GameReviewCommentColumns struct {
+	ID         string // 主键
+	IssueID    string // 问题ID
+	GameID     string // 游戏ID
+	UserID     string // 用户ID
+	UserName   string // 用户名
+	Content    string // 内容
+	CreateTime string // 创建时间
+}
+
+// gameReviewCommentColumns holds the columns for table t_game_review_comment.
+var gameReviewCommentColumns = GameReviewCommentColumns{
+	ID:         "id",
+	IssueID:    "issue_id",
+	GameID:     "game_id",
+	UserID:     "user_id",
+	UserName:   "user_name",
+	Content:    "content",
+	CreateTime: "create_time",
+}
+
+// NewGameReviewCommentDao creates and returns a new DAO object for table data access.
+func NewGameReviewCommentDao() *GameReviewCommentDao {
+	return &GameReviewCommentDao{
+		group:   "default",
+		table:   "t_game_review_comment",
+		columns: gameReviewCommentColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameReviewCommentDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameReviewCommentDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameReviewCommentDao) Columns() GameReviewCommentColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameReviewCommentDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameReviewCommentDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameReviewCommentDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
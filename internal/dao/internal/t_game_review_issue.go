@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameReviewIssueDao is the data access object for table t_game_review_issue.
+type GameReviewIssueDao struct {
+	table   string                 // table is the underlying table name of the DAO.
+	group   string                 // group is the database configuration group name of current DAO.
+	columns GameReviewIssueColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameReviewIssueColumns defines and stores column names for table t_game_review_issue.
+type GameReviewIssueColumns struct {
+	ID           string // 主键
+	GameID       string // 游戏ID
+	Code         string // 拒绝原因编码
+	Field        string // 问题字段
+	Comment      string // 审核意见
+	Status       string // 问题状态
+	ReviewerID   string // 审核人ID
+	ReviewerName string // 审核人名称
+	ResolvedBy   string // 解决人ID
+	ResolveTime  string // 解决时间
+	CreateTime   string // 创建时间
+	UpdateTime   string // 更新时间
+}
+
+// gameReviewIssueColumns holds the columns for table t_game_review_issue.
+var gameReviewIssueColumns = GameReviewIssueColumns{
+	ID:           "id",
+	GameID:       "game_id",
+	Code:         "code",
+	Field:        "field",
+	Comment:      "comment",
+	Status:       "status",
+	ReviewerID:   "reviewer_id",
+	ReviewerName: "reviewer_name",
+	ResolvedBy:   "resolved_by",
+	ResolveTime:  "resolve_time",
+	CreateTime:   "create_time",
+	UpdateTime:   "update_time",
+}
+
+// NewGameReviewIssueDao creates and returns a new DAO object for table data access.
+func NewGameReviewIssueDao() *GameReviewIssueDao {
+	return &GameReviewIssueDao{
+		group:   "default",
+		table:   "t_game_review_issue",
+		columns: gameReviewIssueColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameReviewIssueDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameReviewIssueDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameReviewIssueDao) Columns() GameReviewIssueColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameReviewIssueDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameReviewIssueDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameReviewIssueDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
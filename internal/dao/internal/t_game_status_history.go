@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameStatusHistoryDao is the data access object for table t_game_status_history.
+type GameStatusHistoryDao struct {
+	table   string                   // table is the underlying table name of the DAO.
+	group   string                   // group is the database configuration group name of current DAO.
+	columns GameStatusHistoryColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameStatusHistoryColumns defines and stores column names for table t_game_status_history.
+type GameStatusHistoryColumns struct {
+	ID           string // 主键
+	GameID       string // 游戏ID
+	FromStatus   string // 变更前状态
+	ToStatus     string // 变更后状态
+	Event        string // 触发事件
+	OperatorID   string // 操作人ID
+	OperatorName string // 操作人名称
+	Reason       string // 原因
+	EventData    string // 事件数据
+	CreateTime   string // 创建时间
+}
+
+// gameStatusHistoryColumns holds the columns for table t_game_status_history.
+var gameStatusHistoryColumns = GameStatusHistoryColumns{
+	ID:           "id",
+	GameID:       "game_id",
+	FromStatus:   "from_status",
+	ToStatus:     "to_status",
+	Event:        "event",
+	OperatorID:   "operator_id",
+	OperatorName: "operator_name",
+	Reason:       "reason",
+	EventData:    "event_data",
+	CreateTime:   "create_time",
+}
+
+// NewGameStatusHistoryDao creates and returns a new DAO object for table data access.
+func NewGameStatusHistoryDao() *GameStatusHistoryDao {
+	return &GameStatusHistoryDao{
+		group:   "default",
+		table:   "t_game_status_history",
+		columns: gameStatusHistoryColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameStatusHistoryDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameStatusHistoryDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameStatusHistoryDao) Columns() GameStatusHistoryColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameStatusHistoryDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameStatusHistoryDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameStatusHistoryDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
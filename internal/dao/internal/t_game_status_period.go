@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameStatusPeriodDao is the data access object for table t_game_status_period.
+type GameStatusPeriodDao struct {
+	table   string                  // table is the underlying table name of the DAO.
+	group   string                  // group is the database configuration group name of current DAO.
+	columns GameStatusPeriodColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameStatusPeriodColumns defines and stores column names for table t_game_status_period.
+type GameStatusPeriodColumns struct {
+	ID             string // 主键
+	GameID         string // 游戏ID
+	Status         string // 状态
+	EnterTime      string // 进入状态时间
+	LeaveTime      string // 离开状态时间
+	Duration       string // 停留时长(秒)
+	OverdueAlerted string // 是否已发送超时告警
+	CreateTime     string // 创建时间
+}
+
+// gameStatusPeriodColumns holds the columns for table t_game_status_period.
+var gameStatusPeriodColumns = GameStatusPeriodColumns{
+	ID:             "id",
+	GameID:         "game_id",
+	Status:         "status",
+	EnterTime:      "enter_time",
+	LeaveTime:      "leave_time",
+	Duration:       "duration",
+	OverdueAlerted: "overdue_alerted",
+	CreateTime:     "create_time",
+}
+
+// NewGameStatusPeriodDao creates and returns a new DAO object for table data access.
+func NewGameStatusPeriodDao() *GameStatusPeriodDao {
+	return &GameStatusPeriodDao{
+		group:   "default",
+		table:   "t_game_status_period",
+		columns: gameStatusPeriodColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameStatusPeriodDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameStatusPeriodDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameStatusPeriodDao) Columns() GameStatusPeriodColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameStatusPeriodDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameStatusPeriodDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameStatusPeriodDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// GameValidationFindingDao is the data access object for table t_game_validation_finding.
+type GameValidationFindingDao struct {
+	table   string                       // table is the underlying table name of the DAO.
+	group   string                       // group is the database configuration group name of current DAO.
+	columns GameValidationFindingColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// GameValidationFindingColumns defines and stores column names for table t_game_validation_finding.
+type GameValidationFindingColumns struct {
+	ID         string // 主键
+	GameID     string // 游戏ID
+	Draft      string // 是否为草稿提交
+	Validator  string // 检查项
+	Severity   string // 严重程度
+	Field      string // 问题字段
+	Message    string // 问题描述
+	CreateTime string // 创建时间
+}
+
+// gameValidationFindingColumns holds the columns for table t_game_validation_finding.
+var gameValidationFindingColumns = GameValidationFindingColumns{
+	ID:         "id",
+	GameID:     "game_id",
+	Draft:      "draft",
+	Validator:  "validator",
+	Severity:   "severity",
+	Field:      "field",
+	Message:    "message",
+	CreateTime: "create_time",
+}
+
+// NewGameValidationFindingDao creates and returns a new DAO object for table data access.
+func NewGameValidationFindingDao() *GameValidationFindingDao {
+	return &GameValidationFindingDao{
+		group:   "default",
+		table:   "t_game_validation_finding",
+		columns: gameValidationFindingColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *GameValidationFindingDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *GameValidationFindingDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *GameValidationFindingDao) Columns() GameValidationFindingColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *GameValidationFindingDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *GameValidationFindingDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *GameValidationFindingDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
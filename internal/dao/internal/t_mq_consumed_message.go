@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// MQConsumedMessageDao is the data access object for table t_mq_consumed_message.
+type MQConsumedMessageDao struct {
+	table   string                   // table is the underlying table name of the DAO.
+	group   string                   // group is the database configuration group name of current DAO.
+	columns MQConsumedMessageColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// MQConsumedMessageColumns defines and stores column names for table t_mq_consumed_message.
+type MQConsumedMessageColumns struct {
+	ID         string // 主键
+	Consumer   string // 消费者
+	MessageID  string // 消息ID
+	CreateTime string // 处理时间
+}
+
+// mqConsumedMessageColumns holds the columns for table t_mq_consumed_message.
+var mqConsumedMessageColumns = MQConsumedMessageColumns{
+	ID:         "id",
+	Consumer:   "consumer",
+	MessageID:  "message_id",
+	CreateTime: "create_time",
+}
+
+// NewMQConsumedMessageDao creates and returns a new DAO object for table data access.
+func NewMQConsumedMessageDao() *MQConsumedMessageDao {
+	return &MQConsumedMessageDao{
+		group:   "default",
+		table:   "t_mq_consumed_message",
+		columns: mqConsumedMessageColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *MQConsumedMessageDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *MQConsumedMessageDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *MQConsumedMessageDao) Columns() MQConsumedMessageColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *MQConsumedMessageDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *MQConsumedMessageDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *MQConsumedMessageDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
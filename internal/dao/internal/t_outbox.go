@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// OutboxDao is the data access object for table t_outbox.
+type OutboxDao struct {
+	table   string        // table is the underlying table name of the DAO.
+	group   string        // group is the database configuration group name of current DAO.
+	columns OutboxColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// OutboxColumns defines and stores column names for table t_outbox.
+type OutboxColumns struct {
+	ID              string // 主键
+	Topic           string // 消息队列主题
+	EventType       string // 领域事件类型
+	DedupKey        string // 去重键
+	Payload         string // 消息体
+	Status          string // 状态
+	RetryCount      string // 发送失败次数
+	NextRetryTime   string // 下次发送时间
+	LastError       string // 最近一次发送失败的错误信息
+	LeaseOwner      string // 领取标识
+	LeaseExpireTime string // 租约到期时间
+	PublishTime     string // 发送成功时间
+	CreateTime      string // 创建时间
+	UpdateTime      string // 更新时间
+}
+
+// outboxColumns holds the columns for table t_outbox.
+var outboxColumns = OutboxColumns{
+	ID:              "id",
+	Topic:           "topic",
+	EventType:       "event_type",
+	DedupKey:        "dedup_key",
+	Payload:         "payload",
+	Status:          "status",
+	RetryCount:      "retry_count",
+	NextRetryTime:   "next_retry_time",
+	LastError:       "last_error",
+	LeaseOwner:      "lease_owner",
+	LeaseExpireTime: "lease_expire_time",
+	PublishTime:     "publish_time",
+	CreateTime:      "create_time",
+	UpdateTime:      "update_time",
+}
+
+// NewOutboxDao creates and returns a new DAO object for table data access.
+func NewOutboxDao() *OutboxDao {
+	return &OutboxDao{
+		group:   "default",
+		table:   "t_outbox",
+		columns: outboxColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *OutboxDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *OutboxDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *OutboxDao) Columns() OutboxColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *OutboxDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *OutboxDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *OutboxDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// RecurringTaskDao is the data access object for table t_recurring_task.
+type RecurringTaskDao struct {
+	table   string               // table is the underlying table name of the DAO.
+	group   string               // group is the database configuration group name of current DAO.
+	columns RecurringTaskColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// RecurringTaskColumns defines and stores column names for table t_recurring_task.
+type RecurringTaskColumns struct {
+	ID          string // 主键
+	Name        string // 名称
+	CronSpec    string // cron表达式
+	Paused      string // 是否暂停
+	NextRunTime string // 下次执行时间
+	LastRunTime string // 最近一次执行时间
+	Version     string // 版本标识
+	CreateTime  string // 创建时间
+	UpdateTime  string // 更新时间
+}
+
+// recurringTaskColumns holds the columns for table t_recurring_task.
+var recurringTaskColumns = RecurringTaskColumns{
+	ID:          "id",
+	Name:        "name",
+	CronSpec:    "cron_spec",
+	Paused:      "paused",
+	NextRunTime: "next_run_time",
+	LastRunTime: "last_run_time",
+	Version:     "version",
+	CreateTime:  "create_time",
+	UpdateTime:  "update_time",
+}
+
+// NewRecurringTaskDao creates and returns a new DAO object for table data access.
+func NewRecurringTaskDao() *RecurringTaskDao {
+	return &RecurringTaskDao{
+		group:   "default",
+		table:   "t_recurring_task",
+		columns: recurringTaskColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *RecurringTaskDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *RecurringTaskDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *RecurringTaskDao) Columns() RecurringTaskColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *RecurringTaskDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *RecurringTaskDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *RecurringTaskDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// ReviewRejectCodeDao is the data access object for table t_review_reject_code.
+type ReviewRejectCodeDao struct {
+	table   string                  // table is the underlying table name of the DAO.
+	group   string                  // group is the database configuration group name of current DAO.
+	columns ReviewRejectCodeColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// ReviewRejectCodeColumns defines and stores column names for table t_review_reject_code.
+type ReviewRejectCodeColumns struct {
+	ID          string // 主键
+	Code        string // 拒绝原因编码
+	Title       string // 标题
+	Description string // 描述
+	Field       string // 默认关联字段
+	Enabled     string // 是否启用
+	CreateTime  string // 创建时间
+	UpdateTime  string // 更新时间
+}
+
+// reviewRejectCodeColumns holds the columns for table t_review_reject_code.
+var reviewRejectCodeColumns = ReviewRejectCodeColumns{
+	ID:          "id",
+	Code:        "code",
+	Title:       "title",
+	Description: "description",
+	Field:       "field",
+	Enabled:     "enabled",
+	CreateTime:  "create_time",
+	UpdateTime:  "update_time",
+}
+
+// NewReviewRejectCodeDao creates and returns a new DAO object for table data access.
+func NewReviewRejectCodeDao() *ReviewRejectCodeDao {
+	return &ReviewRejectCodeDao{
+		group:   "default",
+		table:   "t_review_reject_code",
+		columns: reviewRejectCodeColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *ReviewRejectCodeDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *ReviewRejectCodeDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *ReviewRejectCodeDao) Columns() ReviewRejectCodeColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *ReviewRejectCodeDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *ReviewRejectCodeDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *ReviewRejectCodeDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
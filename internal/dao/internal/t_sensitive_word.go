@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// SensitiveWordDao is the data access object for table t_sensitive_word.
+type SensitiveWordDao struct {
+	table   string               // table is the underlying table name of the DAO.
+	group   string               // group is the database configuration group name of current DAO.
+	columns SensitiveWordColumns // columns contains all the column names of Table for convenient usage.
+}
+
+// SensitiveWordColumns defines and stores column names for table t_sensitive_word.
+type SensitiveWordColumns struct {
+	ID         string // 主键
+	Word       string // 敏感词
+	Category   string // 分类
+	Enabled    string // 是否启用
+	CreateTime string // 创建时间
+	UpdateTime string // 更新时间
+}
+
+// sensitiveWordColumns holds the columns for table t_sensitive_word.
+var sensitiveWordColumns = SensitiveWordColumns{
+	ID:         "id",
+	Word:       "word",
+	Category:   "category",
+	Enabled:    "enabled",
+	CreateTime: "create_time",
+	UpdateTime: "update_time",
+}
+
+// NewSensitiveWordDao creates and returns a new DAO object for table data access.
+func NewSensitiveWordDao() *SensitiveWordDao {
+	return &SensitiveWordDao{
+		group:   "default",
+		table:   "t_sensitive_word",
+		columns: sensitiveWordColumns,
+	}
+}
+
+// DB retrieves and returns the underlying raw database management object of current DAO.
+func (dao *SensitiveWordDao) DB() gdb.DB {
+	return g.DB(dao.group)
+}
+
+// Table returns the table name of current dao.
+func (dao *SensitiveWordDao) Table() string {
+	return dao.table
+}
+
+// Columns returns all column names of current dao.
+func (dao *SensitiveWordDao) Columns() SensitiveWordColumns {
+	return dao.columns
+}
+
+// Group returns the configuration group name of database of current DAO.
+func (dao *SensitiveWordDao) Group() string {
+	return dao.group
+}
+
+// Ctx creates and returns the Model for current DAO, It automatically sets the context for current operation.
+func (dao *SensitiveWordDao) Ctx(ctx context.Context) *gdb.Model {
+	return dao.DB().Model(dao.table).Safe().Ctx(ctx)
+}
+
+// Transaction wraps the transaction logic using function f.
+// It rollbacks the transaction and returns the error from function f if it returns non-nil error.
+// It commits the transaction and returns nil if function f returns nil.
+//
+// Note that, you should not Commit or Rollback the transaction in function f
+// as it is automatically handled by this function.
+func (dao *SensitiveWordDao) Transaction(ctx context.Context, f func(ctx context.Context, tx gdb.TX) error) (err error) {
+	return dao.Ctx(ctx).Transaction(ctx, f)
+}
@@ -18,6 +18,8 @@ type TagDao struct {
 type TagColumns struct {
 	ID         string // 主键
 	Name       string // 标签名称
+	Pinyin     string // 标签名全拼
+	Initials   string // 标签名拼音首字母
 	CreateTime string // 创建时间
 	UpdateTime string // 更新时间
 }
@@ -26,6 +28,8 @@ type TagColumns struct {
 var tagColumns = TagColumns{
 	ID:         "id",
 	Name:       "name",
+	Pinyin:     "pinyin",
+	Initials:   "initials",
 	CreateTime: "create_time",
 	UpdateTime: "update_time",
 }
@@ -6,7 +6,8 @@ import (
 	"GameEngine/internal/model/entity"
 	"context"
 	"database/sql"
-	"time"
+	"fmt"
+	"strings"
 
 	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/os/gtime"
@@ -25,6 +26,9 @@ var (
 	}
 )
 
+// likeEscaper 转义 LIKE 的通配符，按前缀匹配时使用
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func (asyncTask *asyncTaskDao) AddTask(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, content []byte) error {
 	data := map[string]interface{}{
 		asyncTask.Columns().CustomID:      customID,
@@ -60,29 +64,81 @@ func (asyncTask *asyncTaskDao) AddScheduledTask(ctx context.Context, tx gdb.TX,
 	return nil
 }
 
-func (asyncTask *asyncTaskDao) FetchPendingTask(ctx context.Context, op model.AsyncTaskType) (out *entity.AsyncTask, err error) {
-	out = &entity.AsyncTask{}
+// Upsert 按自定义任务ID添加任务；已有相同任务类型和自定义任务ID的等待执行任务(待执行、等待重试)时，替换其内容和执行时间，重试次数清零
+// 唯一性由 (task_type, active_custom_id) 唯一索引保证，active_custom_id 只在任务等待执行时等于 custom_id
+func (asyncTask *asyncTaskDao) Upsert(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, content []byte, scheduledTime *gtime.Time) error {
+	data := map[string]interface{}{
+		asyncTask.Columns().CustomID:      customID,
+		asyncTask.Columns().TaskType:      op,
+		asyncTask.Columns().Content:       string(content),
+		asyncTask.Columns().NextRetryTime: scheduledTime.UTC().Unix(),
+		asyncTask.Columns().CreateTime:    gtime.Now().UTC().Unix(),
+		asyncTask.Columns().UpdateTime:    gtime.Now().UTC().Unix(),
+	}
+	_, err := asyncTask.Ctx(ctx).TX(tx).Data(data).OnDuplicate(map[string]interface{}{
+		asyncTask.Columns().Content:       asyncTask.Columns().Content,
+		asyncTask.Columns().NextRetryTime: asyncTask.Columns().NextRetryTime,
+		asyncTask.Columns().UpdateTime:    asyncTask.Columns().UpdateTime,
+		asyncTask.Columns().Status:        gdb.Raw(fmt.Sprintf("%d", model.AsyncTaskStatusPending)),
+		asyncTask.Columns().RetryCount:    gdb.Raw("0"),
+		asyncTask.Columns().Version:       gdb.Raw(asyncTask.Columns().Version + " + 1"),
+	}).Save()
+	if err != nil {
+		return err
+	}
 
-	err = asyncTask.Ctx(ctx).
+	return nil
+}
+
+// CancelByKey 取消指定自定义任务ID的等待执行任务，返回 false 表示没有等待执行的任务
+func (asyncTask *asyncTaskDao) CancelByKey(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string) (canceled bool, err error) {
+	return asyncTask.updateByKey(ctx, tx, op, customID, map[string]interface{}{
+		asyncTask.Columns().Status: model.AsyncTaskStatusCanceled,
+	})
+}
+
+// RescheduleByKey 修改指定自定义任务ID的等待执行任务的执行时间，返回 false 表示没有等待执行的任务
+func (asyncTask *asyncTaskDao) RescheduleByKey(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, scheduledTime *gtime.Time) (rescheduled bool, err error) {
+	return asyncTask.updateByKey(ctx, tx, op, customID, map[string]interface{}{
+		asyncTask.Columns().NextRetryTime: scheduledTime.UTC().Unix(),
+	})
+}
+
+func (asyncTask *asyncTaskDao) updateByKey(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, data map[string]interface{}) (updated bool, err error) {
+	data[asyncTask.Columns().Version] = gdb.Raw(asyncTask.Columns().Version + " + 1")
+	data[asyncTask.Columns().UpdateTime] = gtime.Now().UTC().Unix()
+	result, err := asyncTask.Ctx(ctx).TX(tx).
 		Where(asyncTask.Columns().TaskType, op).
-		Where(asyncTask.Columns().Status, model.AsyncTaskStatusPending).
-		WhereLT(asyncTask.Columns().NextRetryTime, gtime.Now().UTC().Unix()).
-		OrderAsc(asyncTask.Columns().NextRetryTime).
-		Scan(&out)
+		Where(asyncTask.Columns().CustomID, customID).
+		WhereIn(asyncTask.Columns().Status, model.RunnableAsyncTaskStatuses).
+		Data(data).
+		Update()
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
 	}
 
+	return rowsAffected > 0, nil
+}
+
+// ClaimTask 领取一个已到处理时间的任务：单条 UPDATE 写入领取标识和租约到期时间，再按领取标识读取任务
+// 多个实例、多个工作线程并发领取时不会领取到同一个任务，claimID 需要全局唯一
+func (asyncTask *asyncTaskDao) ClaimTask(ctx context.Context, op model.AsyncTaskType, claimID string, leaseExpireTime int64) (out *entity.AsyncTask, err error) {
 	result, err := asyncTask.Ctx(ctx).
-		Where(asyncTask.Columns().ID, out.ID).
-		Where(asyncTask.Columns().Version, out.Version).
+		Where(asyncTask.Columns().TaskType, op).
+		WhereIn(asyncTask.Columns().Status, model.RunnableAsyncTaskStatuses).
+		WhereLTE(asyncTask.Columns().NextRetryTime, gtime.Now().UTC().Unix()).
+		OrderAsc(asyncTask.Columns().NextRetryTime).
+		Limit(1).
 		Data(map[string]interface{}{
-			asyncTask.Columns().Status:     model.AsyncTaskStatusProcessing,
-			asyncTask.Columns().Version:    out.Version + 1,
-			asyncTask.Columns().UpdateTime: gtime.Now().UTC().Unix(),
+			asyncTask.Columns().Status:          model.AsyncTaskStatusProcessing,
+			asyncTask.Columns().Version:         gdb.Raw(asyncTask.Columns().Version + " + 1"),
+			asyncTask.Columns().LeaseOwner:      claimID,
+			asyncTask.Columns().LeaseExpireTime: leaseExpireTime,
+			asyncTask.Columns().UpdateTime:      gtime.Now().UTC().Unix(),
 		}).
 		Update()
 	if err != nil {
@@ -93,18 +149,49 @@ func (asyncTask *asyncTaskDao) FetchPendingTask(ctx context.Context, op model.As
 		return nil, err
 	}
 	if rowsAffected == 0 {
-		return nil, model.ErrNoRowsAffected
+		return nil, nil
+	}
+
+	out = &entity.AsyncTask{}
+	err = asyncTask.Ctx(ctx).
+		Where(asyncTask.Columns().TaskType, op).
+		Where(asyncTask.Columns().Status, model.AsyncTaskStatusProcessing).
+		Where(asyncTask.Columns().LeaseOwner, claimID).
+		Scan(out)
+	if err != nil {
+		return nil, err
 	}
 
-	out.Version += 1
 	return out, nil
 }
 
+// RenewLease 延长执行中任务的租约，返回 false 表示租约已被回收
+func (asyncTask *asyncTaskDao) RenewLease(ctx context.Context, id int64, version int, claimID string, leaseExpireTime int64) (renewed bool, err error) {
+	result, err := asyncTask.Ctx(ctx).
+		Where(asyncTask.Columns().ID, id).
+		Where(asyncTask.Columns().Version, version).
+		Where(asyncTask.Columns().Status, model.AsyncTaskStatusProcessing).
+		Where(asyncTask.Columns().LeaseOwner, claimID).
+		Data(map[string]interface{}{
+			asyncTask.Columns().LeaseExpireTime: leaseExpireTime,
+		}).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
 func (asyncTask *asyncTaskDao) GetMinNextRetryTime(ctx context.Context, op model.AsyncTaskType) (out *entity.AsyncTask, err error) {
 	out = &entity.AsyncTask{}
 	err = asyncTask.Ctx(ctx).
 		Where(asyncTask.Columns().TaskType, op).
-		Where(asyncTask.Columns().Status, model.AsyncTaskStatusPending).
+		WhereIn(asyncTask.Columns().Status, model.RunnableAsyncTaskStatuses).
 		OrderAsc(asyncTask.Columns().NextRetryTime).
 		Limit(1).
 		Scan(out)
@@ -139,23 +226,155 @@ func (asyncTask *asyncTaskDao) Update(ctx context.Context, id int64, version int
 	return nil
 }
 
-func (asyncTask *asyncTaskDao) ResetTimeoutTasks(ctx context.Context) (rowsAffected int64, err error) {
-	result, err := asyncTask.Ctx(ctx).
+// ReclaimExpiredTasks 回收租约到期的执行中任务(实例崩溃或与数据库断开)，重新置为待执行
+// 执行期间已有相同自定义任务ID的新任务等待执行时，回收的任务被新任务取代，标记为已取消
+func (asyncTask *asyncTaskDao) ReclaimExpiredTasks(ctx context.Context) (rowsAffected int64, err error) {
+	var expiredTasks []*entity.AsyncTask
+	err = asyncTask.Ctx(ctx).
+		Fields(asyncTask.Columns().ID, asyncTask.Columns().Version).
 		Where(asyncTask.Columns().Status, model.AsyncTaskStatusProcessing).
-		WhereGT(asyncTask.Columns().UpdateTime, gtime.Now().Add(-24*time.Hour).Unix()).
-		Data(map[string]interface{}{
-			asyncTask.Columns().Status:     model.AsyncTaskStatusPending,
-			asyncTask.Columns().Version:    gdb.Raw("version + 1"),
-			asyncTask.Columns().UpdateTime: gtime.Now().Unix(),
-		}).
-		Update()
+		WhereLT(asyncTask.Columns().LeaseExpireTime, gtime.Now().UTC().Unix()).
+		Scan(&expiredTasks)
 	if err != nil {
 		return
 	}
-	rowsAffected, err = result.RowsAffected()
+
+	for _, expiredTask := range expiredTasks {
+		data := map[string]interface{}{
+			asyncTask.Columns().Status:          model.AsyncTaskStatusPending,
+			asyncTask.Columns().Version:         expiredTask.Version + 1,
+			asyncTask.Columns().LeaseOwner:      "",
+			asyncTask.Columns().LeaseExpireTime: 0,
+			asyncTask.Columns().LastError:       "执行租约到期，任务被回收",
+		}
+		reclaimed, err := asyncTask.reclaimExpiredTask(ctx, expiredTask, data)
+		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
+			data[asyncTask.Columns().Status] = model.AsyncTaskStatusCanceled
+			data[asyncTask.Columns().LastError] = "执行租约到期，已有相同自定义任务ID的新任务等待执行，任务被取代"
+			reclaimed, err = asyncTask.reclaimExpiredTask(ctx, expiredTask, data)
+		}
+		if err != nil {
+			return rowsAffected, err
+		}
+		if reclaimed {
+			rowsAffected++
+		}
+	}
+
+	return rowsAffected, nil
+}
+
+// ReleaseClaimedTasks 停机时把领取标识以 claimPrefix 开头(当前实例领取)的执行中任务放回待执行，不计入重试次数
+// 执行期间已有相同自定义任务ID的新任务等待执行时，放回的任务被新任务取代，标记为已取消
+func (asyncTask *asyncTaskDao) ReleaseClaimedTasks(ctx context.Context, claimPrefix string) (rowsAffected int64, err error) {
+	var claimedTasks []*entity.AsyncTask
+	err = asyncTask.Ctx(ctx).
+		Fields(asyncTask.Columns().ID, asyncTask.Columns().Version).
+		Where(asyncTask.Columns().Status, model.AsyncTaskStatusProcessing).
+		WhereLike(asyncTask.Columns().LeaseOwner, likeEscaper.Replace(claimPrefix)+"%").
+		Scan(&claimedTasks)
 	if err != nil {
 		return
 	}
 
-	return
+	for _, claimedTask := range claimedTasks {
+		data := map[string]interface{}{
+			asyncTask.Columns().Status:          model.AsyncTaskStatusPending,
+			asyncTask.Columns().Version:         claimedTask.Version + 1,
+			asyncTask.Columns().NextRetryTime:   gtime.Now().UTC().Unix(),
+			asyncTask.Columns().LeaseOwner:      "",
+			asyncTask.Columns().LeaseExpireTime: 0,
+		}
+		err = asyncTask.Update(ctx, claimedTask.ID, claimedTask.Version, data)
+		if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
+			data[asyncTask.Columns().Status] = model.AsyncTaskStatusCanceled
+			delete(data, asyncTask.Columns().NextRetryTime)
+			err = asyncTask.Update(ctx, claimedTask.ID, claimedTask.Version, data)
+		}
+		if err == model.ErrNoRowsAffected {
+			// 放回前任务已执行完成
+			continue
+		}
+		if err != nil {
+			return rowsAffected, err
+		}
+		rowsAffected++
+	}
+
+	return rowsAffected, nil
+}
+
+// reclaimExpiredTask 按版本号和租约到期时间更新，回收前任务已完成或已续约时返回 false
+func (asyncTask *asyncTaskDao) reclaimExpiredTask(ctx context.Context, expiredTask *entity.AsyncTask, data map[string]interface{}) (reclaimed bool, err error) {
+	data[asyncTask.Columns().UpdateTime] = gtime.Now().UTC().Unix()
+	result, err := asyncTask.Ctx(ctx).
+		Where(asyncTask.Columns().ID, expiredTask.ID).
+		Where(asyncTask.Columns().Version, expiredTask.Version).
+		Where(asyncTask.Columns().Status, model.AsyncTaskStatusProcessing).
+		WhereLT(asyncTask.Columns().LeaseExpireTime, gtime.Now().UTC().Unix()).
+		Data(data).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetByID 任务不存在时返回 nil
+func (asyncTask *asyncTaskDao) GetByID(ctx context.Context, id int64) (out *entity.AsyncTask, err error) {
+	out = &entity.AsyncTask{}
+	err = asyncTask.Ctx(ctx).Where(asyncTask.Columns().ID, id).Scan(out)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// CountByTypeAndStatus 按任务类型和状态统计任务数量
+func (asyncTask *asyncTaskDao) CountByTypeAndStatus(ctx context.Context) (counts map[model.AsyncTaskType]map[model.AsyncTaskStatus]int, err error) {
+	records, err := asyncTask.Ctx(ctx).
+		Fields(asyncTask.Columns().TaskType, asyncTask.Columns().Status, "COUNT(1) AS total").
+		Group(asyncTask.Columns().TaskType, asyncTask.Columns().Status).
+		All()
+	if err != nil {
+		return nil, err
+	}
+
+	counts = make(map[model.AsyncTaskType]map[model.AsyncTaskStatus]int)
+	for _, record := range records {
+		taskType := model.AsyncTaskType(record[asyncTask.Columns().TaskType].Int())
+		if counts[taskType] == nil {
+			counts[taskType] = make(map[model.AsyncTaskStatus]int)
+		}
+		counts[taskType][model.AsyncTaskStatus(record[asyncTask.Columns().Status].Int())] = record["total"].Int()
+	}
+	return counts, nil
+}
+
+// GetOldestRunnableTime 各任务类型中已到处理时间但未执行的任务，最早的下次处理时间
+func (asyncTask *asyncTaskDao) GetOldestRunnableTime(ctx context.Context) (out map[model.AsyncTaskType]int64, err error) {
+	records, err := asyncTask.Ctx(ctx).
+		Fields(asyncTask.Columns().TaskType, "MIN("+asyncTask.Columns().NextRetryTime+") AS oldest").
+		WhereIn(asyncTask.Columns().Status, model.RunnableAsyncTaskStatuses).
+		WhereLTE(asyncTask.Columns().NextRetryTime, gtime.Now().UTC().Unix()).
+		Group(asyncTask.Columns().TaskType).
+		All()
+	if err != nil {
+		return nil, err
+	}
+
+	out = make(map[model.AsyncTaskType]int64, len(records))
+	for _, record := range records {
+		out[model.AsyncTaskType(record[asyncTask.Columns().TaskType].Int())] = record["oldest"].Int64()
+	}
+	return out, nil
 }
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameApkInfoDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameApkInfoDao struct {
+	*internal.GameApkInfoDao
+}
+
+var (
+	// GameApkInfo is globally public accessible object for table t_game_apk_info operations.
+	GameApkInfo = gameApkInfoDao{
+		internal.NewGameApkInfoDao(),
+	}
+)
+
+// Fill with you ideas below.
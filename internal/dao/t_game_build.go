@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameBuildDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameBuildDao struct {
+	*internal.GameBuildDao
+}
+
+var (
+	// GameBuild is globally public accessible object for table t_game_build operations.
+	GameBuild = gameBuildDao{
+		internal.NewGameBuildDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameDraftDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameDraftDao struct {
+	*internal.GameDraftDao
+}
+
+var (
+	// GameDraft is globally public accessible object for table t_game_draft operations.
+	GameDraft = gameDraftDao{
+		internal.NewGameDraftDao(),
+	}
+)
+
+// Fill with you ideas below.
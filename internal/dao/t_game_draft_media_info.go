@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameDraftMediaInfoDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameDraftMediaInfoDao struct {
+	*internal.GameDraftMediaInfoDao
+}
+
+var (
+	// GameDraftMediaInfo is globally public accessible object for table t_game_draft_media_info operations.
+	GameDraftMediaInfo = gameDraftMediaInfoDao{
+		internal.NewGameDraftMediaInfoDao(),
+	}
+)
+
+// Fill with you ideas below.
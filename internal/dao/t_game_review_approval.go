@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameReviewApprovalDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameReviewApprovalDao struct {
+	*internal.GameReviewApprovalDao
+}
+
+var (
+	// GameReviewApproval is globally public accessible object for table t_game_review_approval operations.
+	GameReviewApproval = gameReviewApprovalDao{
+		internal.NewGameReviewApprovalDao(),
+	}
+)
+
+// Fill with you ideas below.
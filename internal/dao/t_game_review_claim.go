@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameReviewClaimDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameReviewClaimDao struct {
+	*internal.GameReviewClaimDao
+}
+
+var (
+	// GameReviewClaim is globally public accessible object for table t_game_review_claim operations.
+	GameReviewClaim = gameReviewClaimDao{
+		internal.NewGameReviewClaimDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameReviewCommentDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameReviewCommentDao struct {
+	*internal.GameReviewCommentDao
+}
+
+var (
+	// GameReviewComment is globally public accessible object for table t_game_review_comment operations.
+	GameReviewComment = gameReviewCommentDao{
+		internal.NewGameReviewCommentDao(),
+	}
+)
+
+// Fill with you ideas below.
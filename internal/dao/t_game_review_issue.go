@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameReviewIssueDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameReviewIssueDao struct {
+	*internal.GameReviewIssueDao
+}
+
+var (
+	// GameReviewIssue is globally public accessible object for table t_game_review_issue operations.
+	GameReviewIssue = gameReviewIssueDao{
+		internal.NewGameReviewIssueDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameStatusHistoryDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameStatusHistoryDao struct {
+	*internal.GameStatusHistoryDao
+}
+
+var (
+	// GameStatusHistory is globally public accessible object for table t_game_status_history operations.
+	GameStatusHistory = gameStatusHistoryDao{
+		internal.NewGameStatusHistoryDao(),
+	}
+)
+
+// Fill with you ideas below.
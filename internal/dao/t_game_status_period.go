@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameStatusPeriodDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameStatusPeriodDao struct {
+	*internal.GameStatusPeriodDao
+}
+
+var (
+	// GameStatusPeriod is globally public accessible object for table t_game_status_period operations.
+	GameStatusPeriod = gameStatusPeriodDao{
+		internal.NewGameStatusPeriodDao(),
+	}
+)
+
+// Fill with you ideas below.
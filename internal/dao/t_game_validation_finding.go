@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// gameValidationFindingDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type gameValidationFindingDao struct {
+	*internal.GameValidationFindingDao
+}
+
+var (
+	// GameValidationFinding is globally public accessible object for table t_game_validation_finding operations.
+	GameValidationFinding = gameValidationFindingDao{
+		internal.NewGameValidationFindingDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,53 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// mqConsumedMessageDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type mqConsumedMessageDao struct {
+	*internal.MQConsumedMessageDao
+}
+
+var (
+	// MQConsumedMessage is globally public accessible object for table t_mq_consumed_message operations.
+	MQConsumedMessage = mqConsumedMessageDao{
+		internal.NewMQConsumedMessageDao(),
+	}
+)
+
+// Add 记录已处理的消息，需要与处理结果处于同一事务中；已处理过时返回 false
+func (consumed *mqConsumedMessageDao) Add(ctx context.Context, tx gdb.TX, consumer string, messageID string) (added bool, err error) {
+	result, err := consumed.Ctx(ctx).TX(tx).Data(map[string]interface{}{
+		consumed.Columns().Consumer:   consumer,
+		consumed.Columns().MessageID:  messageID,
+		consumed.Columns().CreateTime: gtime.Now().UTC().Unix(),
+	}).InsertIgnore()
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteBefore 删除处理时间早于 before 的记录，每次最多删除 limit 条
+func (consumed *mqConsumedMessageDao) DeleteBefore(ctx context.Context, before int64, limit int) (rowsAffected int64, err error) {
+	result, err := consumed.Ctx(ctx).
+		WhereLT(consumed.Columns().CreateTime, before).
+		Limit(limit).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,113 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// outboxDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type outboxDao struct {
+	*internal.OutboxDao
+}
+
+var (
+	// Outbox is globally public accessible object for table t_outbox operations.
+	Outbox = outboxDao{
+		internal.NewOutboxDao(),
+	}
+)
+
+// Add 写入待发送的消息，需要与业务数据处于同一事务中；去重键已存在时忽略
+func (outbox *outboxDao) Add(ctx context.Context, tx gdb.TX, topic string, eventType string, dedupKey string, payload []byte) error {
+	data := map[string]interface{}{
+		outbox.Columns().Topic:         topic,
+		outbox.Columns().EventType:     eventType,
+		outbox.Columns().DedupKey:      dedupKey,
+		outbox.Columns().Payload:       string(payload),
+		outbox.Columns().NextRetryTime: gtime.Now().UTC().Unix(),
+		outbox.Columns().CreateTime:    gtime.Now().UTC().Unix(),
+		outbox.Columns().UpdateTime:    gtime.Now().UTC().Unix(),
+	}
+	_, err := outbox.Ctx(ctx).TX(tx).Data(data).InsertIgnore()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Claim 领取一批到达发送时间的待发送消息，按写入顺序；租约到期前其他实例不会领取
+func (outbox *outboxDao) Claim(ctx context.Context, claimID string, leaseExpireTime int64, limit int) (outs []*entity.Outbox, err error) {
+	now := gtime.Now().UTC().Unix()
+	result, err := outbox.Ctx(ctx).
+		Where(outbox.Columns().Status, model.OutboxStatusPending).
+		WhereLTE(outbox.Columns().NextRetryTime, now).
+		WhereLT(outbox.Columns().LeaseExpireTime, now).
+		OrderAsc(outbox.Columns().ID).
+		Limit(limit).
+		Data(map[string]interface{}{
+			outbox.Columns().LeaseOwner:      claimID,
+			outbox.Columns().LeaseExpireTime: leaseExpireTime,
+			outbox.Columns().UpdateTime:      now,
+		}).
+		Update()
+	if err != nil {
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil || rowsAffected == 0 {
+		return nil, err
+	}
+
+	err = outbox.Ctx(ctx).
+		Where(outbox.Columns().LeaseOwner, claimID).
+		Where(outbox.Columns().Status, model.OutboxStatusPending).
+		OrderAsc(outbox.Columns().ID).
+		Scan(&outs)
+	if err != nil {
+		return nil, err
+	}
+
+	return outs, nil
+}
+
+// UpdateClaimed 更新本次领取的消息并释放租约，租约已到期被其他实例领取时返回 false
+func (outbox *outboxDao) UpdateClaimed(ctx context.Context, id int64, claimID string, data map[string]interface{}) (updated bool, err error) {
+	data[outbox.Columns().LeaseOwner] = ""
+	data[outbox.Columns().LeaseExpireTime] = 0
+	data[outbox.Columns().UpdateTime] = gtime.Now().UTC().Unix()
+	result, err := outbox.Ctx(ctx).
+		Where(outbox.Columns().ID, id).
+		Where(outbox.Columns().LeaseOwner, claimID).
+		Data(data).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeletePublished 删除发送时间早于 before 的已发送消息，每次最多删除 limit 条
+func (outbox *outboxDao) DeletePublished(ctx context.Context, before int64, limit int) (rowsAffected int64, err error) {
+	result, err := outbox.Ctx(ctx).
+		Where(outbox.Columns().Status, model.OutboxStatusPublished).
+		WhereLT(outbox.Columns().PublishTime, before).
+		Limit(limit).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+	"context"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// recurringTaskDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type recurringTaskDao struct {
+	*internal.RecurringTaskDao
+}
+
+var (
+	// RecurringTask is globally public accessible object for table t_recurring_task operations.
+	RecurringTask = recurringTaskDao{
+		internal.NewRecurringTaskDao(),
+	}
+)
+
+func (recurringTask *recurringTaskDao) Create(ctx context.Context, name string, cronSpec string, nextRunTime int64) error {
+	data := map[string]interface{}{
+		recurringTask.Columns().Name:        name,
+		recurringTask.Columns().CronSpec:    cronSpec,
+		recurringTask.Columns().NextRunTime: nextRunTime,
+		recurringTask.Columns().CreateTime:  gtime.Now().UTC().Unix(),
+		recurringTask.Columns().UpdateTime:  gtime.Now().UTC().Unix(),
+	}
+	_, err := recurringTask.Ctx(ctx).Data(data).Insert()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateWithVersion 按版本号更新，版本号不一致(已被其他实例或管理员修改)时返回 false
+func (recurringTask *recurringTaskDao) UpdateWithVersion(ctx context.Context, id int64, version int, data map[string]interface{}) (updated bool, err error) {
+	data[recurringTask.Columns().Version] = version + 1
+	data[recurringTask.Columns().UpdateTime] = gtime.Now().UTC().Unix()
+	result, err := recurringTask.Ctx(ctx).
+		Where(recurringTask.Columns().ID, id).
+		Where(recurringTask.Columns().Version, version).
+		Data(data).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
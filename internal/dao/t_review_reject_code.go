@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// reviewRejectCodeDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type reviewRejectCodeDao struct {
+	*internal.ReviewRejectCodeDao
+}
+
+var (
+	// ReviewRejectCode is globally public accessible object for table t_review_reject_code operations.
+	ReviewRejectCode = reviewRejectCodeDao{
+		internal.NewReviewRejectCodeDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -0,0 +1,20 @@
+package dao
+
+import (
+	"GameEngine/internal/dao/internal"
+)
+
+// sensitiveWordDao is the manager for logic model data accessing and custom defined data operations functions management.
+// You can define custom methods on it to extend its functionality as you wish.
+type sensitiveWordDao struct {
+	*internal.SensitiveWordDao
+}
+
+var (
+	// SensitiveWord is globally public accessible object for table t_sensitive_word operations.
+	SensitiveWord = sensitiveWordDao{
+		internal.NewSensitiveWordDao(),
+	}
+)
+
+// Fill with you ideas below.
@@ -2,6 +2,10 @@ package dao
 
 import (
 	"GameEngine/internal/dao/internal"
+	"GameEngine/internal/model"
+	"context"
+
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // userBehaviorDao is the manager for logic model data accessing and custom defined data operations functions management.
@@ -18,3 +22,24 @@ var (
 )
 
 // Fill with you ideas below.
+
+// SearchKeywordCount 搜索关键词及搜索过的用户数
+type SearchKeywordCount struct {
+	Keyword string `orm:"search_keyword"`
+	Users   int64  `orm:"users"`
+}
+
+// PopularSearchKeywords 统计 since 之后至少 minUsers 个用户搜索过的关键词，按用户数从多到少最多返回 limit 条
+func (behavior *userBehaviorDao) PopularSearchKeywords(ctx context.Context, since *gtime.Time, minUsers int, limit int) (out []*SearchKeywordCount, err error) {
+	err = behavior.Ctx(ctx).
+		Fields(behavior.Columns().SearchKeyword, "COUNT(DISTINCT "+behavior.Columns().UserID+") AS users").
+		Where(behavior.Columns().BehaviorType, model.BehaviorSearch).
+		WhereGTE(behavior.Columns().BehaviorTime, since).
+		WhereNot(behavior.Columns().SearchKeyword, "").
+		Group(behavior.Columns().SearchKeyword).
+		Having("users >= ?", minUsers).
+		OrderDesc("users").
+		Limit(limit).
+		Scan(&out)
+	return
+}
@@ -2,18 +2,34 @@ package logics
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	mathrand "math/rand"
+	"os"
+	"runtime/debug"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
+	"GameEngine/internal/service"
 
 	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/glog"
 	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+const (
+	defaultAsyncTaskAlertTopic = "core.alert.async-task"
+	maxAsyncTaskErrorLength    = 1024
 )
 
 // 逻辑层
@@ -25,14 +41,29 @@ var (
 type logicsAsyncTask struct {
 	dbPool *sql.DB
 	logger *glog.Logger
-	ctx    context.Context
+	ctx    context.Context // 停机时取消，工作线程和后台线程退出，不再领取新任务
+	cancel context.CancelFunc
+
+	abortCtx context.Context // 停机超时后取消，通知执行中的处理函数退出
+	abort    context.CancelFunc
+	workers  sync.WaitGroup // 工作线程和后台线程，停机时等待退出
+
+	initInterval     time.Duration // 工作线程初始化间隔
+	queryInterval    time.Duration // 工作线程没有任务时，休眠间隔
+	errSleepInterval time.Duration // 工作线程获取任务失败，休眠间隔
+	leaseDuration    time.Duration // 领取任务的租约时长，执行期间每 1/3 租约时长续约一次
+	reclaimInterval  time.Duration // 检查并回收租约到期任务的间隔
 
-	initInterval     time.Duration   // 工作线程初始化间隔
-	queryInterval    time.Duration   // 工作线程没有任务时，休眠间隔
-	errSleepInterval time.Duration   // 工作线程获取任务失败，休眠间隔
-	backoffIntervals []time.Duration // 工作线程执行任务失败，退避间隔
+	instanceID string       // 当前实例标识，与领取序号组成任务的领取标识
+	claimSeq   atomic.Int64 // 领取序号
 
-	sigChanMap map[model.AsyncTaskType]chan struct{} // 任务线程唤醒信号通道
+	concurrency map[model.AsyncTaskType]int // 各任务类型的工作线程数量，启动时从配置加载
+
+	defaultRetryPolicy retryPolicy                           // 未单独配置的任务类型使用的重试策略
+	retryPolicies      map[model.AsyncTaskType]*retryPolicy  // 各任务类型的重试策略，启动时从配置加载
+	sigChanMap         map[model.AsyncTaskType]chan struct{} // 任务线程唤醒信号通道
+
+	recurring map[string]*recurringTask // 注册的周期任务，按名称索引
 
 	handler map[model.AsyncTaskType]model.AsyncTaskHandler
 	mutex   sync.RWMutex
@@ -40,24 +71,32 @@ type logicsAsyncTask struct {
 
 func NewAsyncTask() *logicsAsyncTask {
 	logicsAsyncTaskOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		abortCtx, abort := context.WithCancel(context.Background())
 		logicsAsyncTaskInstance = &logicsAsyncTask{
-			ctx:    context.Background(),
-			logger: g.Log(),
+			ctx:      ctx,
+			cancel:   cancel,
+			abortCtx: abortCtx,
+			abort:    abort,
+			logger:   g.Log(),
 
 			initInterval:     10 * time.Second,
 			queryInterval:    30 * time.Second,
 			errSleepInterval: 3 * time.Second,
-			backoffIntervals: []time.Duration{
-				2 * time.Second,
-				3 * time.Second,
-				5 * time.Second,
-				10 * time.Second,
-				30 * time.Second,
-				1 * time.Minute,
-				5 * time.Minute,
+			leaseDuration:    5 * time.Minute,
+			reclaimInterval:  time.Minute,
+			instanceID:       newInstanceID(),
+			defaultRetryPolicy: retryPolicy{
+				MaxRetries:   20,
+				BaseInterval: 2 * time.Second,
+				MaxInterval:  5 * time.Minute,
+				Jitter:       0.2,
 			},
 
-			sigChanMap: make(map[model.AsyncTaskType]chan struct{}),
+			concurrency:   make(map[model.AsyncTaskType]int),
+			retryPolicies: make(map[model.AsyncTaskType]*retryPolicy),
+			sigChanMap:    make(map[model.AsyncTaskType]chan struct{}),
+			recurring:     make(map[string]*recurringTask),
 
 			handler: make(map[model.AsyncTaskType]model.AsyncTaskHandler),
 		}
@@ -77,15 +116,88 @@ func (o *logicsAsyncTask) RegisterHandler(op model.AsyncTaskType, handler model.
 }
 
 func (o *logicsAsyncTask) Start() {
-	o.mutex.RLock()
-	defer o.mutex.RUnlock()
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 
-	for op, handler := range o.handler {
+	if lease := g.Cfg().MustGet(o.ctx, "asyncTask.lease").Duration(); lease >= 3*time.Second {
+		o.leaseDuration = lease
+	}
+	if interval := g.Cfg().MustGet(o.ctx, "asyncTask.reclaimInterval").Duration(); interval > 0 {
+		o.reclaimInterval = interval
+	}
+
+	// 工作线程只读重试策略和唤醒信号通道，需要在启动工作线程前全部加载
+	for op := range o.handler {
+		o.retryPolicies[op] = o.loadRetryPolicy(o.ctx, op)
+		o.concurrency[op] = o.loadConcurrency(o.ctx, op)
 		o.sigChanMap[op] = make(chan struct{}, 1000)
-		go o.pushWorker(op, handler)
 	}
+	// 同一任务类型的多个工作线程共用唤醒信号通道，每个信号唤醒其中一个
+	for op, handler := range o.handler {
+		for i := 0; i < o.concurrency[op]; i++ {
+			o.workers.Add(1)
+			go o.pushWorker(op, handler, i)
+		}
+	}
+
+	o.workers.Add(1)
+	go o.startLeaseMonitor()
+	if len(o.recurring) > 0 {
+		o.workers.Add(1)
+		go o.startRecurringScheduler()
+	}
+}
+
+// Shutdown 停止领取新任务，等待执行中的任务完成
+// ctx 到期时取消执行中处理函数的 context，本实例领取但未完成的任务放回待执行，由其他实例或重启后重新执行
+func (o *logicsAsyncTask) Shutdown(ctx context.Context) (err error) {
+	o.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		o.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		o.logger.Infof(ctx, "[AsyncTask]: all workers exited")
+	case <-ctx.Done():
+		o.logger.Warningf(ctx, "[AsyncTask]: shutdown deadline exceeded, abort running tasks")
+		o.abort()
+	}
+
+	// 停机超时后 ctx 已取消，放回任务时不受其影响
+	released, err := dao.AsyncTask.ReleaseClaimedTasks(context.WithoutCancel(ctx), o.instanceID+"-")
+	if err != nil {
+		return fmt.Errorf("release claimed tasks error: %w", err)
+	}
+	if released > 0 {
+		o.logger.Infof(ctx, "[AsyncTask]: released %d claimed tasks to pending", released)
+	}
+	return nil
+}
 
-	go o.startTimeoutMonitor()
+// loadConcurrency 读取 asyncTask.concurrency.<任务类型名>，未配置时使用 asyncTask.concurrency.default，至少为 1
+func (o *logicsAsyncTask) loadConcurrency(ctx context.Context, taskType model.AsyncTaskType) int {
+	concurrency := g.Cfg().MustGet(ctx, "asyncTask.concurrency."+model.GetAsyncTaskType(taskType)).Int()
+	if concurrency <= 0 {
+		concurrency = g.Cfg().MustGet(ctx, "asyncTask.concurrency.default", 1).Int()
+	}
+	return max(concurrency, 1)
+}
+
+// newInstanceID 主机名 + 进程号 + 随机数，区分多实例部署和同一主机上重启后的进程
+func newInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	if len(hostname) > 24 {
+		hostname = hostname[:24]
+	}
+	random := make([]byte, 4)
+	_, _ = rand.Read(random)
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(random))
 }
 
 func (o *logicsAsyncTask) AddTask(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, content []byte) error {
@@ -96,6 +208,24 @@ func (o *logicsAsyncTask) AddScheduledTask(ctx context.Context, tx gdb.TX, op mo
 	return dao.AsyncTask.AddScheduledTask(ctx, tx, op, customID, content, scheduledTime)
 }
 
+func (o *logicsAsyncTask) Upsert(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, content []byte, scheduledTime *gtime.Time) error {
+	if customID == "" {
+		return fmt.Errorf("[AsyncTask]: upsert task %v without custom id", model.GetAsyncTaskType(op))
+	}
+	if scheduledTime == nil {
+		scheduledTime = gtime.Now()
+	}
+	return dao.AsyncTask.Upsert(ctx, tx, op, customID, content, scheduledTime)
+}
+
+func (o *logicsAsyncTask) CancelByKey(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string) (canceled bool, err error) {
+	return dao.AsyncTask.CancelByKey(ctx, tx, op, customID)
+}
+
+func (o *logicsAsyncTask) RescheduleByKey(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, scheduledTime *gtime.Time) (rescheduled bool, err error) {
+	return dao.AsyncTask.RescheduleByKey(ctx, tx, op, customID, scheduledTime)
+}
+
 // 非阻塞方式发送通知，通知推送线程有新消息
 func (o *logicsAsyncTask) WakeUp(op model.AsyncTaskType) {
 	o.mutex.RLock()
@@ -115,16 +245,21 @@ func (o *logicsAsyncTask) WakeUp(op model.AsyncTaskType) {
 	}
 }
 
-func (o *logicsAsyncTask) pushWorker(taskType model.AsyncTaskType, handler model.AsyncTaskHandler) {
+func (o *logicsAsyncTask) pushWorker(taskType model.AsyncTaskType, handler model.AsyncTaskHandler, workerIndex int) {
+	defer o.workers.Done()
 	defer func() {
 		if r := recover(); r != nil {
-			o.logger.Errorf(o.ctx, "[AsyncTask]: push worker for task %v panic: %v", model.GetAsyncTaskType(taskType), r)
+			o.logger.Errorf(o.ctx, "[AsyncTask]: push worker %d for task %v panic: %v", workerIndex, model.GetAsyncTaskType(taskType), r)
 		}
 	}()
 
-	time.Sleep(o.initInterval)
+	select {
+	case <-o.ctx.Done():
+		return
+	case <-time.After(o.initInterval):
+	}
 
-	o.logger.Infof(o.ctx, "[AsyncTask]: push worker for task %v start", model.GetAsyncTaskType(taskType))
+	o.logger.Infof(o.ctx, "[AsyncTask]: push worker %d for task %v start", workerIndex, model.GetAsyncTaskType(taskType))
 
 	// 状态变量
 	var (
@@ -142,6 +277,8 @@ func (o *logicsAsyncTask) pushWorker(taskType model.AsyncTaskType, handler model
 
 		// 阻塞等待信号触发或者定时器触发，直到有任务可处理
 		select {
+		case <-o.ctx.Done():
+			continue
 		case <-o.sigChanMap[taskType]: // 信号触发，立即执行
 			// o.logger.Debugf(o.ctx, "[AsyncTask]: push goroutine for task %v notify by signal", model.GetAsyncTaskType(taskType))
 		case <-time.After(time.Until(nextFetchTime)): // 正常等待计时器触发
@@ -149,8 +286,10 @@ func (o *logicsAsyncTask) pushWorker(taskType model.AsyncTaskType, handler model
 		}
 
 		// 获取并处理消息
-		taskEntity, err := dao.AsyncTask.FetchPendingTask(o.ctx, taskType)
+		claimID := fmt.Sprintf("%s-%d", o.instanceID, o.claimSeq.Add(1))
+		taskEntity, err := dao.AsyncTask.ClaimTask(o.ctx, taskType, claimID, time.Now().Add(o.leaseDuration).Unix())
 		if err != nil {
+			o.logger.Errorf(o.ctx, "[AsyncTask]: push goroutine for task %v claim task error: %v", model.GetAsyncTaskType(taskType), err)
 			nextFetchTime = time.Now().Add(o.errSleepInterval)
 			continue
 		}
@@ -178,12 +317,16 @@ func (o *logicsAsyncTask) pushWorker(taskType model.AsyncTaskType, handler model
 		// 不管消息处理成功还是失败，立即准备下次获取，避免消息堆积
 		nextFetchTime = time.Now()
 
+		// 已领取的任务在停机时也要执行完并更新状态，不受 o.ctx 取消影响
+		taskCtx := context.WithoutCancel(o.ctx)
 		taskInfo, err := model.ConvertAsyncTaskEntityToModel(taskEntity)
 		if err != nil {
-			o.logger.Errorf(o.ctx, "[AsyncTask]: push goroutine for task %v convert task entity to model error: %v", model.GetAsyncTaskType(taskType), err)
-			continue
+			// 任务内容不是合法的JSON，重试也无法执行
+			err = o.finish(taskCtx, convertAsyncTaskForAdmin(taskEntity), fmt.Errorf("%w: %v", model.ErrMalformedAsyncTaskContent, err), "")
+		} else {
+			err = o.handle(taskCtx, taskInfo, handler)
 		}
-		if err = o.handle(o.ctx, taskInfo, handler); err != nil {
+		if err != nil {
 			o.logger.Errorf(o.ctx, "[AsyncTask]: push goroutine for task %v handle task error: %v", model.GetAsyncTaskType(taskType), err)
 			continue
 		}
@@ -191,51 +334,232 @@ func (o *logicsAsyncTask) pushWorker(taskType model.AsyncTaskType, handler model
 }
 
 func (o *logicsAsyncTask) handle(ctx context.Context, taskInfo *model.AsyncTask, handler model.AsyncTaskHandler) (err error) {
-	var dataUpdate map[string]interface{}
-	if err = handler(ctx, taskInfo); err != nil {
-		dataUpdate = map[string]interface{}{
-			dao.AsyncTask.Columns().Status:        model.AsyncTaskStatusPending,
-			dao.AsyncTask.Columns().RetryCount:    taskInfo.RetryCount + 1,
-			dao.AsyncTask.Columns().NextRetryTime: o.calculateNextRetryTime(taskInfo),
-			dao.AsyncTask.Columns().Version:       taskInfo.Version,
-		}
+	// 租约被回收后(续约失败)或停机超时后取消处理函数的 context
+	// 处理函数的 context 保留 ctx 中的值(链路ID等)，只在上述两种情况下取消
+	handlerCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	stopAbort := context.AfterFunc(o.abortCtx, cancel)
+	defer stopAbort()
+	stopHeartbeat := o.keepLease(handlerCtx, cancel, taskInfo)
+	stack, handleErr := o.invoke(handlerCtx, taskInfo, handler)
+	stopHeartbeat()
+
+	// 停机超时被中断的任务不计入重试次数，由 Shutdown 放回待执行
+	if handleErr != nil && o.abortCtx.Err() != nil {
+		return fmt.Errorf("task %v(id=%d) aborted by shutdown: %w", model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID, handleErr)
+	}
+
+	return o.finish(ctx, taskInfo, handleErr, stack)
+}
+
+// finish 按处理结果更新任务状态：成功、等待重试或重试耗尽；任务内容格式错误时不重试
+func (o *logicsAsyncTask) finish(ctx context.Context, taskInfo *model.AsyncTask, handleErr error, stack string) (err error) {
+	if handleErr == nil {
+		err = dao.AsyncTask.Update(ctx, taskInfo.ID, taskInfo.Version, map[string]interface{}{
+			dao.AsyncTask.Columns().Status:          model.AsyncTaskStatusSuccess,
+			dao.AsyncTask.Columns().Version:         taskInfo.Version + 1,
+			dao.AsyncTask.Columns().LeaseOwner:      "",
+			dao.AsyncTask.Columns().LeaseExpireTime: 0,
+		})
+		return o.wrapLeaseLost(taskInfo, err)
+	}
+
+	policy := o.retryPolicies[taskInfo.TaskType]
+	retryCount := taskInfo.RetryCount + 1
+	malformed := errors.Is(handleErr, model.ErrMalformedAsyncTaskContent)
+	dead := malformed || policy.MaxRetries > 0 && retryCount > policy.MaxRetries
+	dataUpdate := map[string]interface{}{
+		dao.AsyncTask.Columns().RetryCount:      retryCount,
+		dao.AsyncTask.Columns().LastError:       gstr.SubStrRune(handleErr.Error(), 0, maxAsyncTaskErrorLength),
+		dao.AsyncTask.Columns().LastErrorStack:  stack,
+		dao.AsyncTask.Columns().Version:         taskInfo.Version + 1,
+		dao.AsyncTask.Columns().LeaseOwner:      "",
+		dao.AsyncTask.Columns().LeaseExpireTime: 0,
+	}
+	if malformed {
+		dataUpdate[dao.AsyncTask.Columns().Status] = model.AsyncTaskStatusDead
+		o.logger.Errorf(ctx, "[AsyncTask]: task %v(id=%d, custom_id=%s) has malformed content, give up: %v",
+			model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID, taskInfo.CustomID, handleErr)
+	} else if dead {
+		dataUpdate[dao.AsyncTask.Columns().Status] = model.AsyncTaskStatusDead
+		o.logger.Errorf(ctx, "[AsyncTask]: task %v(id=%d, custom_id=%s) failed %d times, give up: %v",
+			model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID, taskInfo.CustomID, retryCount, handleErr)
 	} else {
-		dataUpdate = map[string]interface{}{
-			dao.AsyncTask.Columns().Status:  model.AsyncTaskStatusSuccess,
-			dao.AsyncTask.Columns().Version: taskInfo.Version + 1,
-		}
+		dataUpdate[dao.AsyncTask.Columns().Status] = model.AsyncTaskStatusFailed
+		dataUpdate[dao.AsyncTask.Columns().NextRetryTime] = time.Now().Add(policy.backoff(retryCount)).Unix()
+		o.logger.Warningf(ctx, "[AsyncTask]: task %v(id=%d, custom_id=%s) failed %d times, will retry: %v",
+			model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID, taskInfo.CustomID, retryCount, handleErr)
 	}
 
 	err = dao.AsyncTask.Update(ctx, taskInfo.ID, taskInfo.Version, dataUpdate)
+	if err != nil && !dead && strings.Contains(err.Error(), "Duplicate entry") {
+		// 执行期间已通过 Upsert 添加了相同自定义任务ID的新任务，本任务被取代，不再重试
+		dataUpdate[dao.AsyncTask.Columns().Status] = model.AsyncTaskStatusCanceled
+		delete(dataUpdate, dao.AsyncTask.Columns().NextRetryTime)
+		o.logger.Infof(ctx, "[AsyncTask]: task %v(id=%d, custom_id=%s) superseded by a newer task, stop retrying",
+			model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID, taskInfo.CustomID)
+		err = dao.AsyncTask.Update(ctx, taskInfo.ID, taskInfo.Version, dataUpdate)
+	}
+	if err != nil {
+		return o.wrapLeaseLost(taskInfo, err)
+	}
+
+	if dead {
+		if alertErr := o.publishDeadAlert(ctx, taskInfo, retryCount, handleErr); alertErr != nil {
+			o.logger.Errorf(ctx, "[AsyncTask]: publish dead task alert error: %v", alertErr)
+		}
+	}
+	return nil
+}
+
+// keepLease 处理函数执行期间定期续约，续约时发现租约已被回收则取消处理函数的 context；返回的函数用于停止续约
+func (o *logicsAsyncTask) keepLease(ctx context.Context, cancel context.CancelFunc, taskInfo *model.AsyncTask) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(o.leaseDuration / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				renewed, err := dao.AsyncTask.RenewLease(ctx, taskInfo.ID, taskInfo.Version, taskInfo.LeaseOwner, time.Now().Add(o.leaseDuration).Unix())
+				if err != nil {
+					o.logger.Warningf(ctx, "[AsyncTask]: renew lease for task %v(id=%d) error: %v", model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID, err)
+					continue
+				}
+				if !renewed {
+					o.logger.Warningf(ctx, "[AsyncTask]: lease for task %v(id=%d) lost, cancel handler", model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// wrapLeaseLost 任务处理完成后更新状态时版本号不匹配，说明租约到期已被回收，处理结果作废
+func (o *logicsAsyncTask) wrapLeaseLost(taskInfo *model.AsyncTask, err error) error {
+	if err == model.ErrNoRowsAffected {
+		return fmt.Errorf("task %v(id=%d) lease lost before update: %w", model.GetAsyncTaskType(taskInfo.TaskType), taskInfo.ID, err)
+	}
+	return err
+}
+
+// invoke 执行任务处理函数，处理函数 panic 时转换为错误，避免工作线程退出
+// 返回的调用栈来自 panic 现场，或者带调用栈的错误(gerror)
+func (o *logicsAsyncTask) invoke(ctx context.Context, taskInfo *model.AsyncTask, handler model.AsyncTaskHandler) (stack string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+			stack = string(debug.Stack())
+		}
+	}()
 
+	err = handler(ctx, taskInfo)
+	if err != nil && gerror.HasStack(err) {
+		stack = gerror.Stack(err)
+	}
 	return
 }
 
-func (o *logicsAsyncTask) calculateNextRetryTime(taskInfo *model.AsyncTask) (nextRetryTimeStamp int64) {
-	if taskInfo.RetryCount >= len(o.backoffIntervals) {
-		return time.Now().Add(o.backoffIntervals[len(o.backoffIntervals)-1]).Unix()
+func (o *logicsAsyncTask) publishDeadAlert(ctx context.Context, taskInfo *model.AsyncTask, retryCount int, handleErr error) (err error) {
+	body := map[string]interface{}{
+		"title":       "异步任务重试次数耗尽",
+		"task_id":     taskInfo.ID,
+		"task_type":   model.GetAsyncTaskType(taskInfo.TaskType),
+		"custom_id":   taskInfo.CustomID,
+		"retry_count": retryCount,
+		"last_error":  gstr.SubStrRune(handleErr.Error(), 0, maxAsyncTaskErrorLength),
 	}
-	return time.Now().Add(o.backoffIntervals[taskInfo.RetryCount]).Unix()
+	topic := g.Cfg().MustGet(ctx, "asyncTask.alertTopic", defaultAsyncTaskAlertTopic).String()
+	return service.MQ().Publish(ctx, topic, body)
 }
 
-func (o *logicsAsyncTask) startTimeoutMonitor() {
-	ticker := time.NewTicker(24 * time.Hour)
-	defer ticker.Stop()
+// retryPolicy 执行失败后的重试策略，退避间隔按指数增长并加入随机抖动，避免大量任务同时重试
+type retryPolicy struct {
+	MaxRetries   int           // 最大重试次数，0 表示不限制
+	BaseInterval time.Duration // 第一次重试的退避间隔
+	MaxInterval  time.Duration // 退避间隔上限
+	Jitter       float64       // 退避间隔上下浮动的比例，取值 0~1
+}
 
-	time.Sleep(o.queryInterval)
+// loadRetryPolicy 读取 asyncTask.retry.default 和 asyncTask.retry.<任务类型名> 配置，后者覆盖前者
+func (o *logicsAsyncTask) loadRetryPolicy(ctx context.Context, taskType model.AsyncTaskType) *retryPolicy {
+	return readRetryPolicy(ctx, o.defaultRetryPolicy, "asyncTask.retry.default", "asyncTask.retry."+model.GetAsyncTaskType(taskType))
+}
+
+// readRetryPolicy 依次读取各配置项覆盖默认策略，配置项下的字段为 maxRetries、baseInterval、maxInterval、jitter
+func readRetryPolicy(ctx context.Context, defaultPolicy retryPolicy, keys ...string) *retryPolicy {
+	policy := defaultPolicy
+	for _, key := range keys {
+		if v := g.Cfg().MustGet(ctx, key+".maxRetries"); !v.IsNil() {
+			policy.MaxRetries = v.Int()
+		}
+		if v := g.Cfg().MustGet(ctx, key+".baseInterval"); !v.IsNil() {
+			policy.BaseInterval = v.Duration()
+		}
+		if v := g.Cfg().MustGet(ctx, key+".maxInterval"); !v.IsNil() {
+			policy.MaxInterval = v.Duration()
+		}
+		if v := g.Cfg().MustGet(ctx, key+".jitter"); !v.IsNil() {
+			policy.Jitter = v.Float64()
+		}
+	}
+
+	if policy.BaseInterval <= 0 {
+		policy.BaseInterval = defaultPolicy.BaseInterval
+	}
+	if policy.MaxInterval < policy.BaseInterval {
+		policy.MaxInterval = policy.BaseInterval
+	}
+	policy.Jitter = min(max(policy.Jitter, 0), 1)
+	return &policy
+}
+
+// backoff 第 retryCount 次重试前的退避间隔：BaseInterval * 2^(retryCount-1)，不超过 MaxInterval，再上下浮动 Jitter 比例
+func (p *retryPolicy) backoff(retryCount int) time.Duration {
+	interval := p.MaxInterval
+	if shift := max(retryCount-1, 0); shift < 32 && p.BaseInterval<<shift < p.MaxInterval {
+		interval = p.BaseInterval << shift
+	}
+	if p.Jitter > 0 {
+		interval = time.Duration(float64(interval) * (1 - p.Jitter + 2*p.Jitter*mathrand.Float64()))
+	}
+	return interval
+}
+
+// startLeaseMonitor 定期回收租约到期的执行中任务，并唤醒工作线程重新执行
+func (o *logicsAsyncTask) startLeaseMonitor() {
+	defer o.workers.Done()
+	ticker := time.NewTicker(o.reclaimInterval)
+	defer ticker.Stop()
 
 	for {
 		select {
 		case <-o.ctx.Done():
 			return
 		case <-ticker.C:
-			rowsAffected, err := dao.AsyncTask.ResetTimeoutTasks(o.ctx)
+			rowsAffected, err := dao.AsyncTask.ReclaimExpiredTasks(o.ctx)
 			if err != nil {
-				o.logger.Errorf(o.ctx, "[AsyncTask]: reset timeout tasks error: %v", err)
+				o.logger.Errorf(o.ctx, "[AsyncTask]: reclaim expired tasks error: %v", err)
+				continue
+			}
+			if rowsAffected == 0 {
 				continue
 			}
-			if rowsAffected > 0 {
-				o.logger.Infof(o.ctx, "[AsyncTask]: reset %d timeout tasks", rowsAffected)
+
+			o.logger.Infof(o.ctx, "[AsyncTask]: reclaim %d expired tasks", rowsAffected)
+			o.mutex.RLock()
+			taskTypes := make([]model.AsyncTaskType, 0, len(o.handler))
+			for taskType := range o.handler {
+				taskTypes = append(taskTypes, taskType)
+			}
+			o.mutex.RUnlock()
+			for _, taskType := range taskTypes {
+				o.WakeUp(taskType)
 			}
 		}
 	}
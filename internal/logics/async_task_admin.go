@@ -0,0 +1,195 @@
+package logics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ListTasks 管理端查询任务列表，按ID倒序
+func (o *logicsAsyncTask) ListTasks(ctx context.Context, filter *model.AsyncTaskFilter, pageReq *model.PageReq) (outs []*model.AsyncTask, pageRes *model.PageRes, err error) {
+	if pageReq.Page == 0 {
+		pageReq.Page = 1
+	}
+	if pageReq.Size == 0 {
+		pageReq.Size = 10
+	}
+
+	query := dao.AsyncTask.Ctx(ctx)
+	if filter.TaskType != nil {
+		query = query.Where(dao.AsyncTask.Columns().TaskType, int(*filter.TaskType))
+	}
+	if filter.Status != nil {
+		query = query.Where(dao.AsyncTask.Columns().Status, int(*filter.Status))
+	}
+	if filter.CustomID != "" {
+		query = query.Where(dao.AsyncTask.Columns().CustomID, filter.CustomID)
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return
+	}
+
+	var entities []*entity.AsyncTask
+	err = query.OrderDesc(dao.AsyncTask.Columns().ID).Page(pageReq.Page, pageReq.Size).Scan(&entities)
+	if err != nil {
+		return
+	}
+	outs = make([]*model.AsyncTask, 0, len(entities))
+	for _, taskEntity := range entities {
+		outs = append(outs, convertAsyncTaskForAdmin(taskEntity))
+	}
+
+	pageRes = &model.PageRes{
+		Total:       total,
+		CurrentPage: pageReq.Page,
+	}
+	return
+}
+
+// GetTask 管理端查询任务详情
+func (o *logicsAsyncTask) GetTask(ctx context.Context, id int64) (out *model.AsyncTask, err error) {
+	taskEntity, err := dao.AsyncTask.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if taskEntity == nil {
+		return nil, fmt.Errorf("任务不存在")
+	}
+	return convertAsyncTaskForAdmin(taskEntity), nil
+}
+
+// RerunTask 重新执行重试次数耗尽的任务，重试次数清零，立即执行
+func (o *logicsAsyncTask) RerunTask(ctx context.Context, id int64, version int) (err error) {
+	return o.updateTaskByAdmin(ctx, id, version, []model.AsyncTaskStatus{model.AsyncTaskStatusDead}, map[string]interface{}{
+		dao.AsyncTask.Columns().Status:        model.AsyncTaskStatusPending,
+		dao.AsyncTask.Columns().RetryCount:    0,
+		dao.AsyncTask.Columns().NextRetryTime: gtime.Now().UTC().Unix(),
+		dao.AsyncTask.Columns().Version:       version + 1,
+	})
+}
+
+// CancelTask 取消待执行或等待重试的任务
+func (o *logicsAsyncTask) CancelTask(ctx context.Context, id int64, version int) (err error) {
+	return o.updateTaskByAdmin(ctx, id, version, model.RunnableAsyncTaskStatuses, map[string]interface{}{
+		dao.AsyncTask.Columns().Status:  model.AsyncTaskStatusCanceled,
+		dao.AsyncTask.Columns().Version: version + 1,
+	})
+}
+
+// RescheduleTask 修改待执行或等待重试的任务的下次处理时间
+func (o *logicsAsyncTask) RescheduleTask(ctx context.Context, id int64, version int, nextRetryTime *gtime.Time) (err error) {
+	return o.updateTaskByAdmin(ctx, id, version, model.RunnableAsyncTaskStatuses, map[string]interface{}{
+		dao.AsyncTask.Columns().NextRetryTime: nextRetryTime.UTC().Unix(),
+		dao.AsyncTask.Columns().Version:       version + 1,
+	})
+}
+
+// GetTaskStats 各任务类型的任务数量和积压情况，包含已注册但没有任务的类型
+func (o *logicsAsyncTask) GetTaskStats(ctx context.Context) (outs []*model.AsyncTaskStats, err error) {
+	counts, err := dao.AsyncTask.CountByTypeAndStatus(ctx)
+	if err != nil {
+		return
+	}
+	oldestTimes, err := dao.AsyncTask.GetOldestRunnableTime(ctx)
+	if err != nil {
+		return
+	}
+
+	o.mutex.RLock()
+	for taskType := range o.handler {
+		if counts[taskType] == nil {
+			counts[taskType] = make(map[model.AsyncTaskStatus]int)
+		}
+	}
+	o.mutex.RUnlock()
+
+	now := gtime.Now().UTC().Unix()
+	outs = make([]*model.AsyncTaskStats, 0, len(counts))
+	for taskType, statusCounts := range counts {
+		stats := &model.AsyncTaskStats{
+			TaskType:     taskType,
+			StatusCounts: statusCounts,
+		}
+		if oldest, ok := oldestTimes[taskType]; ok {
+			stats.OldestPendingAge = max(now-oldest, 0)
+		}
+		outs = append(outs, stats)
+	}
+	sort.Slice(outs, func(i, j int) bool {
+		return outs[i].TaskType < outs[j].TaskType
+	})
+	return outs, nil
+}
+
+// updateTaskByAdmin 检查任务状态后按版本号更新，更新成功后唤醒对应的工作线程
+func (o *logicsAsyncTask) updateTaskByAdmin(ctx context.Context, id int64, version int, allowedStatuses []model.AsyncTaskStatus, data map[string]interface{}) (err error) {
+	taskEntity, err := dao.AsyncTask.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if taskEntity == nil {
+		return fmt.Errorf("任务不存在")
+	}
+	if taskEntity.Version != version {
+		return fmt.Errorf("任务已被修改，请刷新后重试")
+	}
+
+	allowed := false
+	for _, status := range allowedStatuses {
+		if model.AsyncTaskStatus(taskEntity.Status) == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("任务当前状态为%s，不支持该操作", model.GetAsyncTaskStatusText(model.AsyncTaskStatus(taskEntity.Status)))
+	}
+
+	err = dao.AsyncTask.Update(ctx, id, version, data)
+	if err != nil {
+		if err == model.ErrNoRowsAffected {
+			return fmt.Errorf("任务已被修改，请刷新后重试")
+		}
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return fmt.Errorf("已有相同自定义任务ID的任务等待执行")
+		}
+		return
+	}
+
+	o.logger.Infof(ctx, "[AsyncTask]: task %v(id=%d) updated by admin: %v", model.GetAsyncTaskType(model.AsyncTaskType(taskEntity.TaskType)), id, data)
+	o.WakeUp(model.AsyncTaskType(taskEntity.TaskType))
+	return nil
+}
+
+// convertAsyncTaskForAdmin 任务内容无法按JSON解析时返回原始内容，便于排查
+func convertAsyncTaskForAdmin(in *entity.AsyncTask) *model.AsyncTask {
+	out, err := model.ConvertAsyncTaskEntityToModel(in)
+	if err == nil {
+		return out
+	}
+	return &model.AsyncTask{
+		ID:              in.ID,
+		CustomID:        in.CustomID,
+		TaskType:        model.AsyncTaskType(in.TaskType),
+		Status:          model.AsyncTaskStatus(in.Status),
+		RetryCount:      in.RetryCount,
+		Content:         in.Content,
+		Version:         in.Version,
+		NextRetryTime:   in.NextRetryTime,
+		LastError:       in.LastError,
+		LastErrorStack:  in.LastErrorStack,
+		LeaseOwner:      in.LeaseOwner,
+		LeaseExpireTime: in.LeaseExpireTime,
+		CreateTime:      in.CreateTime,
+		UpdateTime:      in.UpdateTime,
+	}
+}
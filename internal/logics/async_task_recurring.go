@@ -0,0 +1,284 @@
+package logics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"GameEngine/internal/common/cron"
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	maxRecurringTaskNameLength  = 30 // 自定义任务ID为 recurring_{名称}，不能超过40个字符
+	recurringTaskCustomIDPrefix = "recurring_"
+)
+
+// recurringTask 代码中注册的周期任务
+type recurringTask struct {
+	name     string
+	cronSpec string
+	schedule cron.Schedule
+	handler  model.AsyncTaskHandler
+}
+
+// RegisterRecurring 注册周期任务，需要在 Start 之前调用
+// cron 表达式可以通过 asyncTask.recurring.specs.<名称> 覆盖；每次执行生成一条 Recurring 类型的异步任务，执行失败按重试策略重试
+// 上一次执行未结束(待执行、执行中、等待重试)时跳过本次执行，保证同一周期任务同时只有一个在执行
+func (o *logicsAsyncTask) RegisterRecurring(name string, cronSpec string, handler model.AsyncTaskHandler) {
+	if name == "" || len(name) > maxRecurringTaskNameLength {
+		panic(fmt.Sprintf("[AsyncTask]: recurring task name must be 1-%d characters: %q", maxRecurringTaskNameLength, name))
+	}
+	if spec := g.Cfg().MustGet(o.ctx, "asyncTask.recurring.specs."+name).String(); spec != "" {
+		cronSpec = spec
+	}
+	schedule, err := cron.Parse(cronSpec)
+	if err != nil {
+		panic(fmt.Sprintf("[AsyncTask]: recurring task %s: %v", name, err))
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if _, ok := o.recurring[name]; ok {
+		panic(fmt.Sprintf("[AsyncTask]: recurring task already registered: %s", name))
+	}
+	o.recurring[name] = &recurringTask{
+		name:     name,
+		cronSpec: cronSpec,
+		schedule: schedule,
+		handler:  handler,
+	}
+	if _, ok := o.handler[model.AsyncTaskTypeRecurring]; !ok {
+		o.handler[model.AsyncTaskTypeRecurring] = o.handleRecurring
+	}
+}
+
+// ListRecurringTasks 查询所有周期任务，包括当前实例未注册(已从代码中移除或其他版本注册)的周期任务
+func (o *logicsAsyncTask) ListRecurringTasks(ctx context.Context) (outs []*model.RecurringTask, err error) {
+	var entities []*entity.RecurringTask
+	err = dao.RecurringTask.Ctx(ctx).OrderAsc(dao.RecurringTask.Columns().Name).Scan(&entities)
+	if err != nil {
+		return
+	}
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	outs = make([]*model.RecurringTask, 0, len(entities))
+	for _, recurringEntity := range entities {
+		out := model.ConvertRecurringTaskEntityToModel(recurringEntity)
+		_, out.Registered = o.recurring[out.Name]
+		outs = append(outs, out)
+	}
+	return
+}
+
+// PauseRecurringTask 暂停周期任务，已生成的异步任务不受影响
+func (o *logicsAsyncTask) PauseRecurringTask(ctx context.Context, name string) (err error) {
+	return o.setRecurringTaskPaused(ctx, name, true)
+}
+
+// ResumeRecurringTask 恢复周期任务，从当前时间重新计算下次执行时间，暂停期间错过的执行不再补执行
+func (o *logicsAsyncTask) ResumeRecurringTask(ctx context.Context, name string) (err error) {
+	return o.setRecurringTaskPaused(ctx, name, false)
+}
+
+func (o *logicsAsyncTask) setRecurringTaskPaused(ctx context.Context, name string, paused bool) (err error) {
+	var recurringEntity entity.RecurringTask
+	err = dao.RecurringTask.Ctx(ctx).Where(dao.RecurringTask.Columns().Name, name).Scan(&recurringEntity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("周期任务不存在")
+		}
+		return
+	}
+	if (recurringEntity.Paused == 1) == paused {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		dao.RecurringTask.Columns().Paused: boolToInt(paused),
+	}
+	if !paused {
+		schedule, err := cron.Parse(recurringEntity.CronSpec)
+		if err != nil {
+			return err
+		}
+		data[dao.RecurringTask.Columns().NextRunTime] = schedule.Next(time.Now()).Unix()
+	}
+	updated, err := dao.RecurringTask.UpdateWithVersion(ctx, recurringEntity.ID, recurringEntity.Version, data)
+	if err != nil {
+		return
+	}
+	if !updated {
+		return fmt.Errorf("周期任务已被修改，请刷新后重试")
+	}
+
+	o.logger.Infof(ctx, "[AsyncTask]: recurring task %s paused=%v", name, paused)
+	return nil
+}
+
+// syncRecurringTasks 启动时把代码中注册的周期任务写入数据库，cron 表达式变更时从当前时间重新计算下次执行时间
+func (o *logicsAsyncTask) syncRecurringTasks(ctx context.Context) {
+	for _, task := range o.recurring {
+		var recurringEntity entity.RecurringTask
+		err := dao.RecurringTask.Ctx(ctx).Where(dao.RecurringTask.Columns().Name, task.name).Scan(&recurringEntity)
+		if err != nil && err != sql.ErrNoRows {
+			o.logger.Errorf(ctx, "[AsyncTask]: get recurring task %s error: %v", task.name, err)
+			continue
+		}
+
+		nextRunTime := task.schedule.Next(time.Now()).Unix()
+		if err == sql.ErrNoRows {
+			err = dao.RecurringTask.Create(ctx, task.name, task.cronSpec, nextRunTime)
+			// 多个实例同时启动时只有一个写入成功
+			if err != nil && !strings.Contains(err.Error(), "Duplicate entry") {
+				o.logger.Errorf(ctx, "[AsyncTask]: create recurring task %s error: %v", task.name, err)
+			}
+			continue
+		}
+
+		if recurringEntity.CronSpec == task.cronSpec {
+			continue
+		}
+		_, err = dao.RecurringTask.UpdateWithVersion(ctx, recurringEntity.ID, recurringEntity.Version, map[string]interface{}{
+			dao.RecurringTask.Columns().CronSpec:    task.cronSpec,
+			dao.RecurringTask.Columns().NextRunTime: nextRunTime,
+		})
+		if err != nil {
+			o.logger.Errorf(ctx, "[AsyncTask]: update recurring task %s error: %v", task.name, err)
+			continue
+		}
+		o.logger.Infof(ctx, "[AsyncTask]: recurring task %s cron spec changed: %s -> %s", task.name, recurringEntity.CronSpec, task.cronSpec)
+	}
+}
+
+// startRecurringScheduler 定期检查到期的周期任务，生成异步任务
+func (o *logicsAsyncTask) startRecurringScheduler() {
+	defer o.workers.Done()
+	checkInterval := g.Cfg().MustGet(o.ctx, "asyncTask.recurring.checkInterval", 30*time.Second).Duration()
+	if checkInterval <= 0 {
+		checkInterval = 30 * time.Second
+	}
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	o.syncRecurringTasks(o.ctx)
+
+	for {
+		select {
+		case <-o.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := o.materializeRecurringTasks(o.ctx); err != nil {
+				o.logger.Errorf(o.ctx, "[AsyncTask]: materialize recurring tasks error: %v", err)
+			}
+		}
+	}
+}
+
+func (o *logicsAsyncTask) materializeRecurringTasks(ctx context.Context) (err error) {
+	names := make([]string, 0, len(o.recurring))
+	for name := range o.recurring {
+		names = append(names, name)
+	}
+
+	var dueTasks []*entity.RecurringTask
+	err = dao.RecurringTask.Ctx(ctx).
+		WhereIn(dao.RecurringTask.Columns().Name, names).
+		Where(dao.RecurringTask.Columns().Paused, 0).
+		WhereLTE(dao.RecurringTask.Columns().NextRunTime, time.Now().Unix()).
+		Scan(&dueTasks)
+	if err != nil {
+		return
+	}
+
+	materialized := false
+	for _, dueTask := range dueTasks {
+		ok, err := o.materializeRecurringTask(ctx, o.recurring[dueTask.Name], dueTask)
+		if err != nil {
+			o.logger.Errorf(ctx, "[AsyncTask]: materialize recurring task %s error: %v", dueTask.Name, err)
+			continue
+		}
+		materialized = materialized || ok
+	}
+	if materialized {
+		o.WakeUp(model.AsyncTaskTypeRecurring)
+	}
+	return nil
+}
+
+// materializeRecurringTask 按版本号推进下次执行时间，多个实例中只有推进成功的实例生成本次执行的异步任务
+func (o *logicsAsyncTask) materializeRecurringTask(ctx context.Context, task *recurringTask, dueTask *entity.RecurringTask) (materialized bool, err error) {
+	customID := recurringTaskCustomIDPrefix + task.name
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		updated, err := dao.RecurringTask.UpdateWithVersion(ctx, dueTask.ID, dueTask.Version, map[string]interface{}{
+			dao.RecurringTask.Columns().NextRunTime: task.schedule.Next(time.Now()).Unix(),
+			dao.RecurringTask.Columns().LastRunTime: dueTask.NextRunTime,
+		})
+		if err != nil || !updated {
+			return err
+		}
+
+		running, err := dao.AsyncTask.Ctx(ctx).
+			Where(dao.AsyncTask.Columns().TaskType, int(model.AsyncTaskTypeRecurring)).
+			Where(dao.AsyncTask.Columns().CustomID, customID).
+			WhereIn(dao.AsyncTask.Columns().Status, []int{int(model.AsyncTaskStatusPending), int(model.AsyncTaskStatusProcessing), int(model.AsyncTaskStatusFailed)}).
+			Exist()
+		if err != nil {
+			return err
+		}
+		if running {
+			o.logger.Warningf(ctx, "[AsyncTask]: recurring task %s previous run not finished, skip run at %s",
+				task.name, gtime.NewFromTimeStamp(dueTask.NextRunTime).String())
+			return nil
+		}
+
+		contentBytes, err := model.MarshalAsyncTaskPayload(&model.RecurringTaskRun{
+			Name:          task.name,
+			ScheduledTime: dueTask.NextRunTime,
+		})
+		if err != nil {
+			return err
+		}
+		err = o.Upsert(ctx, tx, model.AsyncTaskTypeRecurring, customID, contentBytes, nil)
+		if err != nil {
+			return err
+		}
+		materialized = true
+		return nil
+	})
+	return
+}
+
+// handleRecurring 周期任务的执行入口，按名称分发到注册的处理函数
+func (o *logicsAsyncTask) handleRecurring(ctx context.Context, task *model.AsyncTask) (err error) {
+	run, err := model.DecodeAsyncTaskPayload[model.RecurringTaskRun](task)
+	if err != nil {
+		return
+	}
+
+	o.mutex.RLock()
+	recurring, ok := o.recurring[run.Name]
+	o.mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf("周期任务未注册: %s", run.Name)
+	}
+	return recurring.handler(ctx, task)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
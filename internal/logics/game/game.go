@@ -1,12 +1,15 @@
 package game
 
 import (
+	"GameEngine/internal/common/pinyin"
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
 	"GameEngine/internal/service"
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 )
 
@@ -16,11 +19,14 @@ var (
 )
 
 type Game struct {
+	validators     []*namedValidator // 提交审核检查项，按注册顺序执行
+	validatorMutex sync.RWMutex
 }
 
 func NewGame() service.IGame {
 	gameOnce.Do(func() {
 		gameInstance = &Game{}
+		gameInstance.registerBuiltinValidators()
 	})
 	return gameInstance
 }
@@ -31,6 +37,8 @@ func (gg *Game) Download(ctx context.Context, gameID, userID int64) (err error)
 }
 
 // 游戏搜索相关方法
+// SearchGameByGameName 按相关度搜索可预约和已上架的游戏，匹配名称、描述、详情、开发商、发行商、分类和标签，以及名称、开发商、标签的拼音
+// 搜索索引未加载完成时退化为按名称模糊查询
 func (gg *Game) SearchGameByGameName(ctx context.Context, name string, page, size int) (out []*model.Game, pageRes *model.PageRes, err error) {
 	if page == 0 {
 		page = 1
@@ -38,12 +46,58 @@ func (gg *Game) SearchGameByGameName(ctx context.Context, name string, page, siz
 	if size == 0 {
 		size = 20 // 搜索接口最多返回20条
 	}
+
+	out, pageRes, _, err = gg.SearchGames(ctx, &model.GameSearchQuery{
+		Keyword:  name,
+		Statuses: liveGameStatuses,
+		Page:     page,
+		Size:     size,
+	})
+	if errors.Is(err, model.ErrSearchIndexNotReady) {
+		return gg.searchGameByNameLike(ctx, name, page, size)
+	}
+	return
+}
+
+// SearchGames 按条件搜索游戏，返回当前页的游戏和各筛选项的游戏数量
+func (gg *Game) SearchGames(ctx context.Context, in *model.GameSearchQuery) (out []*model.Game, pageRes *model.PageRes, facets *model.GameSearchFacets, err error) {
+	result, err := service.Search().Search(ctx, in)
+	if err != nil {
+		return
+	}
+	pageRes = &model.PageRes{
+		Total:       result.Total,
+		CurrentPage: max(in.Page, 1),
+	}
+	facets = result.Facets
+
+	gameIDs := make([]int64, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		gameIDs = append(gameIDs, hit.GameID)
+	}
+	out, err = gg.getGamesInOrder(ctx, gameIDs)
+	return
+}
+
+// liveGameStatuses 用户可以搜索到的游戏状态
+var liveGameStatuses = []model.GameStatus{model.GameStatusPreRegister, model.GameStatusPublished}
+
+func (gg *Game) searchGameByNameLike(ctx context.Context, name string, page, size int) (out []*model.Game, pageRes *model.PageRes, err error) {
 	pageRes = &model.PageRes{
 		CurrentPage: page,
 	}
 
-	// 使用LIKE进行模糊搜索
-	query := dao.Game.Ctx(ctx).WhereLike(dao.Game.Columns().Name, "%"+name+"%")
+	// 使用LIKE进行模糊搜索，不包含汉字时同时匹配名称的全拼和首字母(如 wzry、wangzhe)
+	columns := dao.Game.Columns()
+	condition := dao.Game.Ctx(ctx).Builder().WhereLike(columns.Name, "%"+name+"%")
+	if keyword := strings.ToLower(strings.Join(strings.Fields(name), "")); keyword != "" && !pinyin.ContainsHan(keyword) {
+		condition = condition.
+			WhereOrLike(columns.NamePinyin, "%"+keyword+"%").
+			WhereOrLike(columns.NameInitials, "%"+keyword+"%")
+	}
+	query := dao.Game.Ctx(ctx).
+		Where(condition).
+		WhereIn(columns.Status, liveGameStatuses)
 
 	var entities []*entity.Game
 	err = query.Page(page, size).OrderDesc(dao.Game.Columns().CreateTime).Scan(&entities)
@@ -57,6 +111,27 @@ func (gg *Game) SearchGameByGameName(ctx context.Context, name string, page, siz
 	return
 }
 
+// getGamesInOrder 按传入的ID顺序返回游戏，已删除的游戏跳过
+func (gg *Game) getGamesInOrder(ctx context.Context, gameIDs []int64) (out []*model.Game, err error) {
+	if len(gameIDs) == 0 {
+		return
+	}
+	games, err := gg.GetGamesByIDs(ctx, gameIDs)
+	if err != nil {
+		return
+	}
+	gameMap := make(map[int64]*model.Game, len(games))
+	for _, game := range games {
+		gameMap[game.ID] = game
+	}
+	for _, gameID := range gameIDs {
+		if game, ok := gameMap[gameID]; ok {
+			out = append(out, game)
+		}
+	}
+	return
+}
+
 func (gg *Game) AssertExists(ctx context.Context, gameID int64) (err error) {
 	exists, err := dao.Game.Ctx(ctx).Where(dao.Game.Columns().ID, gameID).Exist()
 	if err != nil {
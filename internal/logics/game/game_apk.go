@@ -0,0 +1,265 @@
+package game
+
+import (
+	"GameEngine/internal/common/apk"
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	defaultApkDownloadTimeout = 10 * time.Minute
+	maxApkParseErrorLength    = 500
+	maxApkCertSubjectLength   = 512
+)
+
+// GetApkInfo 获取APK安装包的解析结果
+func (gg *Game) GetApkInfo(ctx context.Context, gameID int64, fileID string) (out *model.GameApkInfo, err error) {
+	var apkInfo entity.GameApkInfo
+	err = dao.GameApkInfo.Ctx(ctx).
+		Where(dao.GameApkInfo.Columns().GameID, gameID).
+		Where(dao.GameApkInfo.Columns().FileID, fileID).
+		Scan(&apkInfo)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("APK解析结果不存在")
+		}
+		return
+	}
+	return model.ConvertGameApkInfoEntityToModel(&apkInfo), nil
+}
+
+// addApkParseTask APK文件通过内容安全扫描后创建解析任务，文件重复扫描时重新解析，需要与文件状态更新处于同一事务中
+// 返回 false 表示文件不是APK安装包
+func addApkParseTask(ctx context.Context, tx gdb.TX, fileID string) (added bool, err error) {
+	gameID, err := getApkFileGameID(ctx, fileID)
+	if err != nil || gameID == 0 {
+		return false, err
+	}
+
+	_, err = dao.GameApkInfo.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameApkInfo.Columns().GameID: gameID,
+		dao.GameApkInfo.Columns().FileID: fileID,
+		dao.GameApkInfo.Columns().Status: int(model.GameApkParseStatusPending),
+		dao.GameApkInfo.Columns().Error:  "",
+	}).Save()
+	if err != nil {
+		return false, err
+	}
+	id, err := dao.GameApkInfo.Ctx(ctx).Where(dao.GameApkInfo.Columns().FileID, fileID).Value(dao.GameApkInfo.Columns().ID)
+	if err != nil {
+		return false, err
+	}
+
+	contentBytes, err := model.MarshalAsyncTaskPayload(&model.GameApkParseTask{
+		FileID: fileID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("序列化任务内容失败: %v", err)
+	}
+	customID := fmt.Sprintf("game_apk_parse_%d", id.Int64())
+	err = service.AsyncTask().Upsert(ctx, tx, model.AsyncTaskTypeGameApkParse, customID, contentBytes, nil)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getApkFileGameID 文件属于线上数据、草稿的APK媒体文件或构建版本时返回游戏ID，否则返回 0
+func getApkFileGameID(ctx context.Context, fileID string) (gameID int64, err error) {
+	value, err := dao.GameMediaInfo.Ctx(ctx).
+		Where(dao.GameMediaInfo.Columns().FileID, fileID).
+		Where(dao.GameMediaInfo.Columns().MediaType, int(model.GameMediaTypeApkFile)).
+		Value(dao.GameMediaInfo.Columns().GameID)
+	if err != nil || !value.IsEmpty() {
+		return value.Int64(), err
+	}
+
+	value, err = dao.GameDraftMediaInfo.Ctx(ctx).
+		Where(dao.GameDraftMediaInfo.Columns().FileID, fileID).
+		Where(dao.GameDraftMediaInfo.Columns().MediaType, int(model.GameMediaTypeApkFile)).
+		Value(dao.GameDraftMediaInfo.Columns().GameID)
+	if err != nil || !value.IsEmpty() {
+		return value.Int64(), err
+	}
+
+	value, err = dao.GameBuild.Ctx(ctx).
+		Where(dao.GameBuild.Columns().FileID, fileID).
+		Value(dao.GameBuild.Columns().GameID)
+	return value.Int64(), err
+}
+
+// HandleApkParse 下载APK并解析包信息和签名证书
+// 下载失败返回错误由异步任务重试；文件本身无法解析时记录失败原因，不再重试
+func (gg *Game) HandleApkParse(ctx context.Context, task *model.GameApkParseTask) (err error) {
+	fileID := task.FileID
+
+	file, size, err := downloadApk(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("下载APK失败: file_id=%s, %v", fileID, err)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	info, parseErr := apk.Parse(file, size)
+	if parseErr != nil {
+		g.Log().Warningf(ctx, "[APK] 解析失败: file_id=%s, %v", fileID, parseErr)
+		return saveApkParseResult(ctx, fileID, map[string]interface{}{
+			dao.GameApkInfo.Columns().Status: int(model.GameApkParseStatusFailed),
+			dao.GameApkInfo.Columns().Error:  truncateRunes(parseErr.Error(), maxApkParseErrorLength),
+		})
+	}
+
+	permissions, err := json.Marshal(info.Permissions)
+	if err != nil {
+		return
+	}
+	err = saveApkParseResult(ctx, fileID, map[string]interface{}{
+		dao.GameApkInfo.Columns().Status:          int(model.GameApkParseStatusSuccess),
+		dao.GameApkInfo.Columns().PackageName:     info.PackageName,
+		dao.GameApkInfo.Columns().VersionCode:     info.VersionCode,
+		dao.GameApkInfo.Columns().VersionName:     info.VersionName,
+		dao.GameApkInfo.Columns().MinSdk:          info.MinSdkVersion,
+		dao.GameApkInfo.Columns().TargetSdk:       info.TargetSdkVersion,
+		dao.GameApkInfo.Columns().Permissions:     string(permissions),
+		dao.GameApkInfo.Columns().SignatureScheme: info.SignatureScheme,
+		dao.GameApkInfo.Columns().CertSha256:      info.Certificate.SHA256,
+		dao.GameApkInfo.Columns().CertSubject:     truncateRunes(info.Certificate.Subject, maxApkCertSubjectLength),
+		dao.GameApkInfo.Columns().Error:           "",
+	})
+	if err != nil {
+		return
+	}
+
+	g.Log().Infof(ctx, "[APK] 解析完成: file_id=%s, package=%s, version=%s(%d), signature=%s",
+		fileID, info.PackageName, info.VersionName, info.VersionCode, info.SignatureScheme)
+	return nil
+}
+
+func saveApkParseResult(ctx context.Context, fileID string, data map[string]interface{}) (err error) {
+	_, err = dao.GameApkInfo.Ctx(ctx).
+		Where(dao.GameApkInfo.Columns().FileID, fileID).
+		Data(data).
+		Update()
+	return
+}
+
+// downloadApk 下载到临时文件，调用方负责关闭并删除
+func downloadApk(ctx context.Context, fileID string) (file *os.File, size int64, err error) {
+	body, err := openMediaFile(ctx, fileID, g.Cfg().MustGet(ctx, "apkParse.downloadTimeout", defaultApkDownloadTimeout).Duration())
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	file, err = os.CreateTemp("", "game-apk-*.apk")
+	if err != nil {
+		return
+	}
+	maxSize := g.Cfg().MustGet(ctx, "validation.apk.maxSize", 2<<30).Int64()
+	size, err = io.Copy(file, io.LimitReader(body, maxSize+1))
+	if err == nil && size > maxSize {
+		err = fmt.Errorf("文件大小超过上限 %s", formatFileSize(maxSize))
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, 0, err
+	}
+	return file, size, nil
+}
+
+// markApkApproved 记录安装包审核通过，作为后续版本包名和签名证书的比对基准，需要与审核状态更新处于同一事务中
+func markApkApproved(ctx context.Context, gameID int64, fileIDs ...string) (err error) {
+	if len(fileIDs) == 0 {
+		return nil
+	}
+	_, err = dao.GameApkInfo.Ctx(ctx).
+		Where(dao.GameApkInfo.Columns().GameID, gameID).
+		WhereIn(dao.GameApkInfo.Columns().FileID, fileIDs).
+		Data(map[string]interface{}{
+			dao.GameApkInfo.Columns().ApproveTime: gtime.Now(),
+		}).Update()
+	return
+}
+
+// apkFileIDs 媒体文件中的APK安装包文件ID
+func apkFileIDs(mediaInfos []*model.GameMediaInfo) (fileIDs []string) {
+	for _, mediaInfo := range mediaInfos {
+		if mediaInfo.MediaType == model.GameMediaTypeApkFile && mediaInfo.FileID != "" {
+			fileIDs = append(fileIDs, mediaInfo.FileID)
+		}
+	}
+	return
+}
+
+// checkApkConsistency 检查安装包是否解析完成，以及包名、签名证书是否与最近审核通过的安装包一致
+// 功能上线前上传的安装包没有解析结果，只给出警告
+func checkApkConsistency(ctx context.Context, gameID int64, fileID string) (findings []*model.ValidationFinding, err error) {
+	var current entity.GameApkInfo
+	err = dao.GameApkInfo.Ctx(ctx).
+		Where(dao.GameApkInfo.Columns().GameID, gameID).
+		Where(dao.GameApkInfo.Columns().FileID, fileID).
+		Scan(&current)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return []*model.ValidationFinding{warning(model.ReviewFieldApk, "APK安装包没有解析结果，无法校验包名和签名证书")}, nil
+		}
+		return
+	}
+
+	switch model.GameApkParseStatus(current.Status) {
+	case model.GameApkParseStatusPending:
+		return []*model.ValidationFinding{blocking(model.ReviewFieldApk, "APK安装包正在解析中，请稍后再提交")}, nil
+	case model.GameApkParseStatusFailed:
+		return []*model.ValidationFinding{blocking(model.ReviewFieldApk, "APK安装包解析失败: %s", current.Error)}, nil
+	}
+
+	var baseline entity.GameApkInfo
+	err = dao.GameApkInfo.Ctx(ctx).
+		Where(dao.GameApkInfo.Columns().GameID, gameID).
+		Where(dao.GameApkInfo.Columns().Status, int(model.GameApkParseStatusSuccess)).
+		WhereNotNull(dao.GameApkInfo.Columns().ApproveTime).
+		WhereNot(dao.GameApkInfo.Columns().FileID, fileID).
+		OrderDesc(dao.GameApkInfo.Columns().ApproveTime).
+		OrderDesc(dao.GameApkInfo.Columns().ID).
+		Scan(&baseline)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return
+	}
+
+	if current.PackageName != baseline.PackageName {
+		findings = append(findings, blocking(model.ReviewFieldApk, "APK包名(%s)与已审核通过的版本(%s)不一致",
+			current.PackageName, baseline.PackageName))
+	}
+	if current.CertSha256 != baseline.CertSha256 {
+		findings = append(findings, blocking(model.ReviewFieldApk, "APK签名证书与已审核通过的版本(%s)不一致",
+			baseline.VersionName))
+	}
+	return findings, nil
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
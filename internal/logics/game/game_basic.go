@@ -2,9 +2,11 @@ package game
 
 import (
 	v1 "GameEngine/api/v1"
+	"GameEngine/internal/common/pinyin"
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
 	"GameEngine/internal/service"
 	"context"
 	"database/sql"
@@ -21,6 +23,11 @@ var (
 )
 
 func (gg *Game) CreateGame(ctx context.Context, in *v1.CreateGameReq) (id int64, err error) {
+	err = rejectSensitiveGameText(ctx, in.Name, in.Developer, in.Publisher)
+	if err != nil {
+		return
+	}
+
 	dataGameInsert := map[string]interface{}{
 		dao.Game.Columns().Name:           in.Name,
 		dao.Game.Columns().DistributeType: in.DistributeType,
@@ -30,6 +37,7 @@ func (gg *Game) CreateGame(ctx context.Context, in *v1.CreateGameReq) (id int64,
 		dao.Game.Columns().Details:        in.Details,
 		dao.Game.Columns().Status:         model.GameStatusInit,
 	}
+	setGamePinyin(dataGameInsert, in.Name, in.Developer)
 
 	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
 		id, err = dao.Game.Ctx(ctx).TX(tx).Data(dataGameInsert).InsertAndGetId()
@@ -40,6 +48,11 @@ func (gg *Game) CreateGame(ctx context.Context, in *v1.CreateGameReq) (id int64,
 			return err
 		}
 
+		err = openStatusPeriod(ctx, id, model.GameStatusInit)
+		if err != nil {
+			return err
+		}
+
 		err = service.Metadata().AddGameCategory(ctx, tx, id, in.CategoryID)
 		if err != nil {
 			return err
@@ -51,12 +64,23 @@ func (gg *Game) CreateGame(ctx context.Context, in *v1.CreateGameReq) (id int64,
 				return err
 			}
 		}
-		return nil
+
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_created_%d", id), &events.GameCreated{
+			GameID:         id,
+			Name:           in.Name,
+			DistributeType: model.GameDistributeType(in.DistributeType),
+			Developer:      in.Developer,
+			Publisher:      in.Publisher,
+			CategoryID:     in.CategoryID,
+			TagIDs:         in.TagIDs,
+		})
 	})
 	if err != nil {
 		return
 	}
 
+	service.Outbox().WakeUp()
+	service.Search().Refresh(ctx, id)
 	return
 }
 
@@ -68,11 +92,17 @@ func (gg *Game) CreateGame(ctx context.Context, in *v1.CreateGameReq) (id int64,
 // 6、删除游戏预约
 // 4、删除游戏媒体关联（TODO: 基于异步任务队列）
 func (gg *Game) DeleteGame(ctx context.Context, id int64) (err error) {
+	deleted := false
 	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
-		_, err = dao.Game.Ctx(ctx).TX(tx).Where(dao.Game.Columns().ID, id).Delete()
+		result, err := dao.Game.Ctx(ctx).TX(tx).Where(dao.Game.Columns().ID, id).Delete()
 		if err != nil {
 			return err
 		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		deleted = rowsAffected > 0
 
 		err = service.Metadata().RemoveGameCategory(ctx, tx, id)
 		if err != nil {
@@ -83,18 +113,43 @@ func (gg *Game) DeleteGame(ctx context.Context, id int64) (err error) {
 		if err != nil {
 			return err
 		}
-		return nil
+
+		// 游戏不存在时不发送删除事件
+		if !deleted {
+			return nil
+		}
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_deleted_%d", id), &events.GameDeleted{
+			GameID: id,
+		})
 	})
+	if err != nil {
+		return
+	}
+
+	if deleted {
+		service.Outbox().WakeUp()
+		service.Search().Refresh(ctx, id)
+	}
 	return
 }
 
 func (gg *Game) UpdateGame(ctx context.Context, in *v1.UpdateGameReq) (err error) {
 	// 检查游戏是否存在
-	err = gg.AssertExists(ctx, in.ID)
+	gameInfo, err := gg.GetGameByID(ctx, in.ID)
+	if err != nil {
+		return err
+	}
+
+	err = rejectSensitiveGameText(ctx, in.Name, in.Developer, in.Publisher)
 	if err != nil {
 		return err
 	}
 
+	// 线上游戏的修改写入草稿，审核通过后才会覆盖线上数据
+	if model.IsGameLive(gameInfo.Status) {
+		return gg.saveDraft(ctx, in)
+	}
+
 	// 构建更新数据
 	updateData := make(map[string]interface{})
 	if in.Name != "" {
@@ -115,6 +170,7 @@ func (gg *Game) UpdateGame(ctx context.Context, in *v1.UpdateGameReq) (err error
 	if in.Details != "" {
 		updateData[dao.Game.Columns().Details] = in.Details
 	}
+	setGamePinyin(updateData, in.Name, in.Developer)
 
 	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
 		if len(updateData) > 0 {
@@ -159,7 +215,11 @@ func (gg *Game) UpdateGame(ctx context.Context, in *v1.UpdateGameReq) (err error
 
 		return nil
 	})
+	if err != nil {
+		return
+	}
 
+	service.Search().Refresh(ctx, in.ID)
 	return
 }
 
@@ -224,3 +284,37 @@ func (gg *Game) ListGame(ctx context.Context, in *v1.ListGameReq) (outs []*model
 	}
 	return
 }
+
+// rejectSensitiveGameText 名称、开发商、发行商包含敏感词时直接拒绝
+// 描述和详情在提交审核时检查，命中时提示审核人员
+func rejectSensitiveGameText(ctx context.Context, name, developer, publisher string) (err error) {
+	fields := []struct {
+		field string
+		text  string
+	}{
+		{"游戏名称", name},
+		{"开发商", developer},
+		{"发行商", publisher},
+	}
+	for _, f := range fields {
+		err = service.SensitiveWord().Reject(ctx, f.field, f.text)
+		if err != nil {
+			return
+		}
+	}
+	return nil
+}
+
+// setGamePinyin 名称、开发商修改时同时保存拼音，用于拼音搜索；为空表示未修改
+func setGamePinyin(data map[string]interface{}, name, developer string) {
+	if name != "" {
+		forms := pinyin.Convert(name)
+		data[dao.Game.Columns().NamePinyin] = pinyin.Join(forms.Full)
+		data[dao.Game.Columns().NameInitials] = pinyin.Join(forms.Initials)
+	}
+	if developer != "" {
+		forms := pinyin.Convert(developer)
+		data[dao.Game.Columns().DeveloperPinyin] = pinyin.Join(forms.Full)
+		data[dao.Game.Columns().DeveloperInitials] = pinyin.Join(forms.Initials)
+	}
+}
@@ -0,0 +1,422 @@
+package game
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+var (
+	ErrGameBuildNotFound       = errors.New("游戏构建版本不存在")
+	ErrGameBuildNotAPK         = errors.New("只有APK游戏支持构建版本管理")
+	ErrGameBuildNoCurrent      = errors.New("游戏没有可下载的版本")
+	ErrGameBuildNoRollback     = errors.New("没有可回滚的历史版本")
+	ErrGameBuildVersionCodeLow = errors.New("版本号必须大于已有的版本号")
+)
+
+// CreateBuild 创建构建版本，文件由调用方预上传，上传结果通过 UpdateMediaInfoStatusByFileID 回写
+func (gg *Game) CreateBuild(ctx context.Context, in *model.GameBuild) (id int64, err error) {
+	gameInfo, err := gg.GetGameByID(ctx, in.GameID)
+	if err != nil {
+		return
+	}
+	if gameInfo.DistributeType != model.GameDistributeTypeAPK {
+		return 0, ErrGameBuildNotAPK
+	}
+
+	maxVersionCode, err := dao.GameBuild.Ctx(ctx).
+		Where(dao.GameBuild.Columns().GameID, in.GameID).
+		Max(dao.GameBuild.Columns().VersionCode)
+	if err != nil {
+		return
+	}
+	if in.VersionCode <= int64(maxVersionCode) {
+		return 0, ErrGameBuildVersionCodeLow
+	}
+
+	id, err = dao.GameBuild.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameBuild.Columns().GameID:       in.GameID,
+		dao.GameBuild.Columns().VersionName:  in.VersionName,
+		dao.GameBuild.Columns().VersionCode:  in.VersionCode,
+		dao.GameBuild.Columns().ReleaseNotes: in.ReleaseNotes,
+		dao.GameBuild.Columns().FileID:       in.FileID,
+		dao.GameBuild.Columns().FileSize:     in.FileSize,
+		dao.GameBuild.Columns().Checksum:     in.Checksum,
+		dao.GameBuild.Columns().MediaUrl:     in.MediaUrl,
+		dao.GameBuild.Columns().FileStatus:   model.GameMediaStatusInit,
+		dao.GameBuild.Columns().Status:       model.GameBuildStatusInit,
+	}).InsertAndGetId()
+	return
+}
+
+// GetBuild 获取构建版本
+func (gg *Game) GetBuild(ctx context.Context, gameID, buildID int64) (out *model.GameBuild, err error) {
+	var entity entity.GameBuild
+	err = dao.GameBuild.Ctx(ctx).
+		Where(dao.GameBuild.Columns().ID, buildID).
+		Where(dao.GameBuild.Columns().GameID, gameID).
+		Scan(&entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrGameBuildNotFound
+		}
+		return
+	}
+
+	out = model.ConvertGameBuildEntityToModel(&entity)
+	return
+}
+
+// ListBuilds 获取游戏的构建版本，按版本号倒序
+func (gg *Game) ListBuilds(ctx context.Context, gameID int64, pageReq *model.PageReq) (outs []*model.GameBuild, pageRes *model.PageRes, err error) {
+	if pageReq.Page == 0 {
+		pageReq.Page = 1
+	}
+	if pageReq.Size == 0 {
+		pageReq.Size = 20
+	}
+
+	err = gg.AssertExists(ctx, gameID)
+	if err != nil {
+		return
+	}
+
+	query := dao.GameBuild.Ctx(ctx).Where(dao.GameBuild.Columns().GameID, gameID)
+
+	total, err := query.Count()
+	if err != nil {
+		return
+	}
+
+	var entities []*entity.GameBuild
+	err = query.Page(pageReq.Page, pageReq.Size).
+		OrderDesc(dao.GameBuild.Columns().VersionCode).
+		Scan(&entities)
+	if err != nil {
+		return
+	}
+
+	for _, entity := range entities {
+		outs = append(outs, model.ConvertGameBuildEntityToModel(entity))
+	}
+	pageRes = &model.PageRes{
+		Total:       total,
+		CurrentPage: pageReq.Page,
+	}
+	return
+}
+
+// GetCurrentBuild 获取游戏的当前版本，没有当前版本时返回 nil
+func (gg *Game) GetCurrentBuild(ctx context.Context, gameID int64) (out *model.GameBuild, err error) {
+	var entity entity.GameBuild
+	err = dao.GameBuild.Ctx(ctx).
+		Where(dao.GameBuild.Columns().GameID, gameID).
+		Where(dao.GameBuild.Columns().IsCurrent, 1).
+		Scan(&entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return
+	}
+
+	out = model.ConvertGameBuildEntityToModel(&entity)
+	return
+}
+
+// SubmitBuildForReview 构建版本提交审核，文件必须已上传成功
+func (gg *Game) SubmitBuildForReview(ctx context.Context, gameID, buildID int64) (err error) {
+	build, err := gg.GetBuild(ctx, gameID, buildID)
+	if err != nil {
+		return
+	}
+	if build.FileStatus != model.GameMediaStatusClean {
+		return fmt.Errorf("构建版本文件%s", notCleanReason(build.FileStatus))
+	}
+
+	// 包名和签名证书必须与已审核通过的版本一致
+	findings, err := checkApkConsistency(ctx, gameID, build.FileID)
+	if err != nil {
+		return
+	}
+	blockingFindings := make([]*model.ValidationFinding, 0)
+	for _, finding := range findings {
+		if finding.Severity == model.ValidationSeverityBlocking {
+			finding.GameID = gameID
+			finding.Validator = ValidatorApkConsistency
+			blockingFindings = append(blockingFindings, finding)
+		}
+	}
+	if len(blockingFindings) > 0 {
+		return &model.ValidationError{Findings: blockingFindings}
+	}
+
+	return updateBuildStatus(ctx, build, []model.GameBuildStatus{model.GameBuildStatusInit, model.GameBuildStatusRejected}, model.GameBuildStatusInReview, "")
+}
+
+// ApproveBuild 构建版本审核通过，审核通过后可以设置为当前版本，需要持有审核任务
+// 游戏已上架，开发商不是首次提交，不需要双人审核
+func (gg *Game) ApproveBuild(ctx context.Context, gameID, buildID int64) (err error) {
+	_, err = service.Review().AssertClaimHolder(ctx, gameID)
+	if err != nil {
+		return
+	}
+	build, err := gg.GetBuild(ctx, gameID, buildID)
+	if err != nil {
+		return
+	}
+
+	return g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		err := updateBuildStatus(ctx, build, []model.GameBuildStatus{model.GameBuildStatusInReview}, model.GameBuildStatusApproved, "")
+		if err != nil {
+			return err
+		}
+		return markApkApproved(ctx, gameID, build.FileID)
+	})
+}
+
+// RejectBuild 构建版本审核拒绝，需要持有审核任务
+func (gg *Game) RejectBuild(ctx context.Context, gameID, buildID int64, reason string) (err error) {
+	_, err = service.Review().AssertClaimHolder(ctx, gameID)
+	if err != nil {
+		return
+	}
+	build, err := gg.GetBuild(ctx, gameID, buildID)
+	if err != nil {
+		return
+	}
+
+	return updateBuildStatus(ctx, build, []model.GameBuildStatus{model.GameBuildStatusInReview}, model.GameBuildStatusRejected, reason)
+}
+
+// SetCurrentBuild 将审核通过的构建版本设置为当前版本，不影响游戏状态
+func (gg *Game) SetCurrentBuild(ctx context.Context, gameID, buildID int64) (err error) {
+	build, err := gg.GetBuild(ctx, gameID, buildID)
+	if err != nil {
+		return
+	}
+	if build.Status != model.GameBuildStatusApproved {
+		return fmt.Errorf("构建版本未审核通过，当前状态: %s", model.GetGameBuildStatusText(build.Status))
+	}
+	if build.IsCurrent {
+		return nil
+	}
+
+	return setCurrentBuild(ctx, build)
+}
+
+// RollbackBuild 回滚到之前审核通过的构建版本，无需重新走游戏审核流程
+// buildID 为 0 时回滚到当前版本之前最近的一个审核通过的版本
+func (gg *Game) RollbackBuild(ctx context.Context, gameID, buildID int64) (out *model.GameBuild, err error) {
+	current, err := gg.GetCurrentBuild(ctx, gameID)
+	if err != nil {
+		return
+	}
+	if current == nil {
+		return nil, ErrGameBuildNoCurrent
+	}
+
+	if buildID == 0 {
+		var entity entity.GameBuild
+		err = dao.GameBuild.Ctx(ctx).
+			Where(dao.GameBuild.Columns().GameID, gameID).
+			Where(dao.GameBuild.Columns().Status, int(model.GameBuildStatusApproved)).
+			WhereLT(dao.GameBuild.Columns().VersionCode, current.VersionCode).
+			OrderDesc(dao.GameBuild.Columns().VersionCode).
+			Scan(&entity)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, ErrGameBuildNoRollback
+			}
+			return
+		}
+		out = model.ConvertGameBuildEntityToModel(&entity)
+	} else {
+		out, err = gg.GetBuild(ctx, gameID, buildID)
+		if err != nil {
+			return
+		}
+		if out.Status != model.GameBuildStatusApproved {
+			return nil, fmt.Errorf("构建版本未审核通过，当前状态: %s", model.GetGameBuildStatusText(out.Status))
+		}
+		if out.VersionCode >= current.VersionCode {
+			return nil, fmt.Errorf("只能回滚到比当前版本(%s)更早的版本", current.VersionName)
+		}
+	}
+
+	err = setCurrentBuild(ctx, out)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Log().Infof(ctx, "[Build] 游戏版本回滚: game_id=%d, from=%s(%d), to=%s(%d)",
+		gameID, current.VersionName, current.VersionCode, out.VersionName, out.VersionCode)
+	return
+}
+
+// ResolveDownloadFileID 获取游戏下载使用的文件ID
+// 优先使用当前构建版本，尚未创建构建版本的游戏使用线上的APK媒体文件
+func (gg *Game) ResolveDownloadFileID(ctx context.Context, gameID int64) (fileID string, err error) {
+	build, err := gg.GetCurrentBuild(ctx, gameID)
+	if err != nil {
+		return
+	}
+	if build != nil {
+		return build.FileID, nil
+	}
+
+	value, err := dao.GameMediaInfo.Ctx(ctx).
+		Where(dao.GameMediaInfo.Columns().GameID, gameID).
+		Where(dao.GameMediaInfo.Columns().MediaType, int(model.GameMediaTypeApkFile)).
+		// 内容扫描上线前上传的文件状态为成功，仍允许下载，可通过重新扫描接口补扫
+		WhereIn(dao.GameMediaInfo.Columns().Status, []int{int(model.GameMediaStatusSuccess), int(model.GameMediaStatusClean)}).
+		OrderDesc(dao.GameMediaInfo.Columns().ID).
+		Value(dao.GameMediaInfo.Columns().FileID)
+	if err != nil {
+		return
+	}
+	if value.IsEmpty() {
+		return "", ErrGameBuildNoCurrent
+	}
+	return value.String(), nil
+}
+
+// setCurrentBuild 切换当前版本，并同步线上的APK媒体文件，保证游戏详情展示的是当前版本
+func setCurrentBuild(ctx context.Context, build *model.GameBuild) (err error) {
+	return g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		_, err := dao.GameBuild.Ctx(ctx).
+			Where(dao.GameBuild.Columns().GameID, build.GameID).
+			Where(dao.GameBuild.Columns().IsCurrent, 1).
+			Data(map[string]interface{}{
+				dao.GameBuild.Columns().IsCurrent: 0,
+			}).Update()
+		if err != nil {
+			return err
+		}
+
+		result, err := dao.GameBuild.Ctx(ctx).
+			Where(dao.GameBuild.Columns().ID, build.ID).
+			Where(dao.GameBuild.Columns().Status, int(model.GameBuildStatusApproved)).
+			Data(map[string]interface{}{
+				dao.GameBuild.Columns().IsCurrent: 1,
+			}).Update()
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("构建版本状态已变更，请刷新后重试")
+		}
+
+		err = replaceDraftApk(ctx, build)
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.GameMediaInfo.Ctx(ctx).
+			Where(dao.GameMediaInfo.Columns().GameID, build.GameID).
+			Where(dao.GameMediaInfo.Columns().MediaType, int(model.GameMediaTypeApkFile)).
+			Delete()
+		if err != nil {
+			return err
+		}
+		_, err = dao.GameMediaInfo.Ctx(ctx).Data(map[string]interface{}{
+			dao.GameMediaInfo.Columns().GameID:    build.GameID,
+			dao.GameMediaInfo.Columns().FileID:    build.FileID,
+			dao.GameMediaInfo.Columns().MediaType: model.GameMediaTypeApkFile,
+			dao.GameMediaInfo.Columns().MediaUrl:  build.MediaUrl,
+			dao.GameMediaInfo.Columns().FileSize:  build.FileSize,
+			dao.GameMediaInfo.Columns().Status:    build.FileStatus,
+		}).Insert()
+		return err
+	})
+}
+
+// replaceDraftApk 草稿中仍是切换前的线上APK时替换为新的当前版本，避免草稿审核通过时用旧的APK覆盖当前版本
+// 开发者在草稿中上传的其他APK保持不变；需要在删除线上的APK媒体文件之前、与切换版本处于同一事务中调用
+func replaceDraftApk(ctx context.Context, build *model.GameBuild) (err error) {
+	values, err := dao.GameMediaInfo.Ctx(ctx).
+		Where(dao.GameMediaInfo.Columns().GameID, build.GameID).
+		Where(dao.GameMediaInfo.Columns().MediaType, int(model.GameMediaTypeApkFile)).
+		Array(dao.GameMediaInfo.Columns().FileID)
+	if err != nil || len(values) == 0 {
+		return
+	}
+	liveFileIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		liveFileIDs = append(liveFileIDs, value.String())
+	}
+
+	result, err := dao.GameDraftMediaInfo.Ctx(ctx).
+		Where(dao.GameDraftMediaInfo.Columns().GameID, build.GameID).
+		Where(dao.GameDraftMediaInfo.Columns().MediaType, int(model.GameMediaTypeApkFile)).
+		WhereIn(dao.GameDraftMediaInfo.Columns().FileID, liveFileIDs).
+		Delete()
+	if err != nil {
+		return
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil
+	}
+
+	exists, err := dao.GameDraftMediaInfo.Ctx(ctx).
+		Where(dao.GameDraftMediaInfo.Columns().FileID, build.FileID).
+		Exist()
+	if err != nil || exists {
+		return
+	}
+	_, err = dao.GameDraftMediaInfo.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameDraftMediaInfo.Columns().GameID:    build.GameID,
+		dao.GameDraftMediaInfo.Columns().FileID:    build.FileID,
+		dao.GameDraftMediaInfo.Columns().MediaType: model.GameMediaTypeApkFile,
+		dao.GameDraftMediaInfo.Columns().MediaUrl:  build.MediaUrl,
+		dao.GameDraftMediaInfo.Columns().FileSize:  build.FileSize,
+		dao.GameDraftMediaInfo.Columns().Status:    build.FileStatus,
+	}).Insert()
+	return
+}
+
+// updateBuildStatus 更新构建版本审核状态，当前状态不在 fromStatuses 中时返回错误
+func updateBuildStatus(ctx context.Context, build *model.GameBuild, fromStatuses []model.GameBuildStatus, toStatus model.GameBuildStatus, reason string) (err error) {
+	allowed := false
+	for _, status := range fromStatuses {
+		if build.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("构建版本当前状态(%s)不允许变更为(%s)",
+			model.GetGameBuildStatusText(build.Status), model.GetGameBuildStatusText(toStatus))
+	}
+
+	result, err := dao.GameBuild.Ctx(ctx).
+		Where(dao.GameBuild.Columns().ID, build.ID).
+		Where(dao.GameBuild.Columns().Status, int(build.Status)).
+		Data(map[string]interface{}{
+			dao.GameBuild.Columns().Status:       toStatus,
+			dao.GameBuild.Columns().RejectReason: reason,
+		}).Update()
+	if err != nil {
+		return
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("构建版本状态已变更，请刷新后重试")
+	}
+	return
+}
@@ -0,0 +1,273 @@
+package game
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const (
+	defaultContentScanDownloadTimeout = 10 * time.Minute
+	defaultContentScanAlertTopic      = "core.alert.content-scan"
+	// imageHeaderSize 读取图片尺寸时保留的文件开头长度，JPEG 的尺寸在 EXIF 等信息之后
+	imageHeaderSize = 1 << 20
+)
+
+// addContentScanTask 创建文件内容扫描任务，需要与文件状态更新处于同一事务中
+func addContentScanTask(ctx context.Context, tx gdb.TX, fileID string) error {
+	contentBytes, err := model.MarshalAsyncTaskPayload(&model.MediaContentScanTask{
+		FileID: fileID,
+	})
+	if err != nil {
+		return fmt.Errorf("序列化任务内容失败: %v", err)
+	}
+
+	customID := "media_content_scan_" + fileIDDigest(fileID)
+	return service.AsyncTask().Upsert(ctx, tx, model.AsyncTaskTypeMediaContentScan, customID, contentBytes, nil)
+}
+
+// fileIDDigest 文件ID长度不固定，自定义任务ID、去重键使用文件ID的摘要
+func fileIDDigest(fileID string) string {
+	sum := md5.Sum([]byte(fileID))
+	return hex.EncodeToString(sum[:8])
+}
+
+// HandleContentScan 下载文件并扫描恶意内容：扫描通过标记为安全，发现恶意内容时标记为存在风险并删除文件
+// 扫描引擎不可用时返回错误，由异步任务重试
+func (gg *Game) HandleContentScan(ctx context.Context, task *model.MediaContentScanTask) (err error) {
+	fileID := task.FileID
+
+	statuses, err := getMediaFileStatuses(ctx, fileID)
+	if err != nil {
+		return
+	}
+	switch {
+	case statuses[model.GameMediaStatusInfected]:
+		// 已标记为存在风险但删除文件失败，只重试删除
+		return deleteInfectedFile(ctx, fileID)
+	case !statuses[model.GameMediaStatusScanning] && !statuses[model.GameMediaStatusSuccess]:
+		g.Log().Infof(ctx, "[ContentScan] 文件状态已变更，跳过扫描: file_id=%s", fileID)
+		return nil
+	}
+
+	body, err := openMediaFile(ctx, fileID, g.Cfg().MustGet(ctx, "contentScanner.downloadTimeout", defaultContentScanDownloadTimeout).Duration())
+	if err != nil {
+		return fmt.Errorf("下载文件失败: file_id=%s, %v", fileID, err)
+	}
+	defer body.Close()
+
+	// 扫描的同时保留文件开头的内容，用于读取截图尺寸
+	header := &headBuffer{limit: imageHeaderSize}
+	result, err := service.ContentScanner().Scan(ctx, io.TeeReader(body, header))
+	if err != nil {
+		return fmt.Errorf("扫描文件失败: file_id=%s, %v", fileID, err)
+	}
+
+	if !result.Infected {
+		g.Log().Infof(ctx, "[ContentScan] 扫描通过: file_id=%s, engine=%s", fileID, result.Engine)
+		err = updateScreenshotSize(ctx, fileID, header.Bytes())
+		if err != nil {
+			return
+		}
+		_, err = gg.updateMediaFileStatus(ctx, fileID, nil, model.GameMediaStatusClean, result)
+		return
+	}
+
+	g.Log().Warningf(ctx, "[ContentScan] 发现恶意内容: file_id=%s, engine=%s, signature=%s", fileID, result.Engine, result.Signature)
+	_, err = gg.updateMediaFileStatus(ctx, fileID, nil, model.GameMediaStatusInfected, result)
+	if err != nil {
+		return
+	}
+	if alertErr := publishContentScanAlert(ctx, fileID, result); alertErr != nil {
+		g.Log().Errorf(ctx, "[ContentScan] 发送告警失败: file_id=%s, %v", fileID, alertErr)
+	}
+	return deleteInfectedFile(ctx, fileID)
+}
+
+// RescanMediaFile 重新扫描文件，用于内容扫描上线前上传的文件，以及扫描长时间未完成的文件
+// 不修改文件状态，扫描期间不影响已上线文件的下载
+func (gg *Game) RescanMediaFile(ctx context.Context, fileID string) (err error) {
+	statuses, err := getMediaFileStatuses(ctx, fileID)
+	if err != nil {
+		return
+	}
+	if !statuses[model.GameMediaStatusSuccess] && !statuses[model.GameMediaStatusScanning] {
+		return fmt.Errorf("文件不存在，或不是待扫描状态")
+	}
+
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		return addContentScanTask(ctx, tx, fileID)
+	})
+	if err != nil {
+		return
+	}
+	service.AsyncTask().WakeUp(model.AsyncTaskTypeMediaContentScan)
+	return nil
+}
+
+// getMediaFileStatuses 文件在线上数据、草稿、构建版本中的状态
+func getMediaFileStatuses(ctx context.Context, fileID string) (statuses map[model.GameMediaStatus]bool, err error) {
+	statuses = make(map[model.GameMediaStatus]bool)
+
+	values, err := dao.GameMediaInfo.Ctx(ctx).
+		Where(dao.GameMediaInfo.Columns().FileID, fileID).
+		Array(dao.GameMediaInfo.Columns().Status)
+	if err != nil {
+		return
+	}
+	draftValues, err := dao.GameDraftMediaInfo.Ctx(ctx).
+		Where(dao.GameDraftMediaInfo.Columns().FileID, fileID).
+		Array(dao.GameDraftMediaInfo.Columns().Status)
+	if err != nil {
+		return
+	}
+	buildValues, err := dao.GameBuild.Ctx(ctx).
+		Where(dao.GameBuild.Columns().FileID, fileID).
+		Array(dao.GameBuild.Columns().FileStatus)
+	if err != nil {
+		return
+	}
+
+	values = append(values, draftValues...)
+	values = append(values, buildValues...)
+	for _, value := range values {
+		statuses[model.GameMediaStatus(value.Int())] = true
+	}
+	return
+}
+
+func deleteInfectedFile(ctx context.Context, fileID string) (err error) {
+	err = service.FileEngine().Delete(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("删除存在风险的文件失败: file_id=%s, %v", fileID, err)
+	}
+	g.Log().Infof(ctx, "[ContentScan] 已删除存在风险的文件: file_id=%s", fileID)
+	return nil
+}
+
+func publishContentScanAlert(ctx context.Context, fileID string, result *model.ContentScanResult) (err error) {
+	gameID, err := getMediaFileGameID(ctx, fileID)
+	if err != nil {
+		return
+	}
+
+	body := map[string]interface{}{
+		"title":     "上传文件发现恶意内容",
+		"game_id":   gameID,
+		"file_id":   fileID,
+		"engine":    result.Engine,
+		"signature": result.Signature,
+	}
+	topic := g.Cfg().MustGet(ctx, "contentScanner.alertTopic", defaultContentScanAlertTopic).String()
+	return service.MQ().Publish(ctx, topic, body)
+}
+
+// getMediaFileGameID 文件所属的游戏ID，文件不存在时返回 0
+func getMediaFileGameID(ctx context.Context, fileID string) (gameID int64, err error) {
+	value, err := dao.GameMediaInfo.Ctx(ctx).Where(dao.GameMediaInfo.Columns().FileID, fileID).Value(dao.GameMediaInfo.Columns().GameID)
+	if err != nil || !value.IsEmpty() {
+		return value.Int64(), err
+	}
+	value, err = dao.GameDraftMediaInfo.Ctx(ctx).Where(dao.GameDraftMediaInfo.Columns().FileID, fileID).Value(dao.GameDraftMediaInfo.Columns().GameID)
+	if err != nil || !value.IsEmpty() {
+		return value.Int64(), err
+	}
+	value, err = dao.GameBuild.Ctx(ctx).Where(dao.GameBuild.Columns().FileID, fileID).Value(dao.GameBuild.Columns().GameID)
+	return value.Int64(), err
+}
+
+// openMediaFile 通过文件引擎获取下载地址并打开文件内容，调用方负责关闭
+func openMediaFile(ctx context.Context, fileID string, timeout time.Duration) (body io.ReadCloser, err error) {
+	preDownload, err := service.FileEngine().PreDownload(ctx, fileID)
+	if err != nil {
+		return
+	}
+
+	if timeout <= 0 {
+		timeout = defaultContentScanDownloadTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, preDownload.DownloadURL, nil)
+	if err != nil {
+		cancel()
+		return
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("下载地址返回状态码 %d", resp.StatusCode)
+	}
+	return &cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}, nil
+}
+
+// cancelReadCloser 关闭时同时释放下载超时的 context
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelReadCloser) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
+
+// headBuffer 只保留写入内容的前 limit 个字节
+type headBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if remain := b.limit - b.Len(); remain > 0 {
+		b.Buffer.Write(p[:min(len(p), remain)])
+	}
+	return len(p), nil
+}
+
+// updateScreenshotSize 截图的宽高以服务端从文件中读取的为准，覆盖客户端上报的尺寸
+// 无法识别的图片格式记为 0，提交审核时提示无法检查宽高比
+func updateScreenshotSize(ctx context.Context, fileID string, header []byte) (err error) {
+	var width, height int
+	if config, _, err := image.DecodeConfig(bytes.NewReader(header)); err == nil {
+		width, height = config.Width, config.Height
+	}
+
+	_, err = dao.GameMediaInfo.Ctx(ctx).
+		Where(dao.GameMediaInfo.Columns().FileID, fileID).
+		Where(dao.GameMediaInfo.Columns().MediaType, model.GameMediaTypeScreenshot).
+		Data(map[string]interface{}{
+			dao.GameMediaInfo.Columns().Width:  width,
+			dao.GameMediaInfo.Columns().Height: height,
+		}).Update()
+	if err != nil {
+		return
+	}
+	_, err = dao.GameDraftMediaInfo.Ctx(ctx).
+		Where(dao.GameDraftMediaInfo.Columns().FileID, fileID).
+		Where(dao.GameDraftMediaInfo.Columns().MediaType, model.GameMediaTypeScreenshot).
+		Data(map[string]interface{}{
+			dao.GameDraftMediaInfo.Columns().Width:  width,
+			dao.GameDraftMediaInfo.Columns().Height: height,
+		}).Update()
+	return
+}
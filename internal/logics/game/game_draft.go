@@ -0,0 +1,640 @@
+package game
+
+import (
+	v1 "GameEngine/api/v1"
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+var (
+	ErrGameDraftNotFound = errors.New("游戏草稿不存在")
+	ErrGameDraftExists   = errors.New("游戏已有未完成的草稿")
+	ErrGameDraftInReview = errors.New("游戏草稿审核中，无法修改")
+	ErrGameNotLive       = errors.New("游戏未上架，请直接修改游戏信息")
+)
+
+// GetDraft 获取游戏草稿
+func (gg *Game) GetDraft(ctx context.Context, gameID int64) (out *model.GameDraft, err error) {
+	return getDraft(ctx, gameID)
+}
+
+// GetDraftMediaInfo 获取游戏草稿的媒体信息
+func (gg *Game) GetDraftMediaInfo(ctx context.Context, gameID int64) (out []*model.GameMediaInfo, err error) {
+	return getDraftMediaInfo(ctx, gameID)
+}
+
+// DiscardDraft 丢弃编辑中的草稿
+func (gg *Game) DiscardDraft(ctx context.Context, gameID int64) (err error) {
+	draft, err := getDraft(ctx, gameID)
+	if err != nil {
+		return
+	}
+	if draft.Status != model.GameDraftStatusEditing {
+		return ErrGameDraftInReview
+	}
+
+	return g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		return removeDraft(ctx, gameID)
+	})
+}
+
+// SubmitDraftForReview 草稿提交审核，H5链接的可访问性在事务之外检查
+func (gg *Game) SubmitDraftForReview(ctx context.Context, gameID int64) (err error) {
+	mediaInfos, err := getDraftMediaInfo(ctx, gameID)
+	if err != nil {
+		return
+	}
+	data := map[string]interface{}{
+		"h5_link_probes": probeH5Links(ctx, mediaInfos),
+	}
+	return gg.HandleGameEvent(ctx, gameID, model.SubmitDraftForReview, data)
+}
+
+// ApproveDraft 草稿审核通过，草稿内容覆盖线上数据，需要持有审核任务
+// 游戏已审核通过，开发商不是首次提交，不需要双人审核
+func (gg *Game) ApproveDraft(ctx context.Context, gameID int64) (err error) {
+	_, err = service.Review().AssertClaimHolder(ctx, gameID)
+	if err != nil {
+		return
+	}
+	return gg.HandleGameEvent(ctx, gameID, model.ApproveDraft, nil)
+}
+
+// RejectDraft 草稿审核拒绝，草稿回到编辑中，需要持有审核任务
+func (gg *Game) RejectDraft(ctx context.Context, gameID int64, reason string) (err error) {
+	_, err = service.Review().AssertClaimHolder(ctx, gameID)
+	if err != nil {
+		return
+	}
+	data := map[string]interface{}{
+		"reason": reason,
+	}
+	return gg.HandleGameEvent(ctx, gameID, model.RejectDraft, data)
+}
+
+// ListDraftInReview 获取待审核的草稿
+func (gg *Game) ListDraftInReview(ctx context.Context, pageReq *model.PageReq) (outs []*model.GameDraft, pageRes *model.PageRes, err error) {
+	if pageReq.Page == 0 {
+		pageReq.Page = 1
+	}
+	if pageReq.Size == 0 {
+		pageReq.Size = 20
+	}
+
+	query := dao.GameDraft.Ctx(ctx).Where(dao.GameDraft.Columns().Status, int(model.GameDraftStatusInReview))
+
+	total, err := query.Count()
+	if err != nil {
+		return
+	}
+
+	var entities []*entity.GameDraft
+	err = query.Page(pageReq.Page, pageReq.Size).OrderAsc(dao.GameDraft.Columns().UpdateTime).Scan(&entities)
+	if err != nil {
+		return
+	}
+
+	for _, entity := range entities {
+		outs = append(outs, model.ConvertGameDraftEntityToModel(entity))
+	}
+	pageRes = &model.PageRes{
+		Total:       total,
+		CurrentPage: pageReq.Page,
+	}
+	return
+}
+
+// DiffDraft 对比草稿与线上数据，供审核人员查看本次修改的内容
+func (gg *Game) DiffDraft(ctx context.Context, gameID int64) (out *model.GameDraftDiff, err error) {
+	gameInfo, err := gg.GetGameByID(ctx, gameID)
+	if err != nil {
+		return
+	}
+	draft, err := getDraft(ctx, gameID)
+	if err != nil {
+		return
+	}
+
+	out = &model.GameDraftDiff{
+		GameID:       gameID,
+		Fields:       make([]*model.GameFieldDiff, 0),
+		MediaAdded:   make([]*model.GameMediaInfo, 0),
+		MediaRemoved: make([]*model.GameMediaInfo, 0),
+	}
+	addDiff := func(field string, liveValue, draftValue interface{}) {
+		if fmt.Sprint(liveValue) != fmt.Sprint(draftValue) {
+			out.Fields = append(out.Fields, &model.GameFieldDiff{
+				Field:      field,
+				LiveValue:  liveValue,
+				DraftValue: draftValue,
+			})
+		}
+	}
+	addDiff("name", gameInfo.Name, draft.Name)
+	addDiff("distribute_type", gameInfo.DistributeType, draft.DistributeType)
+	addDiff("developer", gameInfo.Developer, draft.Developer)
+	addDiff("publisher", gameInfo.Publisher, draft.Publisher)
+	addDiff("description", gameInfo.Description, draft.Description)
+	addDiff("details", gameInfo.Details, draft.Details)
+
+	category, err := service.Metadata().GetCategoryByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	var liveCategoryID int64
+	if category != nil {
+		liveCategoryID = category.ID
+	}
+	addDiff("category_id", liveCategoryID, draft.CategoryID)
+
+	tags, err := service.Metadata().GetTagsByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	liveTagIDs := make([]int64, 0, len(tags))
+	for _, tag := range tags {
+		liveTagIDs = append(liveTagIDs, tag.ID)
+	}
+	draftTagIDs := append([]int64{}, draft.TagIDs...)
+	sort.Slice(liveTagIDs, func(i, j int) bool { return liveTagIDs[i] < liveTagIDs[j] })
+	sort.Slice(draftTagIDs, func(i, j int) bool { return draftTagIDs[i] < draftTagIDs[j] })
+	addDiff("tag_ids", liveTagIDs, draftTagIDs)
+
+	liveMediaInfos, err := gg.GetMediaInfo(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	draftMediaInfos, err := getDraftMediaInfo(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	liveMediaKeys := make(map[string]bool, len(liveMediaInfos))
+	for _, mediaInfo := range liveMediaInfos {
+		liveMediaKeys[mediaKey(mediaInfo)] = true
+	}
+	draftMediaKeys := make(map[string]bool, len(draftMediaInfos))
+	for _, mediaInfo := range draftMediaInfos {
+		draftMediaKeys[mediaKey(mediaInfo)] = true
+		if !liveMediaKeys[mediaKey(mediaInfo)] {
+			out.MediaAdded = append(out.MediaAdded, mediaInfo)
+		}
+	}
+	for _, mediaInfo := range liveMediaInfos {
+		if !draftMediaKeys[mediaKey(mediaInfo)] {
+			out.MediaRemoved = append(out.MediaRemoved, mediaInfo)
+		}
+	}
+
+	return
+}
+
+// mediaKey 媒体文件的唯一标识，H5链接没有文件ID，使用链接地址区分
+func mediaKey(mediaInfo *model.GameMediaInfo) string {
+	if mediaInfo.FileID == "" {
+		return fmt.Sprintf("%d:%s", mediaInfo.MediaType, mediaInfo.MediaUrl)
+	}
+	return fmt.Sprintf("%d:%s", mediaInfo.MediaType, mediaInfo.FileID)
+}
+
+// saveDraft 修改线上游戏的信息，写入草稿
+func (gg *Game) saveDraft(ctx context.Context, in *v1.UpdateGameReq) (err error) {
+	draft, err := gg.ensureEditableDraft(ctx, in.ID)
+	if err != nil {
+		return
+	}
+
+	updateData := make(map[string]interface{})
+	if in.Name != "" {
+		updateData[dao.GameDraft.Columns().Name] = in.Name
+	}
+	if in.DistributeType > 0 {
+		updateData[dao.GameDraft.Columns().DistributeType] = in.DistributeType
+	}
+	if in.Developer != "" {
+		updateData[dao.GameDraft.Columns().Developer] = in.Developer
+	}
+	if in.Publisher != "" {
+		updateData[dao.GameDraft.Columns().Publisher] = in.Publisher
+	}
+	if in.Description != "" {
+		updateData[dao.GameDraft.Columns().Description] = in.Description
+	}
+	if in.Details != "" {
+		updateData[dao.GameDraft.Columns().Details] = in.Details
+	}
+	if in.CategoryID > 0 {
+		updateData[dao.GameDraft.Columns().CategoryID] = in.CategoryID
+	}
+	if len(in.TagIDs) > 0 {
+		tagIDs, err := json.Marshal(in.TagIDs)
+		if err != nil {
+			return err
+		}
+		updateData[dao.GameDraft.Columns().TagIDs] = string(tagIDs)
+	}
+	if len(updateData) == 0 {
+		return
+	}
+	updateData[dao.GameDraft.Columns().Version] = draft.Version + 1
+
+	result, err := dao.GameDraft.Ctx(ctx).
+		Where(dao.GameDraft.Columns().GameID, in.ID).
+		Where(dao.GameDraft.Columns().Version, draft.Version).
+		Where(dao.GameDraft.Columns().Status, int(model.GameDraftStatusEditing)).
+		Data(updateData).
+		Update()
+	if err != nil {
+		return
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		return ErrConcurrentUpdate
+	}
+	return
+}
+
+// addDraftMediaInfo 添加草稿媒体信息
+func (gg *Game) addDraftMediaInfo(ctx context.Context, mediaInfo *model.GameMediaInfo) (err error) {
+	_, err = gg.ensureEditableDraft(ctx, mediaInfo.GameID)
+	if err != nil {
+		return
+	}
+
+	_, err = dao.GameDraftMediaInfo.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameDraftMediaInfo.Columns().GameID:    mediaInfo.GameID,
+		dao.GameDraftMediaInfo.Columns().FileID:    mediaInfo.FileID,
+		dao.GameDraftMediaInfo.Columns().MediaType: mediaInfo.MediaType,
+		dao.GameDraftMediaInfo.Columns().MediaUrl:  mediaInfo.MediaUrl,
+		dao.GameDraftMediaInfo.Columns().FileSize:  mediaInfo.FileSize,
+		dao.GameDraftMediaInfo.Columns().Width:     mediaInfo.Width,
+		dao.GameDraftMediaInfo.Columns().Height:    mediaInfo.Height,
+		dao.GameDraftMediaInfo.Columns().Status:    mediaInfo.Status,
+	}).Insert()
+	return
+}
+
+// setDraftH5Link 设置草稿的H5链接，替换草稿中已有的链接
+func (gg *Game) setDraftH5Link(ctx context.Context, gameID int64, link string) (err error) {
+	_, err = gg.ensureEditableDraft(ctx, gameID)
+	if err != nil {
+		return
+	}
+
+	return g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		_, err = dao.GameDraftMediaInfo.Ctx(ctx).TX(tx).
+			Where(dao.GameDraftMediaInfo.Columns().GameID, gameID).
+			Where(dao.GameDraftMediaInfo.Columns().MediaType, model.GameMediaTypeH5Link).
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.GameDraftMediaInfo.Ctx(ctx).TX(tx).Data(map[string]interface{}{
+			dao.GameDraftMediaInfo.Columns().GameID:    gameID,
+			dao.GameDraftMediaInfo.Columns().FileID:    "",
+			dao.GameDraftMediaInfo.Columns().MediaType: model.GameMediaTypeH5Link,
+			dao.GameDraftMediaInfo.Columns().MediaUrl:  link,
+			dao.GameDraftMediaInfo.Columns().Status:    model.GameMediaStatusSuccess,
+		}).Insert()
+		return err
+	})
+}
+
+// updateDraftMediaInfoByGameID 保存草稿媒体信息，移除不在列表中的文件
+func (gg *Game) updateDraftMediaInfoByGameID(ctx context.Context, gameID int64, mediaInfos []*model.GameMediaInfo) (err error) {
+	_, err = gg.ensureEditableDraft(ctx, gameID)
+	if err != nil {
+		return
+	}
+
+	keepFileIDs := make([]string, 0, len(mediaInfos))
+	for _, mediaInfo := range mediaInfos {
+		if mediaInfo.FileID != "" {
+			keepFileIDs = append(keepFileIDs, mediaInfo.FileID)
+		}
+	}
+
+	query := dao.GameDraftMediaInfo.Ctx(ctx).
+		Where(dao.GameDraftMediaInfo.Columns().GameID, gameID).
+		WhereNot(dao.GameDraftMediaInfo.Columns().MediaType, model.GameMediaTypeH5Link)
+	if len(keepFileIDs) > 0 {
+		query = query.WhereNotIn(dao.GameDraftMediaInfo.Columns().FileID, keepFileIDs)
+	}
+	_, err = query.Delete()
+	return
+}
+
+// ensureEditableDraft 获取可编辑的草稿，草稿不存在时基于线上数据开启草稿
+// 只有线上游戏可以开启草稿，其他状态的游戏触发修改信息事件会改变游戏状态(如审核通过回到初始状态)
+func (gg *Game) ensureEditableDraft(ctx context.Context, gameID int64) (draft *model.GameDraft, err error) {
+	gameInfo, err := gg.GetGameByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !model.IsGameLive(gameInfo.Status) {
+		return nil, ErrGameNotLive
+	}
+
+	draft, err = getDraft(ctx, gameID)
+	if err == ErrGameDraftNotFound {
+		err = gg.HandleGameEvent(ctx, gameID, model.UpdateInfo, nil)
+		if err != nil {
+			return nil, err
+		}
+		draft, err = getDraft(ctx, gameID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != model.GameDraftStatusEditing {
+		return nil, ErrGameDraftInReview
+	}
+	return
+}
+
+func getDraft(ctx context.Context, gameID int64) (out *model.GameDraft, err error) {
+	var entity entity.GameDraft
+	err = dao.GameDraft.Ctx(ctx).Where(dao.GameDraft.Columns().GameID, gameID).Scan(&entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrGameDraftNotFound
+		}
+		return
+	}
+
+	out = model.ConvertGameDraftEntityToModel(&entity)
+	return
+}
+
+func getDraftMediaInfo(ctx context.Context, gameID int64) (out []*model.GameMediaInfo, err error) {
+	var entities []*entity.GameDraftMediaInfo
+	err = dao.GameDraftMediaInfo.Ctx(ctx).Where(dao.GameDraftMediaInfo.Columns().GameID, gameID).Scan(&entities)
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		out = append(out, model.ConvertGameDraftMediaInfoEntityToModel(entity))
+	}
+	return
+}
+
+// removeDraft 删除草稿及草稿媒体信息
+func removeDraft(ctx context.Context, gameID int64) (err error) {
+	_, err = dao.GameDraft.Ctx(ctx).Where(dao.GameDraft.Columns().GameID, gameID).Delete()
+	if err != nil {
+		return
+	}
+	_, err = dao.GameDraftMediaInfo.Ctx(ctx).Where(dao.GameDraftMediaInfo.Columns().GameID, gameID).Delete()
+	return
+}
+
+// updateDraftStatus 草稿状态变更，基于版本号做并发控制
+func updateDraftStatus(ctx context.Context, draft *model.GameDraft, status model.GameDraftStatus) (err error) {
+	result, err := dao.GameDraft.Ctx(ctx).
+		Where(dao.GameDraft.Columns().ID, draft.ID).
+		Where(dao.GameDraft.Columns().Version, draft.Version).
+		Data(map[string]interface{}{
+			dao.GameDraft.Columns().Status:  int(status),
+			dao.GameDraft.Columns().Version: draft.Version + 1,
+		}).
+		Update()
+	if err != nil {
+		return
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		return ErrConcurrentUpdate
+	}
+	return
+}
+
+// 开启草稿：复制线上的基本信息、分类、标签、媒体文件
+func handleOpenDraft(ctx context.Context, gameInfo *model.Game, data interface{}) (err error) {
+	exists, err := dao.GameDraft.Ctx(ctx).Where(dao.GameDraft.Columns().GameID, gameInfo.ID).Exist()
+	if err != nil {
+		return
+	}
+	if exists {
+		return ErrGameDraftExists
+	}
+
+	var categoryID int64
+	category, err := service.Metadata().GetCategoryByGameID(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+	if category != nil {
+		categoryID = category.ID
+	}
+	tags, err := service.Metadata().GetTagsByGameID(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+	tagIDs := make([]int64, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	tagIDsBytes, err := json.Marshal(tagIDs)
+	if err != nil {
+		return
+	}
+
+	_, err = dao.GameDraft.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameDraft.Columns().GameID:         gameInfo.ID,
+		dao.GameDraft.Columns().Name:           gameInfo.Name,
+		dao.GameDraft.Columns().DistributeType: gameInfo.DistributeType,
+		dao.GameDraft.Columns().Developer:      gameInfo.Developer,
+		dao.GameDraft.Columns().Publisher:      gameInfo.Publisher,
+		dao.GameDraft.Columns().Description:    gameInfo.Description,
+		dao.GameDraft.Columns().Details:        gameInfo.Details,
+		dao.GameDraft.Columns().CategoryID:     categoryID,
+		dao.GameDraft.Columns().TagIDs:         string(tagIDsBytes),
+		dao.GameDraft.Columns().Status:         int(model.GameDraftStatusEditing),
+	}).Insert()
+	if err != nil {
+		return
+	}
+
+	mediaInfos, err := service.Game().GetMediaInfo(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+	if len(mediaInfos) == 0 {
+		return
+	}
+	dataInserts := make([]map[string]interface{}, 0, len(mediaInfos))
+	for _, mediaInfo := range mediaInfos {
+		dataInserts = append(dataInserts, map[string]interface{}{
+			dao.GameDraftMediaInfo.Columns().GameID:    mediaInfo.GameID,
+			dao.GameDraftMediaInfo.Columns().FileID:    mediaInfo.FileID,
+			dao.GameDraftMediaInfo.Columns().MediaType: mediaInfo.MediaType,
+			dao.GameDraftMediaInfo.Columns().MediaUrl:  mediaInfo.MediaUrl,
+			dao.GameDraftMediaInfo.Columns().FileSize:  mediaInfo.FileSize,
+			dao.GameDraftMediaInfo.Columns().Width:     mediaInfo.Width,
+			dao.GameDraftMediaInfo.Columns().Height:    mediaInfo.Height,
+			dao.GameDraftMediaInfo.Columns().Status:    mediaInfo.Status,
+		})
+	}
+	_, err = dao.GameDraftMediaInfo.Ctx(ctx).Data(dataInserts).Insert()
+	return
+}
+
+// 草稿提交审核
+func handleSubmitDraftForReview(ctx context.Context, gameInfo *model.Game, data interface{}) (err error) {
+	draft, err := getDraft(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+	if draft.Status != model.GameDraftStatusEditing {
+		return ErrGameDraftInReview
+	}
+
+	mediaInfos, err := getDraftMediaInfo(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+	err = validateForReview(ctx, &model.ValidationSubject{
+		GameID:         gameInfo.ID,
+		Draft:          true,
+		Name:           draft.Name,
+		DistributeType: draft.DistributeType,
+		Developer:      draft.Developer,
+		Publisher:      draft.Publisher,
+		Description:    draft.Description,
+		Details:        draft.Details,
+		MediaInfos:     mediaInfos,
+		H5LinkProbes:   h5LinkProbesFromData(data),
+	})
+	if err != nil {
+		return
+	}
+
+	return updateDraftStatus(ctx, draft, model.GameDraftStatusInReview)
+}
+
+// 草稿审核拒绝，回到编辑中
+func handleRejectDraft(ctx context.Context, gameInfo *model.Game, data interface{}) (err error) {
+	draft, err := getDraft(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+	if draft.Status != model.GameDraftStatusInReview {
+		return fmt.Errorf("游戏草稿未提交审核")
+	}
+
+	return updateDraftStatus(ctx, draft, model.GameDraftStatusEditing)
+}
+
+// 草稿审核通过：在同一事务中用草稿覆盖线上的基本信息、分类、标签、媒体文件，然后删除草稿
+func handleApproveDraft(ctx context.Context, gameInfo *model.Game, data interface{}) (err error) {
+	draft, err := getDraft(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+	if draft.Status != model.GameDraftStatusInReview {
+		return fmt.Errorf("游戏草稿未提交审核")
+	}
+	mediaInfos, err := getDraftMediaInfo(ctx, gameInfo.ID)
+	if err != nil {
+		return
+	}
+
+	return g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		updateData := map[string]interface{}{
+			dao.Game.Columns().Name:           draft.Name,
+			dao.Game.Columns().DistributeType: draft.DistributeType,
+			dao.Game.Columns().Developer:      draft.Developer,
+			dao.Game.Columns().Publisher:      draft.Publisher,
+			dao.Game.Columns().Description:    draft.Description,
+			dao.Game.Columns().Details:        draft.Details,
+			dao.Game.Columns().Version:        gameInfo.Version + 1,
+		}
+		setGamePinyin(updateData, draft.Name, draft.Developer)
+		result, err := dao.Game.Ctx(ctx).TX(tx).
+			Where(dao.Game.Columns().ID, gameInfo.ID).
+			Where(dao.Game.Columns().Version, gameInfo.Version).
+			Data(updateData).
+			Update()
+		if err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return fmt.Errorf("游戏名称已存在")
+			}
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrConcurrentUpdate
+		}
+
+		err = service.Metadata().RemoveGameCategory(ctx, tx, gameInfo.ID)
+		if err != nil {
+			return err
+		}
+		if draft.CategoryID > 0 {
+			err = service.Metadata().AddGameCategory(ctx, tx, gameInfo.ID, draft.CategoryID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = service.Metadata().RemoveGameTags(ctx, tx, gameInfo.ID)
+		if err != nil {
+			return err
+		}
+		err = service.Metadata().AddGameTags(ctx, tx, gameInfo.ID, draft.TagIDs)
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.GameMediaInfo.Ctx(ctx).TX(tx).Where(dao.GameMediaInfo.Columns().GameID, gameInfo.ID).Delete()
+		if err != nil {
+			return err
+		}
+		if len(mediaInfos) > 0 {
+			dataInserts := make([]map[string]interface{}, 0, len(mediaInfos))
+			for _, mediaInfo := range mediaInfos {
+				dataInserts = append(dataInserts, map[string]interface{}{
+					dao.GameMediaInfo.Columns().GameID:    mediaInfo.GameID,
+					dao.GameMediaInfo.Columns().FileID:    mediaInfo.FileID,
+					dao.GameMediaInfo.Columns().MediaType: mediaInfo.MediaType,
+					dao.GameMediaInfo.Columns().MediaUrl:  mediaInfo.MediaUrl,
+					dao.GameMediaInfo.Columns().FileSize:  mediaInfo.FileSize,
+					dao.GameMediaInfo.Columns().Width:     mediaInfo.Width,
+					dao.GameMediaInfo.Columns().Height:    mediaInfo.Height,
+					dao.GameMediaInfo.Columns().Status:    mediaInfo.Status,
+				})
+			}
+			_, err = dao.GameMediaInfo.Ctx(ctx).TX(tx).Data(dataInserts).Insert()
+			if err != nil {
+				return err
+			}
+		}
+		err = markApkApproved(ctx, gameInfo.ID, apkFileIDs(mediaInfos)...)
+		if err != nil {
+			return err
+		}
+
+		return removeDraft(ctx, gameInfo.ID)
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
+	"GameEngine/internal/service"
 	"context"
 	"fmt"
 
@@ -27,10 +29,10 @@ func (gg *Game) AddFavorite(ctx context.Context, gameID, userID int64) (err erro
 	}
 
 	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
-		_, err = dao.GameFavorite.Ctx(ctx).TX(tx).Data(map[string]interface{}{
+		favoriteID, err := dao.GameFavorite.Ctx(ctx).TX(tx).Data(map[string]interface{}{
 			dao.GameFavorite.Columns().GameID: gameID,
 			dao.GameFavorite.Columns().UserID: userID,
-		}).Insert()
+		}).InsertAndGetId()
 		if err != nil {
 			return err
 		}
@@ -39,9 +41,18 @@ func (gg *Game) AddFavorite(ctx context.Context, gameID, userID int64) (err erro
 		if err != nil {
 			return err
 		}
-		return nil
+
+		// 取消收藏后可以再次收藏，收藏记录ID作为去重键
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_favorite_added_%d", favoriteID), &events.FavoriteAdded{
+			GameID: gameID,
+			UserID: userID,
+		})
 	})
+	if err != nil {
+		return
+	}
 
+	service.Outbox().WakeUp()
 	return
 }
 
@@ -62,6 +73,14 @@ func (gg *Game) RemoveFavorite(ctx context.Context, gameID, userID int64) (err e
 
 	// 删除收藏记录
 	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		favoriteID, err := dao.GameFavorite.Ctx(ctx).TX(tx).
+			Where(dao.GameFavorite.Columns().GameID, gameID).
+			Where(dao.GameFavorite.Columns().UserID, userID).
+			Value(dao.GameFavorite.Columns().ID)
+		if err != nil {
+			return err
+		}
+
 		_, err = dao.GameFavorite.Ctx(ctx).TX(tx).
 			Where(dao.GameFavorite.Columns().GameID, gameID).
 			Where(dao.GameFavorite.Columns().UserID, userID).Delete()
@@ -73,9 +92,19 @@ func (gg *Game) RemoveFavorite(ctx context.Context, gameID, userID int64) (err e
 		if err != nil {
 			return err
 		}
-		return nil
+
+		// 与收藏事件使用同一条收藏记录的ID
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_favorite_removed_%d", favoriteID.Int64()), &events.FavoriteRemoved{
+			GameID: gameID,
+			UserID: userID,
+		})
 	})
-	return err
+	if err != nil {
+		return
+	}
+
+	service.Outbox().WakeUp()
+	return
 }
 
 func (gg *Game) GetUserFavorites(ctx context.Context, userID int64, pageReq *model.PageReq) (out []*model.Game, pageRes *model.PageRes, err error) {
@@ -117,3 +146,53 @@ func (gg *Game) checkFavoriteExists(ctx context.Context, gameID, userID int64) (
 	}
 	return
 }
+
+// PurgeUserData 用户注销时删除用户的收藏和评分，并扣减游戏的收藏数和评分；重复调用时没有可删除的数据
+func (gg *Game) PurgeUserData(ctx context.Context, userID int64) (err error) {
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		err := gg.purgeUserFavorites(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		return gg.purgeUserRatings(ctx, tx, userID)
+	})
+	if err != nil {
+		return
+	}
+
+	service.Outbox().WakeUp()
+	return
+}
+
+func (gg *Game) purgeUserFavorites(ctx context.Context, tx gdb.TX, userID int64) error {
+	var favorites []*entity.GameFavorite
+	err := dao.GameFavorite.Ctx(ctx).TX(tx).
+		Where(dao.GameFavorite.Columns().UserID, userID).
+		LockUpdate().
+		Scan(&favorites)
+	if err != nil {
+		return err
+	}
+
+	for _, favorite := range favorites {
+		_, err = dao.GameFavorite.Ctx(ctx).TX(tx).Where(dao.GameFavorite.Columns().ID, favorite.ID).Delete()
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.Game.Ctx(ctx).TX(tx).Where(dao.Game.Columns().ID, favorite.GameID).Decrement(dao.Game.Columns().FavoriteCount, 1)
+		if err != nil {
+			return err
+		}
+
+		// 与取消收藏使用相同的去重键
+		err = service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_favorite_removed_%d", favorite.ID), &events.FavoriteRemoved{
+			GameID: favorite.GameID,
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"GameEngine/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const maxH5LinkRedirects = 5
+
+var errH5LinkInternalAddress = errors.New("不能访问内网地址")
+
+// h5LinkClient 检查H5链接可访问性的客户端，链接由开发者填写，连接时(包括重定向)拒绝回环、内网、链路本地地址
+var h5LinkClient = newH5LinkClient()
+
+func newH5LinkClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// 不使用代理，保证连接的是链接解析出的地址
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: rejectInternalAddress,
+	}).DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxH5LinkRedirects {
+				return fmt.Errorf("重定向次数超过%d次", maxH5LinkRedirects)
+			}
+			return nil
+		},
+	}
+}
+
+// rejectInternalAddress 在建立连接前检查解析后的地址，避免通过域名解析或重定向访问内网服务
+func rejectInternalAddress(network, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return errH5LinkInternalAddress
+	}
+	return nil
+}
+
+// probeH5Links 检查H5链接的可访问性，返回链接 -> 警告信息，可以访问时为空
+// 网络请求耗时较长，需要在提交审核的事务之外执行，结果通过事件数据传给 validateH5Link
+func probeH5Links(ctx context.Context, mediaInfos []*model.GameMediaInfo) (probes map[string]string) {
+	timeout := g.Cfg().MustGet(ctx, "validation.h5Link.timeout", "5s").Duration()
+
+	probes = make(map[string]string)
+	for _, mediaInfo := range mediaInfos {
+		if mediaInfo.MediaType != model.GameMediaTypeH5Link {
+			continue
+		}
+		if _, ok := probes[mediaInfo.MediaUrl]; ok {
+			continue
+		}
+		// 格式错误的链接由 validateH5Link 报告
+		if !validH5Link(mediaInfo.MediaUrl) {
+			continue
+		}
+		probes[mediaInfo.MediaUrl] = probeH5Link(ctx, mediaInfo.MediaUrl, timeout)
+	}
+	return
+}
+
+func probeH5Link(ctx context.Context, link string, timeout time.Duration) (message string) {
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, link, nil)
+	if err != nil {
+		return fmt.Sprintf("H5链接无法访问：%v", err)
+	}
+	resp, err := h5LinkClient.Do(req)
+	if err != nil {
+		if errors.Is(err, errH5LinkInternalAddress) {
+			return "H5链接不能指向内网地址"
+		}
+		return fmt.Sprintf("H5链接无法访问：%v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Sprintf("H5链接访问返回状态码%d", resp.StatusCode)
+	}
+	return ""
+}
+
+func validH5Link(link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// h5LinkProbesFromData 从提交审核的事件数据中取出H5链接的检查结果
+func h5LinkProbesFromData(data interface{}) map[string]string {
+	m, _ := data.(map[string]interface{})
+	probes, _ := m["h5_link_probes"].(map[string]string)
+	return probes
+}
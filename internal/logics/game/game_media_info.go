@@ -4,6 +4,8 @@ import (
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
+	"GameEngine/internal/service"
 	"context"
 	"fmt"
 
@@ -13,12 +15,24 @@ import (
 
 // 游戏媒体信息相关方法
 // TODO: 游戏图标应该只有一个。
+// 线上游戏的媒体信息写入草稿，审核通过后才会覆盖线上数据
 func (gg *Game) AddMediaInfo(ctx context.Context, mediaInfo *model.GameMediaInfo) (err error) {
+	gameInfo, err := gg.GetGameByID(ctx, mediaInfo.GameID)
+	if err != nil {
+		return err
+	}
+	if model.IsGameLive(gameInfo.Status) {
+		return gg.addDraftMediaInfo(ctx, mediaInfo)
+	}
+
 	_, err = dao.GameMediaInfo.Ctx(ctx).Data(map[string]interface{}{
 		dao.GameMediaInfo.Columns().GameID:    mediaInfo.GameID,
 		dao.GameMediaInfo.Columns().FileID:    mediaInfo.FileID,
 		dao.GameMediaInfo.Columns().MediaType: mediaInfo.MediaType,
 		dao.GameMediaInfo.Columns().MediaUrl:  mediaInfo.MediaUrl,
+		dao.GameMediaInfo.Columns().FileSize:  mediaInfo.FileSize,
+		dao.GameMediaInfo.Columns().Width:     mediaInfo.Width,
+		dao.GameMediaInfo.Columns().Height:    mediaInfo.Height,
 		dao.GameMediaInfo.Columns().Status:    mediaInfo.Status,
 	}).Insert()
 
@@ -26,7 +40,16 @@ func (gg *Game) AddMediaInfo(ctx context.Context, mediaInfo *model.GameMediaInfo
 }
 
 // TODO: 先删除，再插入? 还是对比差异，只更新差异部分？
+// 线上游戏的媒体信息写入草稿，审核通过后才会覆盖线上数据
 func (gg *Game) UpdateMediaInfoByGameID(ctx context.Context, gameID int64, mediaInfos []*model.GameMediaInfo) (err error) {
+	gameInfo, err := gg.GetGameByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if model.IsGameLive(gameInfo.Status) {
+		return gg.updateDraftMediaInfoByGameID(ctx, gameID, mediaInfos)
+	}
+
 	// 1. 查询当前游戏的所有媒体文件
 	oldMediaInfos, err := gg.GetMediaInfo(ctx, gameID)
 	if err != nil {
@@ -94,10 +117,124 @@ func (gg *Game) UpdateMediaInfoByGameID(ctx context.Context, gameID int64, media
 	return
 }
 
+// UpdateMediaInfoStatusByFileID 更新媒体文件状态，文件可能属于线上数据、草稿或构建版本
+// 进入扫描中时创建内容扫描任务，扫描通过时为APK安装包创建解析任务
 func (gg *Game) UpdateMediaInfoStatusByFileID(ctx context.Context, fileID string, status model.GameMediaStatus) (err error) {
-	_, err = dao.GameMediaInfo.Ctx(ctx).Where(dao.GameMediaInfo.Columns().FileID, fileID).Data(map[string]interface{}{
-		dao.GameMediaInfo.Columns().Status: status,
-	}).Update()
+	_, err = gg.updateMediaFileStatus(ctx, fileID, nil, status, nil)
+	return
+}
+
+// ApplyUploadResult 回写文件上传结果，只更新仍处于初始化状态的文件：上传成功进入扫描中，失败标记为上传失败
+// 客户端上报和文件引擎的上传结果消息都会调用，重复或迟到的结果不会覆盖扫描中及之后的状态
+func (gg *Game) ApplyUploadResult(ctx context.Context, fileID string, success bool) (applied bool, err error) {
+	status := model.GameMediaStatusScanning
+	if !success {
+		status = model.GameMediaStatusFailed
+	}
+	from := model.GameMediaStatusInit
+	return gg.updateMediaFileStatus(ctx, fileID, &from, status, nil)
+}
+
+// HandleFileUploadResult 处理文件引擎的上传结果消息
+func (gg *Game) HandleFileUploadResult(ctx context.Context, e *model.FileUploadResultEvent) (err error) {
+	applied, err := gg.ApplyUploadResult(ctx, e.FileID, e.Success)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		g.Log().Infof(ctx, "upload result of file %s ignored, no file waiting for upload", e.FileID)
+	}
+	return nil
+}
+
+// updateMediaFileStatus 更新媒体文件状态，from 不为空时只更新处于该状态的文件，没有文件被更新时不创建任务和事件
+// 内容扫描完成时(scanResult 不为空)同时写入扫描完成事件
+func (gg *Game) updateMediaFileStatus(ctx context.Context, fileID string, from *model.GameMediaStatus, status model.GameMediaStatus, scanResult *model.ContentScanResult) (updated bool, err error) {
+	var wakeUpTasks []model.AsyncTaskType
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		updated = false
+
+		mediaInfoModel := dao.GameMediaInfo.Ctx(ctx).TX(tx).Where(dao.GameMediaInfo.Columns().FileID, fileID)
+		draftMediaInfoModel := dao.GameDraftMediaInfo.Ctx(ctx).TX(tx).Where(dao.GameDraftMediaInfo.Columns().FileID, fileID)
+		buildModel := dao.GameBuild.Ctx(ctx).TX(tx).Where(dao.GameBuild.Columns().FileID, fileID)
+		if from != nil {
+			mediaInfoModel = mediaInfoModel.Where(dao.GameMediaInfo.Columns().Status, *from)
+			draftMediaInfoModel = draftMediaInfoModel.Where(dao.GameDraftMediaInfo.Columns().Status, *from)
+			buildModel = buildModel.Where(dao.GameBuild.Columns().FileStatus, *from)
+		}
+
+		result, err := mediaInfoModel.Data(map[string]interface{}{
+			dao.GameMediaInfo.Columns().Status: status,
+		}).Update()
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+			updated = true
+		}
+
+		result, err = draftMediaInfoModel.Data(map[string]interface{}{
+			dao.GameDraftMediaInfo.Columns().Status: status,
+		}).Update()
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+			updated = true
+		}
+
+		result, err = buildModel.Data(map[string]interface{}{
+			dao.GameBuild.Columns().FileStatus: status,
+		}).Update()
+		if err != nil {
+			return err
+		}
+		if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+			updated = true
+		}
+		if from != nil && !updated {
+			return nil
+		}
+
+		switch status {
+		case model.GameMediaStatusScanning:
+			err = addContentScanTask(ctx, tx, fileID)
+			if err != nil {
+				return err
+			}
+			wakeUpTasks = append(wakeUpTasks, model.AsyncTaskTypeMediaContentScan)
+		case model.GameMediaStatusClean:
+			added, err := addApkParseTask(ctx, tx, fileID)
+			if err != nil {
+				return err
+			}
+			if added {
+				wakeUpTasks = append(wakeUpTasks, model.AsyncTaskTypeGameApkParse)
+			}
+		}
+
+		if scanResult == nil {
+			return nil
+		}
+		// 每个文件只扫描完成一次(扫描通过或存在风险后不再扫描)
+		return service.Outbox().AddEvent(ctx, tx, "media_scanned_"+fileIDDigest(fileID), &events.MediaScanned{
+			FileID:    fileID,
+			Status:    status,
+			Infected:  scanResult.Infected,
+			Engine:    scanResult.Engine,
+			Signature: scanResult.Signature,
+		})
+	})
+	if err != nil {
+		return
+	}
+
+	for _, taskType := range wakeUpTasks {
+		service.AsyncTask().WakeUp(taskType)
+	}
+	if scanResult != nil {
+		service.Outbox().WakeUp()
+	}
 	return
 }
 
@@ -114,71 +251,84 @@ func (gg *Game) GetMediaInfo(ctx context.Context, gameID int64) (out []*model.Ga
 }
 
 func (gg *Game) CheckMediaInfo(ctx context.Context, gameInfo *model.Game) (err error) {
-	var exists bool
-	exists, err = dao.GameMediaInfo.Ctx(ctx).
-		Where(dao.GameMediaInfo.Columns().GameID, gameInfo.ID).
-		Where(dao.GameMediaInfo.Columns().MediaType, model.GameMediaTypeIcon).
-		Exist()
+	mediaInfos, err := gg.GetMediaInfo(ctx, gameInfo.ID)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return fmt.Errorf("图标媒体文件不存在")
-	}
 
-	exists, err = dao.GameMediaInfo.Ctx(ctx).
-		Where(dao.GameMediaInfo.Columns().GameID, gameInfo.ID).
-		Where(dao.GameMediaInfo.Columns().MediaType, model.GameMediaTypeScreenshot).
-		Exist()
-	if err != nil {
-		return err
+	missing := missingRequiredMedia(gameInfo.DistributeType, mediaInfos)
+	if len(missing) > 0 {
+		return fmt.Errorf("%s媒体文件不存在", model.GetGameMediaTypeText(missing[0]))
 	}
-	if !exists {
-		return fmt.Errorf("截图媒体文件不存在")
+	notClean := notCleanMedia(mediaInfos)
+	if len(notClean) > 0 {
+		return fmt.Errorf("%s媒体文件%s", model.GetGameMediaTypeText(notClean[0].MediaType), notCleanReason(notClean[0].Status))
 	}
+	return nil
+}
 
-	exists, err = dao.GameMediaInfo.Ctx(ctx).
-		Where(dao.GameMediaInfo.Columns().GameID, gameInfo.ID).
-		Where(dao.GameMediaInfo.Columns().MediaType, model.GameMediaTypeVideo).
-		Exist()
-	if err != nil {
-		return err
+// notCleanMedia 返回未通过内容安全扫描的媒体文件，H5链接不是文件，不需要扫描
+func notCleanMedia(mediaInfos []*model.GameMediaInfo) (out []*model.GameMediaInfo) {
+	for _, mediaInfo := range mediaInfos {
+		if mediaInfo.MediaType == model.GameMediaTypeH5Link {
+			continue
+		}
+		if mediaInfo.Status != model.GameMediaStatusClean {
+			out = append(out, mediaInfo)
+		}
 	}
-	if !exists {
-		return fmt.Errorf("视频媒体文件不存在")
+	return
+}
+
+func notCleanReason(status model.GameMediaStatus) string {
+	switch status {
+	case model.GameMediaStatusInit, model.GameMediaStatusFailed:
+		return "未上传成功"
+	case model.GameMediaStatusSuccess:
+		return "未进行安全扫描，请联系管理员重新扫描"
+	case model.GameMediaStatusScanning:
+		return "正在进行安全扫描，请稍后再提交"
+	case model.GameMediaStatusInfected:
+		return "存在安全风险，已被删除，请重新上传"
+	default:
+		return "未通过安全扫描"
 	}
-	if !exists {
-		return fmt.Errorf("视频媒体文件不存在")
+}
+
+// missingRequiredMedia 返回缺少的必要媒体类型，线上数据和草稿共用
+func missingRequiredMedia(distributeType model.GameDistributeType, mediaInfos []*model.GameMediaInfo) (missing []model.GameMediaType) {
+	mediaTypes := make(map[model.GameMediaType]bool, len(mediaInfos))
+	for _, mediaInfo := range mediaInfos {
+		mediaTypes[mediaInfo.MediaType] = true
 	}
 
-	switch gameInfo.DistributeType {
+	required := []model.GameMediaType{model.GameMediaTypeIcon, model.GameMediaTypeScreenshot, model.GameMediaTypeVideo}
+	switch distributeType {
 	case model.GameDistributeTypeAPK:
-		exists, err = dao.GameMediaInfo.Ctx(ctx).
-			Where(dao.GameMediaInfo.Columns().GameID, gameInfo.ID).
-			Where(dao.GameMediaInfo.Columns().MediaType, model.GameMediaTypeApkFile).
-			Exist()
-		if !exists {
-			return fmt.Errorf("APK媒体文件不存在")
-		}
+		required = append(required, model.GameMediaTypeApkFile)
 	case model.GameDistributeTypeLink:
-		exists, err = dao.GameMediaInfo.Ctx(ctx).
-			Where(dao.GameMediaInfo.Columns().GameID, gameInfo.ID).
-			Where(dao.GameMediaInfo.Columns().MediaType, model.GameMediaTypeH5Link).
-			Exist()
-		if err != nil {
-			return err
-		}
-		if !exists {
-			return fmt.Errorf("H5链接媒体文件不存在")
-		}
+		required = append(required, model.GameMediaTypeH5Link)
 	}
 
-	return nil
+	for _, mediaType := range required {
+		if !mediaTypes[mediaType] {
+			missing = append(missing, mediaType)
+		}
+	}
+	return
 }
 
-// SetH5Link 设置H5链接
+// SetH5Link 设置H5链接，线上游戏写入草稿
 func (gg *Game) SetH5Link(ctx context.Context, gameID int64, link string) error {
-	_, err := dao.GameMediaInfo.Ctx(ctx).
+	gameInfo, err := gg.GetGameByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if model.IsGameLive(gameInfo.Status) {
+		return gg.setDraftH5Link(ctx, gameID, link)
+	}
+
+	_, err = dao.GameMediaInfo.Ctx(ctx).
 		Data(map[string]interface{}{
 			dao.GameMediaInfo.Columns().GameID:    gameID,
 			dao.GameMediaInfo.Columns().FileID:    "",
@@ -196,6 +346,9 @@ func (gg *Game) convertMediaInfoEntityToModel(in *entity.GameMediaInfo) (out *mo
 		FileID:    in.FileID,
 		MediaType: model.GameMediaType(in.MediaType),
 		MediaUrl:  in.MediaUrl,
+		FileSize:  in.FileSize,
+		Width:     in.Width,
+		Height:    in.Height,
 		Status:    model.GameMediaStatus(in.Status),
 	}
 	return
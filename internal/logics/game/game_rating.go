@@ -2,6 +2,9 @@ package game
 
 import (
 	"GameEngine/internal/dao"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
+	"GameEngine/internal/service"
 	"context"
 	"fmt"
 
@@ -39,9 +42,18 @@ func (gg *Game) AddRating(ctx context.Context, gameID, userID int64, score int)
 			return err
 		}
 
-		return nil
+		// 每个用户对每个游戏只能评分一次
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_rating_added_%d_%d", gameID, userID), &events.RatingAdded{
+			GameID: gameID,
+			UserID: userID,
+			Score:  score,
+		})
 	})
+	if err != nil {
+		return
+	}
 
+	service.Outbox().WakeUp()
 	return
 }
 
@@ -58,3 +70,31 @@ func (gg *Game) assertRatingNotExists(ctx context.Context, gameID, userID int64)
 	}
 	return nil
 }
+
+// purgeUserRatings 删除用户的评分，并从游戏的总分和评分人数中扣除
+func (gg *Game) purgeUserRatings(ctx context.Context, tx gdb.TX, userID int64) error {
+	var ratings []*entity.GameRating
+	err := dao.GameRating.Ctx(ctx).TX(tx).
+		Where(dao.GameRating.Columns().UserID, userID).
+		LockUpdate().
+		Scan(&ratings)
+	if err != nil {
+		return err
+	}
+
+	for _, rating := range ratings {
+		_, err = dao.GameRating.Ctx(ctx).TX(tx).Where(dao.GameRating.Columns().ID, rating.ID).Delete()
+		if err != nil {
+			return err
+		}
+
+		_, err = dao.Game.Ctx(ctx).TX(tx).Where(dao.Game.Columns().ID, rating.GameID).Data(map[string]interface{}{
+			dao.Game.Columns().RatingScore: gdb.Raw(fmt.Sprintf("%s - %d", dao.Game.Columns().RatingScore, rating.Score)),
+			dao.Game.Columns().RatingCount: gdb.Raw(fmt.Sprintf("%s - 1", dao.Game.Columns().RatingCount)),
+		}).Update()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
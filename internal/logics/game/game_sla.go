@@ -0,0 +1,269 @@
+package game
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	defaultSLAAlertTopic = "core.alert.game-sla"
+	defaultSLAStatsDays  = 30
+)
+
+// slaStatuses 支持配置SLA的状态
+var slaStatuses = []model.GameStatus{
+	model.GameStatusInit,
+	model.GameStatusInReview,
+	model.GameStatusApproved,
+	model.GameStatusPreRegister,
+	model.GameStatusPublished,
+	model.GameStatusUnpublished,
+}
+
+// recordStatusPeriod 记录游戏离开旧状态、进入新状态的时间，需要与状态更新处于同一事务中
+func recordStatusPeriod(ctx context.Context, gameID int64, fromStatus, toStatus model.GameStatus) (err error) {
+	if fromStatus == toStatus {
+		return nil
+	}
+
+	now := gtime.Now()
+	var open entity.GameStatusPeriod
+	err = dao.GameStatusPeriod.Ctx(ctx).
+		Where(dao.GameStatusPeriod.Columns().GameID, gameID).
+		WhereNull(dao.GameStatusPeriod.Columns().LeaveTime).
+		Scan(&open)
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+	// 功能上线前创建的游戏没有停留区间记录，从本次状态变更开始统计
+	if err == nil {
+		_, err = dao.GameStatusPeriod.Ctx(ctx).
+			Where(dao.GameStatusPeriod.Columns().ID, open.ID).
+			Data(map[string]interface{}{
+				dao.GameStatusPeriod.Columns().LeaveTime: now,
+				dao.GameStatusPeriod.Columns().Duration:  int64(now.Sub(open.EnterTime).Seconds()),
+			}).Update()
+		if err != nil {
+			return
+		}
+	}
+
+	return openStatusPeriod(ctx, gameID, toStatus)
+}
+
+// openStatusPeriod 记录游戏进入新状态，创建游戏时直接调用
+func openStatusPeriod(ctx context.Context, gameID int64, status model.GameStatus) (err error) {
+	_, err = dao.GameStatusPeriod.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameStatusPeriod.Columns().GameID:    gameID,
+		dao.GameStatusPeriod.Columns().Status:    int(status),
+		dao.GameStatusPeriod.Columns().EnterTime: gtime.Now(),
+	}).Insert()
+	return
+}
+
+// GetSLAStats 统计状态的停留时长
+// status 为 nil 时统计所有配置了SLA的状态以及审核中状态；days 为统计窗口(天)，只统计窗口内离开该状态的记录
+func (gg *Game) GetSLAStats(ctx context.Context, status *model.GameStatus, days int) (outs []*model.GameSLAStats, err error) {
+	if days <= 0 {
+		days = defaultSLAStatsDays
+	}
+
+	statuses := make([]model.GameStatus, 0)
+	if status != nil {
+		statuses = append(statuses, *status)
+	} else {
+		for _, s := range slaStatuses {
+			if s == model.GameStatusInReview || slaOf(ctx, s) > 0 {
+				statuses = append(statuses, s)
+			}
+		}
+	}
+
+	since := gtime.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	for _, s := range statuses {
+		stats := &model.GameSLAStats{
+			Status:       s,
+			SLA:          int64(slaOf(ctx, s).Seconds()),
+			OverdueGames: make([]*model.GameSLAOverdue, 0),
+		}
+
+		stats.QueueDepth, err = dao.Game.Ctx(ctx).Where(dao.Game.Columns().Status, int(s)).Count()
+		if err != nil {
+			return nil, err
+		}
+
+		durations, err := dao.GameStatusPeriod.Ctx(ctx).
+			Where(dao.GameStatusPeriod.Columns().Status, int(s)).
+			WhereGTE(dao.GameStatusPeriod.Columns().LeaveTime, since).
+			Array(dao.GameStatusPeriod.Columns().Duration)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]int64, 0, len(durations))
+		for _, duration := range durations {
+			values = append(values, duration.Int64())
+		}
+		stats.CompletedCount = len(values)
+		stats.MedianDuration = percentile(values, 50)
+		stats.P95Duration = percentile(values, 95)
+
+		if stats.SLA > 0 {
+			stats.OverdueGames, err = findOverdueGames(ctx, s, slaOf(ctx, s), false)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		outs = append(outs, stats)
+	}
+	return
+}
+
+// HandleSLACheck 检查停留时间超过SLA的游戏，通过消息队列发送告警，由周期任务定期调用
+// 每个停留区间只告警一次
+func (gg *Game) HandleSLACheck(ctx context.Context) (err error) {
+	overdueGames := make([]*model.GameSLAOverdue, 0)
+	for _, status := range slaStatuses {
+		sla := slaOf(ctx, status)
+		if sla <= 0 {
+			continue
+		}
+		games, err := findOverdueGames(ctx, status, sla, true)
+		if err != nil {
+			return err
+		}
+		overdueGames = append(overdueGames, games...)
+	}
+
+	if len(overdueGames) == 0 {
+		return nil
+	}
+	err = publishSLAAlert(ctx, overdueGames)
+	if err != nil {
+		return fmt.Errorf("发送SLA超时告警失败: %v", err)
+	}
+	return nil
+}
+
+// findOverdueGames 查询停留时间超过SLA的游戏，onlyNotAlerted 为 true 时只返回未告警的
+func findOverdueGames(ctx context.Context, status model.GameStatus, sla time.Duration, onlyNotAlerted bool) (outs []*model.GameSLAOverdue, err error) {
+	now := gtime.Now()
+	query := dao.GameStatusPeriod.Ctx(ctx).
+		Where(dao.GameStatusPeriod.Columns().Status, int(status)).
+		WhereNull(dao.GameStatusPeriod.Columns().LeaveTime).
+		WhereLTE(dao.GameStatusPeriod.Columns().EnterTime, now.Add(-sla))
+	if onlyNotAlerted {
+		query = query.Where(dao.GameStatusPeriod.Columns().OverdueAlerted, 0)
+	}
+
+	var periods []*entity.GameStatusPeriod
+	err = query.OrderAsc(dao.GameStatusPeriod.Columns().EnterTime).Scan(&periods)
+	if err != nil {
+		return
+	}
+	if len(periods) == 0 {
+		return make([]*model.GameSLAOverdue, 0), nil
+	}
+
+	gameIDs := make([]int64, 0, len(periods))
+	for _, period := range periods {
+		gameIDs = append(gameIDs, period.GameID)
+	}
+	gameNames, err := dao.Game.Ctx(ctx).
+		WhereIn(dao.Game.Columns().ID, gameIDs).
+		Fields(dao.Game.Columns().ID, dao.Game.Columns().Name).
+		All()
+	if err != nil {
+		return
+	}
+	nameMap := make(map[int64]string, len(gameNames))
+	for _, record := range gameNames {
+		nameMap[record[dao.Game.Columns().ID].Int64()] = record[dao.Game.Columns().Name].String()
+	}
+
+	for _, period := range periods {
+		outs = append(outs, &model.GameSLAOverdue{
+			GameID:    period.GameID,
+			GameName:  nameMap[period.GameID],
+			Status:    status,
+			EnterTime: period.EnterTime,
+			Elapsed:   int64(now.Sub(period.EnterTime).Seconds()),
+			SLA:       int64(sla.Seconds()),
+		})
+	}
+	return
+}
+
+// publishSLAAlert 发送超时告警，并标记对应的停留区间已告警
+func publishSLAAlert(ctx context.Context, overdueGames []*model.GameSLAOverdue) (err error) {
+	games := make([]map[string]interface{}, 0, len(overdueGames))
+	gameIDsByStatus := make(map[model.GameStatus][]int64)
+	for _, overdue := range overdueGames {
+		games = append(games, map[string]interface{}{
+			"game_id":     overdue.GameID,
+			"game_name":   overdue.GameName,
+			"status":      overdue.Status,
+			"status_name": model.GetGameStatusText(overdue.Status),
+			"enter_time":  overdue.EnterTime.String(),
+			"elapsed":     overdue.Elapsed,
+			"sla":         overdue.SLA,
+		})
+		gameIDsByStatus[overdue.Status] = append(gameIDsByStatus[overdue.Status], overdue.GameID)
+	}
+
+	body := map[string]interface{}{
+		"title": "游戏状态停留超过SLA",
+		"games": games,
+	}
+	topic := g.Cfg().MustGet(ctx, "sla.alertTopic", defaultSLAAlertTopic).String()
+	err = service.MQ().Publish(ctx, topic, body)
+	if err != nil {
+		return
+	}
+
+	for status, gameIDs := range gameIDsByStatus {
+		_, err = dao.GameStatusPeriod.Ctx(ctx).
+			Where(dao.GameStatusPeriod.Columns().Status, int(status)).
+			WhereIn(dao.GameStatusPeriod.Columns().GameID, gameIDs).
+			WhereNull(dao.GameStatusPeriod.Columns().LeaveTime).
+			Data(map[string]interface{}{
+				dao.GameStatusPeriod.Columns().OverdueAlerted: 1,
+			}).Update()
+		if err != nil {
+			return
+		}
+	}
+
+	g.Log().Warningf(ctx, "[SLA] 游戏状态停留超过SLA，已发送告警: count=%d", len(overdueGames))
+	return nil
+}
+
+// slaOf 获取状态配置的SLA，未配置时返回 0
+func slaOf(ctx context.Context, status model.GameStatus) time.Duration {
+	return g.Cfg().MustGet(ctx, "sla.status."+model.GetGameStatusKey(status)).Duration()
+}
+
+// percentile 计算百分位数(最近秩法)
+func percentile(values []int64, p int) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64{}, values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
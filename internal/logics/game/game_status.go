@@ -6,7 +6,6 @@ import (
 	"GameEngine/internal/model/entity"
 	"GameEngine/internal/service"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -25,6 +24,7 @@ type StateAction func(ctx context.Context, game *model.Game, data interface{}) (
 type Transition struct {
 	TargetStatus model.GameStatus
 	Action       StateAction
+	WakeUpTasks  []model.AsyncTaskType // 状态变更事务提交后需要唤醒的异步任务
 }
 
 // 状态转移定义
@@ -52,10 +52,12 @@ var stateTransitionMap = map[model.GameStatus]map[model.GameEvent]*Transition{
 		model.PreRegister: {
 			TargetStatus: model.GameStatusPreRegister,
 			Action:       handlePreRegister, // 由 handlePreRegister 处理
+			WakeUpTasks:  []model.AsyncTaskType{model.AsyncTaskTypeGameAutoPublish},
 		},
 		model.PublishNow: {
 			TargetStatus: model.GameStatusPublished,
 			Action:       handlePublished, // 由 handlePublished 处理
+			WakeUpTasks:  []model.AsyncTaskType{model.AsyncTaskTypeGameNotifyReservedUsers},
 		},
 		model.UpdateInfo: {
 			TargetStatus: model.GameStatusInit,
@@ -67,14 +69,27 @@ var stateTransitionMap = map[model.GameStatus]map[model.GameEvent]*Transition{
 		model.AutoPublish: {
 			TargetStatus: model.GameStatusPublished,
 			Action:       handlePublished, // 由 handlePublished 处理
+			WakeUpTasks:  []model.AsyncTaskType{model.AsyncTaskTypeGameNotifyReservedUsers},
 		},
 		model.CancelPreRegister: {
 			TargetStatus: model.GameStatusApproved,
 			Action:       handleCancelPreRegister, // 取消预约发布，回到审核通过状态
 		},
 		model.UpdateInfo: {
-			TargetStatus: model.GameStatusInit,
-			Action:       handleUpdateInfo, // 修改信息，回到初始状态
+			TargetStatus: model.GameStatusPreRegister,
+			Action:       handleOpenDraft, // 修改信息，写入草稿，线上数据不受影响
+		},
+		model.SubmitDraftForReview: {
+			TargetStatus: model.GameStatusPreRegister,
+			Action:       handleSubmitDraftForReview,
+		},
+		model.ApproveDraft: {
+			TargetStatus: model.GameStatusPreRegister,
+			Action:       handleApproveDraft, // 草稿审核通过，覆盖线上数据
+		},
+		model.RejectDraft: {
+			TargetStatus: model.GameStatusPreRegister,
+			Action:       handleRejectDraft,
 		},
 	},
 	// Published(已上架)
@@ -83,26 +98,98 @@ var stateTransitionMap = map[model.GameStatus]map[model.GameEvent]*Transition{
 			TargetStatus: model.GameStatusUnpublished,
 			Action:       handleUnpublished, // 由 handleUnpublished 处理
 		},
+		model.UpdateInfo: {
+			TargetStatus: model.GameStatusPublished,
+			Action:       handleOpenDraft, // 修改信息，写入草稿，线上数据不受影响
+		},
 		model.UpdateVersion: {
-			TargetStatus: model.GameStatusInit,
-			Action:       handleUpdateVersion, // 更新版本，回到初始状态
+			TargetStatus: model.GameStatusPublished,
+			Action:       handleOpenDraft, // 更新版本，写入草稿，线上数据不受影响
+		},
+		model.SubmitDraftForReview: {
+			TargetStatus: model.GameStatusPublished,
+			Action:       handleSubmitDraftForReview,
+		},
+		model.ApproveDraft: {
+			TargetStatus: model.GameStatusPublished,
+			Action:       handleApproveDraft, // 草稿审核通过，覆盖线上数据
+		},
+		model.RejectDraft: {
+			TargetStatus: model.GameStatusPublished,
+			Action:       handleRejectDraft,
 		},
 	},
 }
 
-// SubmitForReview 提交审核
+// SubmitForReview 提交审核，H5链接的可访问性在事务之外检查
 func (gg *Game) SubmitForReview(ctx context.Context, id int64) (err error) {
-	return gg.HandleGameEvent(ctx, id, model.SubmitForReview, nil)
+	mediaInfos, err := gg.GetMediaInfo(ctx, id)
+	if err != nil {
+		return err
+	}
+	data := map[string]interface{}{
+		"h5_link_probes": probeH5Links(ctx, mediaInfos),
+	}
+	return gg.HandleGameEvent(ctx, id, model.SubmitForReview, data)
 }
 
-// Approve 审核通过
-func (gg *Game) Approve(ctx context.Context, id int64) (err error) {
-	return gg.HandleGameEvent(ctx, id, model.Approve, nil)
+// Approve 审核通过，需要持有审核任务
+// 还需要其他审核人员通过时(双人审核)，只记录本次审核通过并释放审核任务，游戏保持审核中
+func (gg *Game) Approve(ctx context.Context, id int64) (pendingApprovals int, err error) {
+	_, err = service.Review().AssertClaimHolder(ctx, id)
+	if err != nil {
+		return
+	}
+
+	gameInfo, err := gg.GetGameByID(ctx, id)
+	if err != nil {
+		return
+	}
+	if gameInfo.Status != model.GameStatusInReview {
+		return 0, fmt.Errorf("游戏当前状态(%s)不允许审核通过", model.GetGameStatusText(gameInfo.Status))
+	}
+
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		pendingApprovals, err = service.Review().AddApproval(ctx, gameInfo)
+		if err != nil {
+			return err
+		}
+		if pendingApprovals > 0 {
+			return service.Review().ReleaseClaim(ctx, id)
+		}
+		return gg.HandleGameEvent(ctx, id, model.Approve, nil)
+	})
+	return
 }
 
-// Reject 审核拒绝
-func (gg *Game) Reject(ctx context.Context, id int64) (err error) {
-	return gg.HandleGameEvent(ctx, id, model.Reject, nil)
+// Reject 审核拒绝，记录结构化的审核问题，并通知开发者
+func (gg *Game) Reject(ctx context.Context, id int64, reason string, issues []*model.ReviewIssue) (err error) {
+	if reason == "" && len(issues) == 0 {
+		return fmt.Errorf("拒绝原因和审核问题不能同时为空")
+	}
+	_, err = service.Review().AssertClaimHolder(ctx, id)
+	if err != nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"reason": reason,
+		"issues": issues,
+	}
+	err = gg.HandleGameEvent(ctx, id, model.Reject, data)
+	if err != nil {
+		return
+	}
+
+	gameInfo, err := gg.GetGameByID(ctx, id)
+	if err != nil {
+		return
+	}
+	// 通知失败不影响审核结果，开发者仍可在审核问题列表中查看
+	if err := service.Review().NotifyRejected(ctx, gameInfo, reason, issues); err != nil {
+		g.Log().Errorf(ctx, "[Review] 审核拒绝通知发送失败: game_id=%d, err=%v", id, err)
+	}
+	return nil
 }
 
 // PreRegisterGame 预约发布游戏
@@ -125,16 +212,19 @@ func (gg *Game) CancelPreRegisterGame(ctx context.Context, gameID int64) error {
 
 // UnpublishGame 下架游戏
 func (gg *Game) UnpublishGame(ctx context.Context, id int64, unpublishReason string) (err error) {
-	// TODO: 可以在这里记录下架原因到数据库
-	return gg.HandleGameEvent(ctx, id, model.UnpublishNow, nil)
+	data := map[string]interface{}{
+		"reason": unpublishReason,
+	}
+	return gg.HandleGameEvent(ctx, id, model.UnpublishNow, data)
 }
 
-// UpdateGameInfo 更新游戏信息（需要回到初始状态）
+// UpdateGameInfo 更新游戏信息
+// 审核通过的游戏回到初始状态；可预约/已上架的游戏开启草稿，线上数据不受影响
 func (gg *Game) UpdateGameInfo(ctx context.Context, gameID int64) error {
 	return gg.HandleGameEvent(ctx, gameID, model.UpdateInfo, nil)
 }
 
-// UpdateGameVersion 更新游戏版本（需要回到初始状态）
+// UpdateGameVersion 更新游戏版本，已上架的游戏开启草稿，线上数据不受影响
 func (gg *Game) UpdateGameVersion(ctx context.Context, gameID int64) error {
 	return gg.HandleGameEvent(ctx, gameID, model.UpdateVersion, nil)
 }
@@ -173,19 +263,27 @@ func (gg *Game) ListInReview(ctx context.Context, pageReq *model.PageReq) (out [
 
 // 提交审核处理
 func handleSubmitForReview(ctx context.Context, gameInfo *model.Game, data interface{}) error {
-	// 验证必要信息
-	if gameInfo.Name == "" || gameInfo.Developer == "" || gameInfo.Publisher == "" {
-		return fmt.Errorf("游戏基本信息不完整，无法提交审核")
+	mediaInfos, err := service.Game().GetMediaInfo(ctx, gameInfo.ID)
+	if err != nil {
+		return err
 	}
 
-	// 检查媒体文件是否上传
-	err := service.Game().CheckMediaInfo(ctx, gameInfo)
+	// 执行提交审核检查，存在阻断性问题时一次性返回全部问题
+	err = validateForReview(ctx, &model.ValidationSubject{
+		GameID:         gameInfo.ID,
+		Name:           gameInfo.Name,
+		DistributeType: gameInfo.DistributeType,
+		Developer:      gameInfo.Developer,
+		Publisher:      gameInfo.Publisher,
+		Description:    gameInfo.Description,
+		Details:        gameInfo.Details,
+		MediaInfos:     mediaInfos,
+		H5LinkProbes:   h5LinkProbesFromData(data),
+	})
 	if err != nil {
 		return err
 	}
 
-	// 可以在这里添加其他审核前的验证逻辑
-	// 比如检查游戏描述长度、标签数量等
 	updateData := map[string]interface{}{
 		dao.Game.Columns().Status: int(model.GameStatusInReview),
 	}
@@ -230,7 +328,18 @@ func handleApproved(ctx context.Context, gameInfo *model.Game, data interface{})
 		return ErrConcurrentUpdate
 	}
 
-	return nil
+	// 审核通过的安装包作为后续版本的比对基准
+	mediaInfos, err := service.Game().GetMediaInfo(ctx, gameInfo.ID)
+	if err != nil {
+		return err
+	}
+	err = markApkApproved(ctx, gameInfo.ID, apkFileIDs(mediaInfos)...)
+	if err != nil {
+		return err
+	}
+
+	// 审核结束，清理领取记录
+	return service.Review().ClearReviewState(ctx, gameInfo.ID)
 }
 
 // 审核拒绝处理
@@ -255,7 +364,15 @@ func handleReject(ctx context.Context, gameInfo *model.Game, data interface{}) e
 		return ErrConcurrentUpdate
 	}
 
-	return nil
+	// 审核结束，清理领取记录和审核通过记录
+	err = service.Review().ClearReviewState(ctx, gameInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	// 记录审核问题
+	issues, _ := data.(map[string]interface{})["issues"].([]*model.ReviewIssue)
+	return service.Review().AddIssues(ctx, gameInfo.ID, issues)
 }
 
 // 预约发布
@@ -292,11 +409,10 @@ func handlePreRegister(ctx context.Context, gameInfo *model.Game, data interface
 		}
 
 		// 任务内容
-		taskContent := map[string]interface{}{
-			"game_id":      gameInfo.ID,
-			"publish_time": publishTime,
-		}
-		contentBytes, err := json.Marshal(taskContent)
+		contentBytes, err := model.MarshalAsyncTaskPayload(&model.GameAutoPublishTask{
+			GameID:      gameInfo.ID,
+			PublishTime: publishTime,
+		})
 		if err != nil {
 			return fmt.Errorf("序列化任务内容失败: %v", err)
 		}
@@ -305,7 +421,8 @@ func handlePreRegister(ctx context.Context, gameInfo *model.Game, data interface
 		g.Log().Infof(ctx, "创建定时发布任务: gameID=%d, publishTime=%s (local), publishTimeUTC=%s",
 			gameInfo.ID, publishTime.Format("2006-01-02 15:04:05"), publishTime.UTC().Format("2006-01-02 15:04:05"))
 
-		err = service.AsyncTask().AddScheduledTask(ctx, tx, model.AsyncTaskTypeGameAutoPublish, "", contentBytes, publishTime)
+		// 重复预约时替换已有的自动发布任务
+		err = service.AsyncTask().Upsert(ctx, tx, model.AsyncTaskTypeGameAutoPublish, gameAutoPublishCustomID(gameInfo.ID), contentBytes, publishTime)
 		if err != nil {
 			return fmt.Errorf("添加自动发布任务失败: %v", err)
 		}
@@ -314,8 +431,6 @@ func handlePreRegister(ctx context.Context, gameInfo *model.Game, data interface
 		return nil
 	})
 
-	service.AsyncTask().WakeUp(model.AsyncTaskTypeGameAutoPublish)
-
 	return err
 }
 
@@ -345,15 +460,15 @@ func handlePublished(ctx context.Context, gameInfo *model.Game, data interface{}
 		}
 
 		// 添加通知预约用户任务
-		taskContent := map[string]interface{}{
-			"game_id": gameInfo.ID,
-		}
-		contentBytes, err := json.Marshal(taskContent)
+		contentBytes, err := model.MarshalAsyncTaskPayload(&model.GameNotifyReservedUsersTask{
+			GameID: gameInfo.ID,
+		})
 		if err != nil {
 			return fmt.Errorf("序列化任务内容失败: %v", err)
 		}
 
-		err = service.AsyncTask().AddTask(ctx, tx, model.AsyncTaskTypeGameNotifyReservedUsers, "", contentBytes)
+		// 下架后重新上架时，未完成的通知任务不重复添加
+		err = service.AsyncTask().Upsert(ctx, tx, model.AsyncTaskTypeGameNotifyReservedUsers, fmt.Sprintf("game_notify_reserved_users_%d", gameInfo.ID), contentBytes, nil)
 		if err != nil {
 			return fmt.Errorf("添加通知预约用户任务失败: %v", err)
 		}
@@ -361,8 +476,6 @@ func handlePublished(ctx context.Context, gameInfo *model.Game, data interface{}
 		return nil
 	})
 
-	service.AsyncTask().WakeUp(model.AsyncTaskTypeGameNotifyReservedUsers)
-
 	return err
 }
 
@@ -411,16 +524,19 @@ func handleCancelPreRegister(ctx context.Context, gameInfo *model.Game, data int
 			return ErrConcurrentUpdate
 		}
 
-		// 删除对应的自动发布任务
-		customID := fmt.Sprintf("game_auto_publish_%d", gameInfo.ID)
-		_, err = dao.AsyncTask.Ctx(ctx).TX(tx).
-			Where(dao.AsyncTask.Columns().CustomID, customID).
-			Where(dao.AsyncTask.Columns().TaskType, model.AsyncTaskTypeGameAutoPublish).
-			Where(dao.AsyncTask.Columns().Status, model.AsyncTaskStatusPending).
-			Delete()
+		// 游戏不再处于线上状态，丢弃未完成的草稿
+		err = removeDraft(ctx, gameInfo.ID)
+		if err != nil {
+			return err
+		}
+
+		// 取消对应的自动发布任务
+		canceled, err := service.AsyncTask().CancelByKey(ctx, tx, model.AsyncTaskTypeGameAutoPublish, gameAutoPublishCustomID(gameInfo.ID))
 		if err != nil {
-			g.Log().Errorf(ctx, "删除自动发布任务失败: gameID=%d, customID=%s, error=%v",
-				gameInfo.ID, customID, err)
+			return fmt.Errorf("取消自动发布任务失败: %v", err)
+		}
+		if !canceled {
+			g.Log().Warningf(ctx, "自动发布任务不存在或已开始执行: gameID=%d", gameInfo.ID)
 		}
 
 		g.Log().Infof(ctx, "取消预约发布成功: gameID=%d", gameInfo.ID)
@@ -432,29 +548,12 @@ func handleUnpublished(ctx context.Context, gameInfo *model.Game, data interface
 	updateData := map[string]interface{}{
 		dao.Game.Columns().Status: int(model.GameStatusUnpublished),
 	}
-	result, err := dao.Game.Ctx(ctx).
-		Where(dao.Game.Columns().ID, gameInfo.ID).
-		Where(dao.Game.Columns().Version, gameInfo.Version).
-		Data(updateData).
-		Update()
-	if err != nil {
-		return err
-	}
-	rowsAffected, err := result.RowsAffected()
+	// 游戏不再处于线上状态，丢弃未完成的草稿
+	err := removeDraft(ctx, gameInfo.ID)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return ErrConcurrentUpdate
-	}
-
-	return nil
-}
 
-func handleUpdateVersion(ctx context.Context, gameInfo *model.Game, data interface{}) error {
-	updateData := map[string]interface{}{
-		dao.Game.Columns().Status: int(model.GameStatusInit),
-	}
 	result, err := dao.Game.Ctx(ctx).
 		Where(dao.Game.Columns().ID, gameInfo.ID).
 		Where(dao.Game.Columns().Version, gameInfo.Version).
@@ -553,15 +652,36 @@ func (gg *Game) HandleGameEvent(ctx context.Context, gameID int64, event model.G
 }
 
 // executeEventTransition 执行基于事件的状态转换
+// 状态更新、状态停留区间与状态变更历史在同一个事务中写入，事务提交后再唤醒相关的异步任务。
 func (gg *Game) executeEventTransition(ctx context.Context, gameInfo *model.Game, event model.GameEvent, transition *Transition, data interface{}) error {
-	// 执行自定义的转换动作
-	if transition.Action != nil {
-		err := transition.Action(ctx, gameInfo, data)
+	err := g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		// 执行自定义的转换动作
+		if transition.Action != nil {
+			err := transition.Action(ctx, gameInfo, data)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 记录状态停留区间，用于SLA统计
+		err := recordStatusPeriod(ctx, gameInfo.ID, gameInfo.Status, transition.TargetStatus)
 		if err != nil {
 			return err
 		}
+
+		// 记录状态变更历史
+		return gg.addStatusHistory(ctx, tx, gameInfo, event, transition.TargetStatus, data)
+	})
+	if err != nil {
+		return err
 	}
 
+	for _, taskType := range transition.WakeUpTasks {
+		service.AsyncTask().WakeUp(taskType)
+	}
+	service.Outbox().WakeUp()
+	service.Search().Refresh(ctx, gameInfo.ID)
+
 	// 记录状态变更日志
 	g.Log().Infof(ctx, "游戏状态变更: gameID=%d, event=%s, %s -> %s",
 		gameInfo.ID,
@@ -572,36 +692,29 @@ func (gg *Game) executeEventTransition(ctx context.Context, gameInfo *model.Game
 	return nil
 }
 
-func (gg *Game) HandleGameAutoPublish(ctx context.Context, task *model.AsyncTask) (err error) {
-	// 解析任务内容
-	taskContent, ok := task.Content.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("任务内容格式错误")
+func (gg *Game) HandleGameAutoPublish(ctx context.Context, task *model.GameAutoPublishTask) (err error) {
+	// 任务开始执行前已取消预约，跳过
+	gameInfo, err := gg.GetGameByID(ctx, task.GameID)
+	if err != nil {
+		return
 	}
-
-	gameID, ok := taskContent["game_id"].(float64)
-	if !ok {
-		return fmt.Errorf("游戏ID格式错误")
+	if gameInfo.Status != model.GameStatusPreRegister {
+		g.Log().Infof(ctx, "游戏不是预约状态，跳过自动发布: gameID=%d, status=%s", gameInfo.ID, model.GetGameStatusText(gameInfo.Status))
+		return nil
 	}
 
-	return gg.HandleGameEvent(ctx, int64(gameID), model.AutoPublish, nil)
+	return gg.HandleGameEvent(ctx, gameInfo.ID, model.AutoPublish, nil)
 }
 
-// NotifyReservedUsers
-func (gg *Game) NotifyReservedUsers(ctx context.Context, task *model.AsyncTask) (err error) {
-	// 解析任务内容
-	taskContent, ok := task.Content.(map[string]interface{})
-	if !ok {
-		return fmt.Errorf("任务内容格式错误")
-	}
-
-	gameID, ok := taskContent["game_id"].(float64)
-	if !ok {
-		return fmt.Errorf("游戏ID格式错误")
-	}
+// gameAutoPublishCustomID 游戏自动发布任务的自定义任务ID，每个游戏只有一个等待执行的自动发布任务
+func gameAutoPublishCustomID(gameID int64) string {
+	return fmt.Sprintf("game_auto_publish_%d", gameID)
+}
 
+// NotifyReservedUsers
+func (gg *Game) NotifyReservedUsers(ctx context.Context, task *model.GameNotifyReservedUsersTask) (err error) {
 	// 获取当前游戏信息
-	gameInfo, err := gg.GetGameByID(ctx, int64(gameID))
+	gameInfo, err := gg.GetGameByID(ctx, task.GameID)
 	if err != nil {
 		return fmt.Errorf("获取游戏信息失败: %v", err)
 	}
@@ -628,9 +741,15 @@ func (gg *Game) NotifyReservedUsers(ctx context.Context, task *model.AsyncTask)
 		"message":   "游戏已发布，请登录游戏引擎查看",
 	}
 
-	err = service.MQ().Publish(ctx, "core.push.users", body)
+	// 任务重试时不重复通知，同一次上架只写入一次
+	dedupKey := fmt.Sprintf("game_notify_reserved_users_%d_%d", gameInfo.ID, gameInfo.PublishTime.Timestamp())
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		return service.Outbox().AddMessage(ctx, tx, "core.push.users", dedupKey, body)
+	})
 	if err != nil {
 		return
 	}
+
+	service.Outbox().WakeUp()
 	return nil
 }
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
+	"GameEngine/internal/service"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// addStatusHistory 记录游戏状态变更历史，并写入状态变更事件，需要与状态更新处于同一事务中
+// 操作人从上下文中获取，异步任务等无登录用户的场景记为系统操作(operator_id = 0)
+func (gg *Game) addStatusHistory(ctx context.Context, tx gdb.TX, gameInfo *model.Game, event model.GameEvent, targetStatus model.GameStatus, data interface{}) (err error) {
+	var (
+		operatorID   int64
+		operatorName string
+		reason       string
+		eventData    string
+	)
+	if userInfo, err := model.GetUserInfo(ctx); err == nil {
+		operatorID = userInfo.ID
+		operatorName = userInfo.Name
+	}
+
+	if data != nil {
+		if m, ok := data.(map[string]interface{}); ok {
+			reason, _ = m["reason"].(string)
+		}
+		dataBytes, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("序列化事件数据失败: %v", err)
+		}
+		eventData = string(dataBytes)
+	}
+
+	historyID, err := dao.GameStatusHistory.Ctx(ctx).TX(tx).Data(map[string]interface{}{
+		dao.GameStatusHistory.Columns().GameID:       gameInfo.ID,
+		dao.GameStatusHistory.Columns().FromStatus:   int(gameInfo.Status),
+		dao.GameStatusHistory.Columns().ToStatus:     int(targetStatus),
+		dao.GameStatusHistory.Columns().Event:        int(event),
+		dao.GameStatusHistory.Columns().OperatorID:   operatorID,
+		dao.GameStatusHistory.Columns().OperatorName: operatorName,
+		dao.GameStatusHistory.Columns().Reason:       reason,
+		dao.GameStatusHistory.Columns().EventData:    eventData,
+	}).InsertAndGetId()
+	if err != nil {
+		return
+	}
+
+	// 每条状态变更历史对应一个事件，历史ID作为去重键
+	return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_status_changed_%d", historyID), &events.GameStatusChanged{
+		GameID:       gameInfo.ID,
+		FromStatus:   gameInfo.Status,
+		ToStatus:     targetStatus,
+		Event:        event,
+		OperatorID:   operatorID,
+		OperatorName: operatorName,
+		Reason:       reason,
+	})
+}
+
+// ListStatusHistory 获取游戏状态变更历史，按时间倒序
+func (gg *Game) ListStatusHistory(ctx context.Context, gameID int64, pageReq *model.PageReq) (outs []*model.GameStatusHistory, pageRes *model.PageRes, err error) {
+	if pageReq.Page == 0 {
+		pageReq.Page = 1
+	}
+	if pageReq.Size == 0 {
+		pageReq.Size = 20
+	}
+
+	err = gg.AssertExists(ctx, gameID)
+	if err != nil {
+		return
+	}
+
+	query := dao.GameStatusHistory.Ctx(ctx).Where(dao.GameStatusHistory.Columns().GameID, gameID)
+
+	total, err := query.Count()
+	if err != nil {
+		return
+	}
+
+	var entities []*entity.GameStatusHistory
+	err = query.Page(pageReq.Page, pageReq.Size).
+		OrderDesc(dao.GameStatusHistory.Columns().ID).
+		Scan(&entities)
+	if err != nil {
+		return
+	}
+
+	for _, entity := range entities {
+		outs = append(outs, model.ConvertGameStatusHistoryEntityToModel(entity))
+	}
+	pageRes = &model.PageRes{
+		Total:       total,
+		CurrentPage: pageReq.Page,
+	}
+	return
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"context"
+	"fmt"
+)
+
+type namedValidator struct {
+	name      string
+	validator model.GameValidator
+}
+
+// RegisterValidator 注册提交审核检查项，同名检查项会被替换
+func (gg *Game) RegisterValidator(name string, validator model.GameValidator) {
+	gg.validatorMutex.Lock()
+	defer gg.validatorMutex.Unlock()
+
+	for _, v := range gg.validators {
+		if v.name == name {
+			v.validator = validator
+			return
+		}
+	}
+	gg.validators = append(gg.validators, &namedValidator{name: name, validator: validator})
+}
+
+// Validate 执行所有提交审核检查项，返回全部问题
+func (gg *Game) Validate(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	gg.validatorMutex.RLock()
+	validators := append([]*namedValidator{}, gg.validators...)
+	gg.validatorMutex.RUnlock()
+
+	findings = make([]*model.ValidationFinding, 0)
+	for _, v := range validators {
+		outs, err := v.validator(ctx, in)
+		if err != nil {
+			return nil, fmt.Errorf("提交审核检查[%s]执行失败: %v", v.name, err)
+		}
+		for _, finding := range outs {
+			finding.GameID = in.GameID
+			finding.Draft = in.Draft
+			finding.Validator = v.name
+			findings = append(findings, finding)
+		}
+	}
+	return
+}
+
+// ListValidationWarnings 获取游戏最近一次提交审核的检查警告，按游戏ID分组
+func (gg *Game) ListValidationWarnings(ctx context.Context, gameIDs []int64, draft bool) (out map[int64][]*model.ValidationFinding, err error) {
+	out = make(map[int64][]*model.ValidationFinding, len(gameIDs))
+	if len(gameIDs) == 0 {
+		return
+	}
+
+	var entities []*entity.GameValidationFinding
+	err = dao.GameValidationFinding.Ctx(ctx).
+		WhereIn(dao.GameValidationFinding.Columns().GameID, gameIDs).
+		Where(dao.GameValidationFinding.Columns().Draft, boolToInt(draft)).
+		Where(dao.GameValidationFinding.Columns().Severity, int(model.ValidationSeverityWarning)).
+		OrderAsc(dao.GameValidationFinding.Columns().ID).
+		Scan(&entities)
+	if err != nil {
+		return
+	}
+
+	for _, e := range entities {
+		out[e.GameID] = append(out[e.GameID], model.ConvertGameValidationFindingEntityToModel(e))
+	}
+	return
+}
+
+// validateForReview 提交审核前执行检查：存在阻断性问题时返回 ValidationError，否则保存警告供审核人员查看
+// 需要与状态更新处于同一事务中
+func validateForReview(ctx context.Context, in *model.ValidationSubject) (err error) {
+	findings, err := gameInstance.Validate(ctx, in)
+	if err != nil {
+		return
+	}
+
+	blocking := make([]*model.ValidationFinding, 0)
+	warnings := make([]*model.ValidationFinding, 0)
+	for _, finding := range findings {
+		if finding.Severity == model.ValidationSeverityBlocking {
+			blocking = append(blocking, finding)
+		} else {
+			warnings = append(warnings, finding)
+		}
+	}
+	if len(blocking) > 0 {
+		return &model.ValidationError{Findings: blocking}
+	}
+
+	// 只保留最近一次提交的检查结果
+	_, err = dao.GameValidationFinding.Ctx(ctx).
+		Where(dao.GameValidationFinding.Columns().GameID, in.GameID).
+		Where(dao.GameValidationFinding.Columns().Draft, boolToInt(in.Draft)).
+		Delete()
+	if err != nil {
+		return
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	dataInserts := make([]map[string]interface{}, 0, len(warnings))
+	for _, finding := range warnings {
+		dataInserts = append(dataInserts, map[string]interface{}{
+			dao.GameValidationFinding.Columns().GameID:    finding.GameID,
+			dao.GameValidationFinding.Columns().Draft:     boolToInt(finding.Draft),
+			dao.GameValidationFinding.Columns().Validator: finding.Validator,
+			dao.GameValidationFinding.Columns().Severity:  int(finding.Severity),
+			dao.GameValidationFinding.Columns().Field:     string(finding.Field),
+			dao.GameValidationFinding.Columns().Message:   finding.Message,
+		})
+	}
+	_, err = dao.GameValidationFinding.Ctx(ctx).Data(dataInserts).Insert()
+	return
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
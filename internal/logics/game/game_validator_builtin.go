@@ -0,0 +1,336 @@
+package game
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// 内置的提交审核检查项，阈值在配置文件 validation 节点中配置
+const (
+	ValidatorBasicInfo         = "basic_info"
+	ValidatorRequiredMedia     = "required_media"
+	ValidatorDescriptionLength = "description_length"
+	ValidatorBannedWords       = "banned_words"
+	ValidatorScreenshot        = "screenshot"
+	ValidatorMediaSize         = "media_size"
+	ValidatorH5Link            = "h5_link"
+	ValidatorDuplicateName     = "duplicate_name"
+	ValidatorApkConsistency    = "apk_consistency"
+)
+
+func (gg *Game) registerBuiltinValidators() {
+	gg.RegisterValidator(ValidatorBasicInfo, validateBasicInfo)
+	gg.RegisterValidator(ValidatorRequiredMedia, validateRequiredMedia)
+	gg.RegisterValidator(ValidatorDescriptionLength, validateDescriptionLength)
+	gg.RegisterValidator(ValidatorBannedWords, validateBannedWords)
+	gg.RegisterValidator(ValidatorScreenshot, validateScreenshot)
+	gg.RegisterValidator(ValidatorMediaSize, validateMediaSize)
+	gg.RegisterValidator(ValidatorH5Link, validateH5Link)
+	gg.RegisterValidator(ValidatorDuplicateName, validateDuplicateName)
+	gg.RegisterValidator(ValidatorApkConsistency, validateApkConsistency)
+}
+
+func blocking(field model.ReviewField, format string, args ...interface{}) *model.ValidationFinding {
+	return &model.ValidationFinding{
+		Severity: model.ValidationSeverityBlocking,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func warning(field model.ReviewField, format string, args ...interface{}) *model.ValidationFinding {
+	return &model.ValidationFinding{
+		Severity: model.ValidationSeverityWarning,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// validateBasicInfo 名称、开发商、发行商不能为空
+func validateBasicInfo(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	if strings.TrimSpace(in.Name) == "" {
+		findings = append(findings, blocking(model.ReviewFieldName, "游戏名称不能为空"))
+	}
+	if strings.TrimSpace(in.Developer) == "" {
+		findings = append(findings, blocking(model.ReviewFieldOther, "开发商不能为空"))
+	}
+	if strings.TrimSpace(in.Publisher) == "" {
+		findings = append(findings, blocking(model.ReviewFieldOther, "发行商不能为空"))
+	}
+	return
+}
+
+// validateRequiredMedia 必要的媒体文件是否齐全，且全部通过内容安全扫描
+func validateRequiredMedia(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	for _, mediaType := range missingRequiredMedia(in.DistributeType, in.MediaInfos) {
+		findings = append(findings, blocking(mediaTypeField(mediaType), "%s媒体文件不存在", model.GetGameMediaTypeText(mediaType)))
+	}
+	for _, mediaInfo := range notCleanMedia(in.MediaInfos) {
+		findings = append(findings, blocking(mediaTypeField(mediaInfo.MediaType), "%s媒体文件%s",
+			model.GetGameMediaTypeText(mediaInfo.MediaType), notCleanReason(mediaInfo.Status)))
+	}
+	return
+}
+
+// validateDescriptionLength 描述长度(按字符计算)
+func validateDescriptionLength(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	minLength := g.Cfg().MustGet(ctx, "validation.description.minLength", 20).Int()
+	maxLength := g.Cfg().MustGet(ctx, "validation.description.maxLength", 500).Int()
+
+	length := utf8.RuneCountInString(strings.TrimSpace(in.Description))
+	if minLength > 0 && length < minLength {
+		findings = append(findings, blocking(model.ReviewFieldDescription, "游戏描述不能少于%d个字符，当前%d个字符", minLength, length))
+	}
+	if maxLength > 0 && length > maxLength {
+		findings = append(findings, blocking(model.ReviewFieldDescription, "游戏描述不能超过%d个字符，当前%d个字符", maxLength, length))
+	}
+	return
+}
+
+// validateBannedWords 敏感词检查：名称命中时拒绝提交，描述和详情命中时提示审核人员
+func validateBannedWords(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	if hits := service.SensitiveWord().Match(ctx, in.Name); len(hits) > 0 {
+		findings = append(findings, blocking(model.ReviewFieldName, "游戏名称包含敏感词：%s", strings.Join(hits, "、")))
+	}
+	if hits := service.SensitiveWord().Match(ctx, in.Description); len(hits) > 0 {
+		findings = append(findings, warning(model.ReviewFieldDescription, "游戏描述包含敏感词：%s", strings.Join(hits, "、")))
+	}
+	if hits := service.SensitiveWord().Match(ctx, in.Details); len(hits) > 0 {
+		findings = append(findings, warning(model.ReviewFieldDetails, "游戏详情包含敏感词：%s", strings.Join(hits, "、")))
+	}
+	return
+}
+
+// validateScreenshot 截图数量和宽高比
+func validateScreenshot(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	minCount := g.Cfg().MustGet(ctx, "validation.screenshot.minCount", 3).Int()
+	maxCount := g.Cfg().MustGet(ctx, "validation.screenshot.maxCount", 10).Int()
+	aspectRatios := g.Cfg().MustGet(ctx, "validation.screenshot.aspectRatios", []string{"16:9", "9:16"}).Strings()
+	tolerance := g.Cfg().MustGet(ctx, "validation.screenshot.ratioTolerance", 0.02).Float64()
+
+	screenshots := make([]*model.GameMediaInfo, 0)
+	for _, mediaInfo := range in.MediaInfos {
+		if mediaInfo.MediaType == model.GameMediaTypeScreenshot {
+			screenshots = append(screenshots, mediaInfo)
+		}
+	}
+	// 没有截图时由 required_media 报告
+	if len(screenshots) == 0 {
+		return
+	}
+
+	if minCount > 0 && len(screenshots) < minCount {
+		findings = append(findings, blocking(model.ReviewFieldScreenshot, "截图不能少于%d张，当前%d张", minCount, len(screenshots)))
+	}
+	if maxCount > 0 && len(screenshots) > maxCount {
+		findings = append(findings, blocking(model.ReviewFieldScreenshot, "截图不能超过%d张，当前%d张", maxCount, len(screenshots)))
+	}
+
+	ratios := make([]float64, 0, len(aspectRatios))
+	for _, aspectRatio := range aspectRatios {
+		var w, h float64
+		if _, err := fmt.Sscanf(aspectRatio, "%g:%g", &w, &h); err != nil || w <= 0 || h <= 0 {
+			g.Log().Warningf(ctx, "截图宽高比配置错误: %s", aspectRatio)
+			continue
+		}
+		ratios = append(ratios, w/h)
+	}
+	if len(ratios) == 0 {
+		return
+	}
+
+	unknown := 0
+	for i, screenshot := range screenshots {
+		if screenshot.Width <= 0 || screenshot.Height <= 0 {
+			unknown++
+			continue
+		}
+		ratio := float64(screenshot.Width) / float64(screenshot.Height)
+		matched := false
+		for _, r := range ratios {
+			if math.Abs(ratio-r)/r <= tolerance {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			findings = append(findings, blocking(model.ReviewFieldScreenshot, "第%d张截图尺寸%dx%d不符合要求的宽高比(%s)",
+				i+1, screenshot.Width, screenshot.Height, strings.Join(aspectRatios, "、")))
+		}
+	}
+	if unknown > 0 {
+		findings = append(findings, warning(model.ReviewFieldScreenshot, "%d张截图无法读取尺寸(仅支持PNG、JPEG、GIF)，无法检查宽高比", unknown))
+	}
+	return
+}
+
+// validateMediaSize 视频和APK文件大小
+func validateMediaSize(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	videoMaxSize := g.Cfg().MustGet(ctx, "validation.video.maxSize", 200<<20).Int64()
+	apkMaxSize := g.Cfg().MustGet(ctx, "validation.apk.maxSize", 2<<30).Int64()
+
+	for _, mediaInfo := range in.MediaInfos {
+		var maxSize int64
+		switch mediaInfo.MediaType {
+		case model.GameMediaTypeVideo:
+			maxSize = videoMaxSize
+		case model.GameMediaTypeApkFile:
+			maxSize = apkMaxSize
+		default:
+			continue
+		}
+		if maxSize > 0 && mediaInfo.FileSize > maxSize {
+			findings = append(findings, blocking(mediaTypeField(mediaInfo.MediaType), "%s文件大小%s超过上限%s",
+				model.GetGameMediaTypeText(mediaInfo.MediaType), formatFileSize(mediaInfo.FileSize), formatFileSize(maxSize)))
+		}
+	}
+	return
+}
+
+// validateH5Link H5链接格式必须正确；无法访问时给出警告，由审核人员确认
+// 可访问性在提交审核的事务之外检查(probeH5Links)，这里只读取检查结果
+func validateH5Link(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	if in.DistributeType != model.GameDistributeTypeLink {
+		return
+	}
+
+	for _, mediaInfo := range in.MediaInfos {
+		if mediaInfo.MediaType != model.GameMediaTypeH5Link {
+			continue
+		}
+		if !validH5Link(mediaInfo.MediaUrl) {
+			findings = append(findings, blocking(model.ReviewFieldH5Link, "H5链接格式错误：%s", mediaInfo.MediaUrl))
+			continue
+		}
+		if message := in.H5LinkProbes[mediaInfo.MediaUrl]; message != "" {
+			findings = append(findings, warning(model.ReviewFieldH5Link, "%s", message))
+		}
+	}
+	return
+}
+
+// validateDuplicateName 名称与已有游戏相似时给出警告
+func validateDuplicateName(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	threshold := g.Cfg().MustGet(ctx, "validation.duplicateName.similarity", 0.8).Float64()
+	name := normalizeGameName(in.Name)
+	if name == "" || threshold <= 0 {
+		return
+	}
+
+	names, err := dao.Game.Ctx(ctx).
+		WhereNot(dao.Game.Columns().ID, in.GameID).
+		Array(dao.Game.Columns().Name)
+	if err != nil {
+		return
+	}
+
+	similar := make([]string, 0)
+	for _, other := range names {
+		if nameSimilarity(name, normalizeGameName(other.String())) >= threshold {
+			similar = append(similar, "《"+other.String()+"》")
+			if len(similar) >= 5 {
+				break
+			}
+		}
+	}
+	if len(similar) > 0 {
+		findings = append(findings, warning(model.ReviewFieldName, "游戏名称与已有游戏相似：%s", strings.Join(similar, "、")))
+	}
+	return
+}
+
+// validateApkConsistency APK安装包解析完成，且包名、签名证书与已审核通过的版本一致
+func validateApkConsistency(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error) {
+	if in.DistributeType != model.GameDistributeTypeAPK {
+		return
+	}
+	for _, fileID := range apkFileIDs(in.MediaInfos) {
+		outs, err := checkApkConsistency(ctx, in.GameID, fileID)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, outs...)
+	}
+	return
+}
+
+func mediaTypeField(mediaType model.GameMediaType) model.ReviewField {
+	switch mediaType {
+	case model.GameMediaTypeIcon:
+		return model.ReviewFieldIcon
+	case model.GameMediaTypeScreenshot:
+		return model.ReviewFieldScreenshot
+	case model.GameMediaTypeVideo:
+		return model.ReviewFieldVideo
+	case model.GameMediaTypeApkFile:
+		return model.ReviewFieldApk
+	case model.GameMediaTypeH5Link:
+		return model.ReviewFieldH5Link
+	default:
+		return model.ReviewFieldOther
+	}
+}
+
+func formatFileSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1fGB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%dB", size)
+	}
+}
+
+// normalizeGameName 比较名称前转小写并去掉空白和标点
+func normalizeGameName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// nameSimilarity 基于编辑距离的相似度，1 表示完全相同
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+	if maxLen == 0 {
+		return 0
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(rb)])/float64(maxLen)
+}
@@ -4,6 +4,7 @@ import (
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
 	"context"
 	"database/sql"
 	"errors"
@@ -17,6 +18,10 @@ var (
 )
 
 func (m *metadata) CreateCategory(ctx context.Context, name string) (id int64, err error) {
+	if err = service.SensitiveWord().Reject(ctx, "分类名称", name); err != nil {
+		return
+	}
+
 	dataInsert := map[string]interface{}{
 		dao.Category.Columns().Name: name,
 	}
@@ -59,6 +64,9 @@ func (m *metadata) UpdateCategory(ctx context.Context, id int64, name string) (e
 	if err = m.AssertCategoryExists(ctx, id); err != nil {
 		return
 	}
+	if err = service.SensitiveWord().Reject(ctx, "分类名称", name); err != nil {
+		return
+	}
 
 	dataUpdate := map[string]interface{}{
 		dao.Category.Columns().Name: name,
@@ -1,9 +1,11 @@
 package metadata
 
 import (
+	"GameEngine/internal/common/pinyin"
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
 	"context"
 	"database/sql"
 	"errors"
@@ -17,8 +19,15 @@ var (
 )
 
 func (m *metadata) CreateTag(ctx context.Context, name string) (id int64, err error) {
+	if err = service.SensitiveWord().Reject(ctx, "标签名称", name); err != nil {
+		return
+	}
+
+	forms := pinyin.Convert(name)
 	dataInsert := map[string]interface{}{
-		dao.Tag.Columns().Name: name,
+		dao.Tag.Columns().Name:     name,
+		dao.Tag.Columns().Pinyin:   pinyin.Join(forms.Full),
+		dao.Tag.Columns().Initials: pinyin.Join(forms.Initials),
 	}
 
 	id, err = dao.Tag.Ctx(ctx).Data(dataInsert).InsertAndGetId()
@@ -54,9 +63,15 @@ func (m *metadata) UpdateTag(ctx context.Context, id int64, name string) (err er
 	if err = m.AssertTagExists(ctx, id); err != nil {
 		return
 	}
+	if err = service.SensitiveWord().Reject(ctx, "标签名称", name); err != nil {
+		return
+	}
 
+	forms := pinyin.Convert(name)
 	dataUpdate := map[string]interface{}{
-		dao.Tag.Columns().Name: name,
+		dao.Tag.Columns().Name:     name,
+		dao.Tag.Columns().Pinyin:   pinyin.Join(forms.Full),
+		dao.Tag.Columns().Initials: pinyin.Join(forms.Initials),
 	}
 
 	_, err = dao.Tag.Ctx(ctx).Where(dao.Tag.Columns().ID, id).Data(dataUpdate).Update()
@@ -0,0 +1,185 @@
+package logics
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	defaultMQConsumerChannel     = "game-engine"
+	maxMQConsumedMessageIDLength = 128
+	mqConsumedCleanupBatchSize   = 1000
+)
+
+var (
+	logicsMQConsumerOnce     sync.Once
+	logicsMQConsumerInstance *logicsMQConsumer
+)
+
+// logicsMQConsumer 消息队列至少投递一次，同一条消息可能收到多次：处理函数与去重记录(channel、主题、消息ID)在同一事务中执行，
+// 已有去重记录的消息直接确认；处理失败时在进程内按重试策略重试，重试耗尽后记录日志并确认，避免一条消息阻塞整个主题
+type logicsMQConsumer struct {
+	logger *glog.Logger
+	ctx    context.Context // 停机时取消，等待重试的消息不再重试
+	cancel context.CancelFunc
+
+	mutex    sync.Mutex
+	handlers map[string]model.MQHandler
+
+	channel     string
+	retention   time.Duration // 去重记录的保留时长，需要大于消息队列重新投递的最长间隔
+	retryPolicy *retryPolicy
+}
+
+func NewMQConsumer() *logicsMQConsumer {
+	logicsMQConsumerOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		logicsMQConsumerInstance = &logicsMQConsumer{
+			logger:   g.Log(),
+			ctx:      ctx,
+			cancel:   cancel,
+			handlers: make(map[string]model.MQHandler),
+
+			channel:   defaultMQConsumerChannel,
+			retention: 7 * 24 * time.Hour,
+		}
+	})
+
+	return logicsMQConsumerInstance
+}
+
+func (o *logicsMQConsumer) RegisterHandler(topic string, handler model.MQHandler) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if _, ok := o.handlers[topic]; ok {
+		panic(fmt.Sprintf("[MQConsumer]: handler already registered for topic: %s", topic))
+	}
+	o.handlers[topic] = handler
+}
+
+func (o *logicsMQConsumer) Start() {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if channel := g.Cfg().MustGet(o.ctx, "mqConsumer.channel").String(); channel != "" {
+		o.channel = channel
+	}
+	if retention := g.Cfg().MustGet(o.ctx, "mqConsumer.dedupRetention").Duration(); retention > 0 {
+		o.retention = retention
+	}
+	o.retryPolicy = readRetryPolicy(o.ctx, retryPolicy{
+		MaxRetries:   10,
+		BaseInterval: time.Second,
+		MaxInterval:  time.Minute,
+		Jitter:       0.2,
+	}, "mqConsumer.retry")
+
+	for topic, handler := range o.handlers {
+		if err := service.MQ().Subscribe(topic, o.channel, o.wrap(topic, handler)); err != nil {
+			o.logger.Errorf(o.ctx, "[MQConsumer]: subscribe %s error: %v", topic, err)
+		}
+	}
+}
+
+// Shutdown 先取消等待中的重试，再等待处理中的消息完成
+func (o *logicsMQConsumer) Shutdown(ctx context.Context) error {
+	o.cancel()
+	return service.MQ().StopConsumers(ctx)
+}
+
+// CleanupConsumed 分批删除，避免长时间锁表
+func (o *logicsMQConsumer) CleanupConsumed(ctx context.Context) error {
+	before := gtime.Now().Add(-o.retention).Unix()
+	var total int64
+	for {
+		rowsAffected, err := dao.MQConsumedMessage.DeleteBefore(ctx, before, mqConsumedCleanupBatchSize)
+		if err != nil {
+			return err
+		}
+		total += rowsAffected
+		if rowsAffected < mqConsumedCleanupBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		o.logger.Infof(ctx, "[MQConsumer]: deleted %d consumed message records", total)
+	}
+	return nil
+}
+
+// wrap 只有停机时返回错误，未处理完成的消息由消息队列重新投递
+func (o *logicsMQConsumer) wrap(topic string, handler model.MQHandler) model.MQHandler {
+	consumer := o.channel + ":" + topic
+	return func(ctx context.Context, message *model.MQMessage) error {
+		for attempt := 1; ; attempt++ {
+			err := o.handle(ctx, consumer, handler, message)
+			switch {
+			case err == nil:
+				return nil
+			case errors.Is(err, model.ErrMalformedMQMessage):
+				o.logger.Errorf(ctx, "[MQConsumer]: drop malformed message %s from %s: %v, body: %s", message.ID, topic, err, message.Body)
+				return nil
+			case o.ctx.Err() != nil:
+				return err
+			case o.retryPolicy.MaxRetries > 0 && attempt > o.retryPolicy.MaxRetries:
+				o.logger.Errorf(ctx, "[MQConsumer]: message %s from %s failed %d times, give up: %v, body: %s", message.ID, topic, attempt, err, message.Body)
+				return nil
+			}
+
+			interval := o.retryPolicy.backoff(attempt)
+			o.logger.Warningf(ctx, "[MQConsumer]: message %s from %s failed %d times, retry after %s: %v", message.ID, topic, attempt, interval, err)
+			select {
+			case <-o.ctx.Done():
+				return err
+			case <-time.After(interval):
+			}
+		}
+	}
+}
+
+// handle 写入去重记录并执行处理函数，已处理过的消息直接返回
+func (o *logicsMQConsumer) handle(ctx context.Context, consumer string, handler model.MQHandler, message *model.MQMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+
+	messageID := message.ID
+	if len(messageID) > maxMQConsumedMessageIDLength {
+		sum := md5.Sum([]byte(messageID))
+		messageID = hex.EncodeToString(sum[:])
+	}
+
+	duplicate := false
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		added, err := dao.MQConsumedMessage.Add(ctx, tx, consumer, messageID)
+		if err != nil {
+			return err
+		}
+		if !added {
+			duplicate = true
+			return nil
+		}
+		return handler(ctx, message)
+	})
+	if err == nil && duplicate {
+		o.logger.Infof(ctx, "[MQConsumer]: skip duplicate message %s for %s", message.ID, consumer)
+	}
+	return err
+}
@@ -0,0 +1,251 @@
+package logics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
+	"GameEngine/internal/service"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+const (
+	maxOutboxErrorLength   = 1024
+	outboxCleanupBatchSize = 1000
+)
+
+var (
+	logicsOutboxOnce     sync.Once
+	logicsOutboxInstance *logicsOutbox
+)
+
+// logicsOutbox 事务性发件箱：消息与业务数据在同一事务中写入 t_outbox，由发送线程发送到消息队列
+// 发送成功后才标记为已发送，消息可能重复发送(发送成功但标记失败)，消费方按消息ID(去重键)去重
+type logicsOutbox struct {
+	logger *glog.Logger
+	ctx    context.Context // 停机时取消，发送线程退出
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	pollInterval time.Duration // 没有待发送消息时的查询间隔
+	batchSize    int           // 每次领取的消息数量
+	lease        time.Duration // 领取消息的租约时长，实例崩溃后到期的消息由其他实例重新领取
+	retention    time.Duration // 已发送消息的保留时长
+	retryPolicy  *retryPolicy
+
+	instanceID string
+	claimSeq   atomic.Int64
+	sigChan    chan struct{}
+}
+
+func NewOutbox() *logicsOutbox {
+	logicsOutboxOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		logicsOutboxInstance = &logicsOutbox{
+			logger: g.Log(),
+			ctx:    ctx,
+			cancel: cancel,
+
+			pollInterval: time.Second,
+			batchSize:    100,
+			lease:        time.Minute,
+			retention:    7 * 24 * time.Hour,
+
+			instanceID: newInstanceID(),
+			sigChan:    make(chan struct{}, 1),
+		}
+	})
+
+	return logicsOutboxInstance
+}
+
+func (o *logicsOutbox) AddEvent(ctx context.Context, tx gdb.TX, dedupKey string, e events.Event) error {
+	definition, ok := events.Lookup(e.EventType())
+	if !ok {
+		return fmt.Errorf("[Outbox]: event type not registered in catalog: %s", e.EventType())
+	}
+	return o.add(ctx, tx, definition.Topic, definition.Type, dedupKey, events.New(dedupKey, e, time.Now()))
+}
+
+func (o *logicsOutbox) AddMessage(ctx context.Context, tx gdb.TX, topic string, dedupKey string, body interface{}) error {
+	return o.add(ctx, tx, topic, "", dedupKey, body)
+}
+
+func (o *logicsOutbox) add(ctx context.Context, tx gdb.TX, topic string, eventType string, dedupKey string, body interface{}) error {
+	if dedupKey == "" {
+		return fmt.Errorf("[Outbox]: message to %s without dedup key", topic)
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("序列化消息失败: %v", err)
+	}
+	return dao.Outbox.Add(ctx, tx, topic, eventType, dedupKey, payload)
+}
+
+// 非阻塞方式通知发送线程有新消息，事务提交后调用
+func (o *logicsOutbox) WakeUp() {
+	select {
+	case o.sigChan <- struct{}{}:
+	default:
+	}
+}
+
+func (o *logicsOutbox) Start() {
+	if interval := g.Cfg().MustGet(o.ctx, "outbox.pollInterval").Duration(); interval > 0 {
+		o.pollInterval = interval
+	}
+	if batchSize := g.Cfg().MustGet(o.ctx, "outbox.batchSize").Int(); batchSize > 0 {
+		o.batchSize = batchSize
+	}
+	if lease := g.Cfg().MustGet(o.ctx, "outbox.lease").Duration(); lease > 0 {
+		o.lease = lease
+	}
+	if retention := g.Cfg().MustGet(o.ctx, "outbox.retention").Duration(); retention > 0 {
+		o.retention = retention
+	}
+	o.retryPolicy = readRetryPolicy(o.ctx, retryPolicy{
+		MaxRetries:   50,
+		BaseInterval: time.Second,
+		MaxInterval:  5 * time.Minute,
+		Jitter:       0.2,
+	}, "outbox.retry")
+
+	o.wg.Add(1)
+	go o.relay()
+}
+
+// Shutdown 停止领取新消息，等待正在发送的一批消息完成；ctx 到期后直接返回，未发送的消息租约到期后重新发送
+func (o *logicsOutbox) Shutdown(ctx context.Context) error {
+	o.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		o.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait outbox relay exit: %w", ctx.Err())
+	}
+}
+
+// CleanupPublished 分批删除，避免长时间锁表
+func (o *logicsOutbox) CleanupPublished(ctx context.Context) error {
+	before := gtime.Now().Add(-o.retention).Unix()
+	var total int64
+	for {
+		rowsAffected, err := dao.Outbox.DeletePublished(ctx, before, outboxCleanupBatchSize)
+		if err != nil {
+			return err
+		}
+		total += rowsAffected
+		if rowsAffected < outboxCleanupBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		o.logger.Infof(ctx, "[Outbox]: deleted %d published messages", total)
+	}
+	return nil
+}
+
+// relay 发送线程，每次领取一批消息按写入顺序发送，领取满一批时立即继续领取
+func (o *logicsOutbox) relay() {
+	defer o.wg.Done()
+
+	o.logger.Infof(o.ctx, "[Outbox]: relay start")
+	for {
+		select {
+		case <-o.ctx.Done():
+			o.logger.Infof(o.ctx, "[Outbox]: relay received exit signal")
+			return
+		case <-o.sigChan:
+		case <-time.After(o.pollInterval):
+		}
+
+		for o.ctx.Err() == nil {
+			claimed, err := o.relayBatch()
+			if err != nil {
+				o.logger.Errorf(o.ctx, "[Outbox]: relay messages error: %v", err)
+				break
+			}
+			if claimed < o.batchSize {
+				break
+			}
+		}
+	}
+}
+
+// relayBatch 领取并发送一批消息，panic 时转换为错误，避免发送线程退出
+// 已领取未发送的消息在租约到期后重新领取
+func (o *logicsOutbox) relayBatch() (claimed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	claimID := fmt.Sprintf("%s-%d", o.instanceID, o.claimSeq.Add(1))
+	messages, err := dao.Outbox.Claim(o.ctx, claimID, time.Now().Add(o.lease).Unix(), o.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// 已领取的消息在停机时也要更新状态，不受 o.ctx 取消影响
+	ctx := context.WithoutCancel(o.ctx)
+	for _, message := range messages {
+		if o.ctx.Err() != nil {
+			// 停机时释放未发送的消息，由其他实例或重启后发送
+			_, err = dao.Outbox.UpdateClaimed(ctx, message.ID, claimID, map[string]interface{}{})
+		} else {
+			err = o.publish(ctx, claimID, message)
+		}
+		if err != nil {
+			return len(messages), err
+		}
+	}
+	return len(messages), nil
+}
+
+func (o *logicsOutbox) publish(ctx context.Context, claimID string, message *entity.Outbox) (err error) {
+	publishErr := service.MQ().PublishWithID(ctx, message.Topic, message.DedupKey, json.RawMessage(message.Payload))
+	if publishErr == nil {
+		_, err = dao.Outbox.UpdateClaimed(ctx, message.ID, claimID, map[string]interface{}{
+			dao.Outbox.Columns().Status:      model.OutboxStatusPublished,
+			dao.Outbox.Columns().PublishTime: gtime.Now().UTC().Unix(),
+		})
+		return
+	}
+
+	retryCount := message.RetryCount + 1
+	data := map[string]interface{}{
+		dao.Outbox.Columns().RetryCount: retryCount,
+		dao.Outbox.Columns().LastError:  gstr.SubStrRune(publishErr.Error(), 0, maxOutboxErrorLength),
+	}
+	if o.retryPolicy.MaxRetries > 0 && retryCount > o.retryPolicy.MaxRetries {
+		data[dao.Outbox.Columns().Status] = model.OutboxStatusDead
+		o.logger.Errorf(ctx, "[Outbox]: message %s(id=%d) to %s failed %d times, give up: %v",
+			message.DedupKey, message.ID, message.Topic, retryCount, publishErr)
+	} else {
+		data[dao.Outbox.Columns().NextRetryTime] = time.Now().Add(o.retryPolicy.backoff(retryCount)).Unix()
+		o.logger.Warningf(ctx, "[Outbox]: message %s(id=%d) to %s failed %d times, will retry: %v",
+			message.DedupKey, message.ID, message.Topic, retryCount, publishErr)
+	}
+	_, err = dao.Outbox.UpdateClaimed(ctx, message.ID, claimID, data)
+	return
+}
@@ -4,6 +4,7 @@ import (
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
 	"context"
 	"fmt"
 
@@ -51,7 +52,7 @@ func (rl *Reservation) ReserveGame(ctx context.Context, userID, gameID int64) er
 			dao.GameReserve.Columns().GameID: gameID,
 			dao.GameReserve.Columns().UserID: userID,
 		}
-		_, err = dao.GameReserve.Ctx(ctx).TX(tx).Data(dataInsert).Insert()
+		reserveID, err := dao.GameReserve.Ctx(ctx).TX(tx).Data(dataInsert).InsertAndGetId()
 		if err != nil {
 			return err
 		}
@@ -63,9 +64,18 @@ func (rl *Reservation) ReserveGame(ctx context.Context, userID, gameID int64) er
 			return err
 		}
 
-		return nil
+		// 取消预约后可以再次预约，预约记录ID作为去重键
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_reservation_created_%d", reserveID), &events.ReservationCreated{
+			GameID: gameID,
+			UserID: userID,
+		})
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	service.Outbox().WakeUp()
+	return nil
 }
 
 // CancelReservation 取消预约
@@ -83,6 +93,14 @@ func (rl *Reservation) CancelReservation(ctx context.Context, userID, gameID int
 	}
 
 	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		reserveID, err := dao.GameReserve.Ctx(ctx).TX(tx).
+			Where(dao.GameReserve.Columns().GameID, gameID).
+			Where(dao.GameReserve.Columns().UserID, userID).
+			Value(dao.GameReserve.Columns().ID)
+		if err != nil {
+			return err
+		}
+
 		_, err = dao.GameReserve.Ctx(ctx).TX(tx).
 			Where(dao.GameReserve.Columns().GameID, gameID).
 			Where(dao.GameReserve.Columns().UserID, userID).
@@ -97,10 +115,65 @@ func (rl *Reservation) CancelReservation(ctx context.Context, userID, gameID int
 		if err != nil {
 			return err
 		}
+
+		// 与预约事件使用同一条预约记录的ID
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_reservation_canceled_%d", reserveID.Int64()), &events.ReservationCanceled{
+			GameID: gameID,
+			UserID: userID,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	service.Outbox().WakeUp()
+	return nil
+}
+
+// PurgeUserReservations 用户注销时删除用户的所有预约，并扣减游戏的预约数；重复调用时没有可删除的数据
+func (rl *Reservation) PurgeUserReservations(ctx context.Context, userID int64) error {
+	err := g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		var reservations []*entity.GameReserve
+		err := dao.GameReserve.Ctx(ctx).TX(tx).
+			Where(dao.GameReserve.Columns().UserID, userID).
+			LockUpdate().
+			Scan(&reservations)
+		if err != nil {
+			return err
+		}
+
+		for _, reservation := range reservations {
+			_, err = dao.GameReserve.Ctx(ctx).TX(tx).
+				Where(dao.GameReserve.Columns().ID, reservation.ID).
+				Delete()
+			if err != nil {
+				return err
+			}
+
+			_, err = dao.Game.Ctx(ctx).TX(tx).
+				Where(dao.Game.Columns().ID, reservation.GameID).
+				Decrement(dao.Game.Columns().ReserveCount, 1)
+			if err != nil {
+				return err
+			}
+
+			// 与取消预约使用相同的去重键
+			err = service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("game_reservation_canceled_%d", reservation.ID), &events.ReservationCanceled{
+				GameID: reservation.GameID,
+				UserID: userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	service.Outbox().WakeUp()
+	return nil
 }
 
 // GetUserReservations 获取用户预约列表
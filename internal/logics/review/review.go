@@ -0,0 +1,23 @@
+package review
+
+import (
+	"GameEngine/internal/service"
+	"sync"
+)
+
+var (
+	reviewOnce     sync.Once
+	reviewInstance *review
+)
+
+type review struct{}
+
+func NewReview() service.IReview {
+	reviewOnce.Do(func() {
+		reviewInstance = &review{}
+	})
+	return reviewInstance
+}
+
+// 确保review实现了IReview接口
+var _ service.IReview = (*review)(nil)
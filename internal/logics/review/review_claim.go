@@ -0,0 +1,443 @@
+package review
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+var (
+	ErrReviewQueueEmpty      = errors.New("没有待领取的审核任务")
+	ErrReviewClaimNotHeld    = errors.New("请先领取该游戏的审核任务")
+	ErrReviewAlreadyApproved = errors.New("您已审核通过该游戏，需要其他审核人员审核")
+)
+
+const (
+	defaultClaimLease = 30 * time.Minute
+	// claimNextBatchSize 领取下一个审核任务时，每次查询的候选游戏数量
+	claimNextBatchSize = 10
+)
+
+// ClaimNext 领取审核队列中等待时间最长的游戏，包括草稿或构建版本审核中的游戏
+// 跳过已被他人领取且租约未过期的游戏，以及当前审核人员已审核通过的游戏(双人审核)
+func (r *review) ClaimNext(ctx context.Context) (out *model.ReviewClaim, err error) {
+	userInfo, err := model.GetUserInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	gameIDs, err := dao.Game.Ctx(ctx).As("g").
+		LeftJoin(dao.GameReviewClaim.Table()+" c", "c.game_id = g.id").
+		// 与 hasPendingReview 一致：游戏、草稿或构建版本处于审核中
+		Where(fmt.Sprintf("(g.%s = ? OR g.%s IN ? OR g.%s IN ?)",
+			dao.Game.Columns().Status, dao.Game.Columns().ID, dao.Game.Columns().ID),
+			int(model.GameStatusInReview),
+			dao.GameDraft.Ctx(ctx).
+				Fields(dao.GameDraft.Columns().GameID).
+				Where(dao.GameDraft.Columns().Status, int(model.GameDraftStatusInReview)),
+			dao.GameBuild.Ctx(ctx).
+				Fields(dao.GameBuild.Columns().GameID).
+				Where(dao.GameBuild.Columns().Status, int(model.GameBuildStatusInReview))).
+		Where("(c.id IS NULL OR c.lease_expire_time <= ?)", gtime.Now()).
+		Where("g."+dao.Game.Columns().ID+" NOT IN ?",
+			dao.GameReviewApproval.Ctx(ctx).
+				Fields(dao.GameReviewApproval.Columns().GameID).
+				Where(dao.GameReviewApproval.Columns().ReviewerID, userInfo.ID)).
+		OrderAsc("g." + dao.Game.Columns().UpdateTime).
+		Limit(claimNextBatchSize).
+		Array("g." + dao.Game.Columns().ID)
+	if err != nil {
+		return
+	}
+
+	// 候选游戏可能同时被其他审核人员领取，依次尝试
+	for _, gameID := range gameIDs {
+		out, err = r.claimGame(ctx, gameID.Int64(), userInfo)
+		if err == nil {
+			return out, nil
+		}
+		g.Log().Debugf(ctx, "[Review] 领取审核任务失败，尝试下一个: game_id=%d, err=%v", gameID.Int64(), err)
+	}
+	return nil, ErrReviewQueueEmpty
+}
+
+// Claim 领取指定游戏的审核任务，已领取时续期租约
+func (r *review) Claim(ctx context.Context, gameID int64) (out *model.ReviewClaim, err error) {
+	userInfo, err := model.GetUserInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	gameInfo, err := service.Game().GetGameByID(ctx, gameID)
+	if err != nil {
+		return
+	}
+	pending, err := r.hasPendingReview(ctx, gameInfo)
+	if err != nil {
+		return
+	}
+	if !pending {
+		return nil, fmt.Errorf("游戏当前状态(%s)不需要审核", model.GetGameStatusText(gameInfo.Status))
+	}
+
+	return r.claimGame(ctx, gameID, userInfo)
+}
+
+// hasPendingReview 游戏、草稿或构建版本是否处于审核中，三者共用游戏的领取记录
+func (r *review) hasPendingReview(ctx context.Context, gameInfo *model.Game) (bool, error) {
+	if gameInfo.Status == model.GameStatusInReview {
+		return true, nil
+	}
+
+	exists, err := dao.GameDraft.Ctx(ctx).
+		Where(dao.GameDraft.Columns().GameID, gameInfo.ID).
+		Where(dao.GameDraft.Columns().Status, int(model.GameDraftStatusInReview)).
+		Exist()
+	if err != nil || exists {
+		return exists, err
+	}
+
+	return dao.GameBuild.Ctx(ctx).
+		Where(dao.GameBuild.Columns().GameID, gameInfo.ID).
+		Where(dao.GameBuild.Columns().Status, int(model.GameBuildStatusInReview)).
+		Exist()
+}
+
+// ReleaseClaim 释放自己领取的审核任务
+func (r *review) ReleaseClaim(ctx context.Context, gameID int64) (err error) {
+	claim, err := r.AssertClaimHolder(ctx, gameID)
+	if err != nil {
+		return
+	}
+
+	_, err = dao.GameReviewClaim.Ctx(ctx).
+		Where(dao.GameReviewClaim.Columns().ID, claim.ID).
+		Where(dao.GameReviewClaim.Columns().Version, claim.Version).
+		Delete()
+	return
+}
+
+// HandoffClaim 将自己领取的审核任务转交给其他审核人员，租约重新计时
+func (r *review) HandoffClaim(ctx context.Context, gameID, toReviewerID int64, toReviewerName string) (err error) {
+	claim, err := r.AssertClaimHolder(ctx, gameID)
+	if err != nil {
+		return
+	}
+	if toReviewerID == claim.ReviewerID {
+		return fmt.Errorf("不能转交给自己")
+	}
+
+	leaseExpireTime := gtime.Now().Add(r.claimLease(ctx))
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		result, err := dao.GameReviewClaim.Ctx(ctx).
+			Where(dao.GameReviewClaim.Columns().ID, claim.ID).
+			Where(dao.GameReviewClaim.Columns().Version, claim.Version).
+			Data(map[string]interface{}{
+				dao.GameReviewClaim.Columns().ReviewerID:      toReviewerID,
+				dao.GameReviewClaim.Columns().ReviewerName:    toReviewerName,
+				dao.GameReviewClaim.Columns().LeaseExpireTime: leaseExpireTime,
+				dao.GameReviewClaim.Columns().Version:         claim.Version + 1,
+			}).Update()
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("审核任务已变更，请刷新后重试")
+		}
+
+		return addClaimExpireTask(ctx, tx, gameID, claim.Version+1, leaseExpireTime)
+	})
+	if err != nil {
+		return
+	}
+
+	service.AsyncTask().WakeUp(model.AsyncTaskTypeReviewClaimExpire)
+	g.Log().Infof(ctx, "[Review] 审核任务转交: game_id=%d, from=%d, to=%d", gameID, claim.ReviewerID, toReviewerID)
+	return
+}
+
+// ListMyClaims 获取当前审核人员领取中的审核任务
+func (r *review) ListMyClaims(ctx context.Context, pageReq *model.PageReq) (outs []*model.ReviewClaim, pageRes *model.PageRes, err error) {
+	if pageReq.Page == 0 {
+		pageReq.Page = 1
+	}
+	if pageReq.Size == 0 {
+		pageReq.Size = 20
+	}
+
+	userInfo, err := model.GetUserInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	query := dao.GameReviewClaim.Ctx(ctx).
+		Where(dao.GameReviewClaim.Columns().ReviewerID, userInfo.ID).
+		WhereGT(dao.GameReviewClaim.Columns().LeaseExpireTime, gtime.Now())
+
+	total, err := query.Count()
+	if err != nil {
+		return
+	}
+
+	var entities []*entity.GameReviewClaim
+	err = query.Page(pageReq.Page, pageReq.Size).
+		OrderAsc(dao.GameReviewClaim.Columns().LeaseExpireTime).
+		Scan(&entities)
+	if err != nil {
+		return
+	}
+
+	for _, entity := range entities {
+		outs = append(outs, model.ConvertReviewClaimEntityToModel(entity))
+	}
+	pageRes = &model.PageRes{
+		Total:       total,
+		CurrentPage: pageReq.Page,
+	}
+	return
+}
+
+// GetClaim 获取游戏当前有效的领取记录，未被领取或租约已过期时返回 nil
+func (r *review) GetClaim(ctx context.Context, gameID int64) (out *model.ReviewClaim, err error) {
+	var entity entity.GameReviewClaim
+	err = dao.GameReviewClaim.Ctx(ctx).
+		Where(dao.GameReviewClaim.Columns().GameID, gameID).
+		WhereGT(dao.GameReviewClaim.Columns().LeaseExpireTime, gtime.Now()).
+		Scan(&entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return
+	}
+
+	out = model.ConvertReviewClaimEntityToModel(&entity)
+	return
+}
+
+// AssertClaimHolder 检查当前用户是否持有游戏的审核任务
+func (r *review) AssertClaimHolder(ctx context.Context, gameID int64) (claim *model.ReviewClaim, err error) {
+	userInfo, err := model.GetUserInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	claim, err = r.GetClaim(ctx, gameID)
+	if err != nil {
+		return
+	}
+	if claim == nil {
+		return nil, ErrReviewClaimNotHeld
+	}
+	if claim.ReviewerID != userInfo.ID {
+		return nil, fmt.Errorf("游戏已被审核人员(%s)领取", claim.ReviewerName)
+	}
+	return
+}
+
+// AddApproval 记录当前审核人员的审核通过，返回还需要的审核通过次数
+// 开启双人审核时，首次提交游戏的开发商需要两名不同的审核人员通过
+func (r *review) AddApproval(ctx context.Context, gameInfo *model.Game) (pendingApprovals int, err error) {
+	userInfo, err := model.GetUserInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	requiredApprovals, err := r.requiredApprovals(ctx, gameInfo)
+	if err != nil {
+		return
+	}
+
+	_, err = dao.GameReviewApproval.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameReviewApproval.Columns().GameID:       gameInfo.ID,
+		dao.GameReviewApproval.Columns().ReviewerID:   userInfo.ID,
+		dao.GameReviewApproval.Columns().ReviewerName: userInfo.Name,
+	}).Insert()
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			err = ErrReviewAlreadyApproved
+		}
+		return
+	}
+
+	approvals, err := dao.GameReviewApproval.Ctx(ctx).
+		Where(dao.GameReviewApproval.Columns().GameID, gameInfo.ID).
+		Count()
+	if err != nil {
+		return
+	}
+
+	pendingApprovals = requiredApprovals - approvals
+	if pendingApprovals < 0 {
+		pendingApprovals = 0
+	}
+	return
+}
+
+// ClearReviewState 审核结束时清理领取记录和审核通过记录，需要与状态变更处于同一事务中
+func (r *review) ClearReviewState(ctx context.Context, gameID int64) (err error) {
+	_, err = dao.GameReviewClaim.Ctx(ctx).Where(dao.GameReviewClaim.Columns().GameID, gameID).Delete()
+	if err != nil {
+		return
+	}
+
+	_, err = dao.GameReviewApproval.Ctx(ctx).Where(dao.GameReviewApproval.Columns().GameID, gameID).Delete()
+	return
+}
+
+// HandleClaimExpire 租约到期自动释放审核任务
+// 领取被续期或转交后版本号会变化，旧的释放任务直接忽略
+func (r *review) HandleClaimExpire(ctx context.Context, task *model.ReviewClaimExpireTask) (err error) {
+	var claim entity.GameReviewClaim
+	err = dao.GameReviewClaim.Ctx(ctx).Where(dao.GameReviewClaim.Columns().GameID, task.GameID).Scan(&claim)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return
+	}
+	if claim.Version != task.ClaimVersion {
+		return nil
+	}
+	if claim.LeaseExpireTime.After(gtime.Now()) {
+		return fmt.Errorf("审核任务租约未到期: game_id=%d, lease_expire_time=%s", claim.GameID, claim.LeaseExpireTime)
+	}
+
+	_, err = dao.GameReviewClaim.Ctx(ctx).
+		Where(dao.GameReviewClaim.Columns().ID, claim.ID).
+		Where(dao.GameReviewClaim.Columns().Version, claim.Version).
+		Delete()
+	if err != nil {
+		return
+	}
+
+	g.Log().Infof(ctx, "[Review] 审核任务租约到期，自动释放: game_id=%d, reviewer_id=%d", claim.GameID, claim.ReviewerID)
+	return nil
+}
+
+// claimGame 领取游戏审核任务
+// 未被领取时新建领取记录；自己已领取时续期；他人领取但租约过期时接管
+func (r *review) claimGame(ctx context.Context, gameID int64, userInfo *model.User) (out *model.ReviewClaim, err error) {
+	leaseExpireTime := gtime.Now().Add(r.claimLease(ctx))
+	err = g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		var (
+			claim   entity.GameReviewClaim
+			version int
+		)
+		err := dao.GameReviewClaim.Ctx(ctx).
+			Where(dao.GameReviewClaim.Columns().GameID, gameID).
+			LockUpdate().
+			Scan(&claim)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if err == sql.ErrNoRows {
+			_, err = dao.GameReviewClaim.Ctx(ctx).Data(map[string]interface{}{
+				dao.GameReviewClaim.Columns().GameID:          gameID,
+				dao.GameReviewClaim.Columns().ReviewerID:      userInfo.ID,
+				dao.GameReviewClaim.Columns().ReviewerName:    userInfo.Name,
+				dao.GameReviewClaim.Columns().LeaseExpireTime: leaseExpireTime,
+				dao.GameReviewClaim.Columns().Version:         0,
+			}).Insert()
+			if err != nil {
+				if strings.Contains(err.Error(), "Duplicate entry") {
+					return fmt.Errorf("游戏已被其他审核人员领取")
+				}
+				return err
+			}
+		} else {
+			if claim.ReviewerID != userInfo.ID && claim.LeaseExpireTime.After(gtime.Now()) {
+				return fmt.Errorf("游戏已被审核人员(%s)领取", claim.ReviewerName)
+			}
+			version = claim.Version + 1
+			_, err = dao.GameReviewClaim.Ctx(ctx).
+				Where(dao.GameReviewClaim.Columns().ID, claim.ID).
+				Data(map[string]interface{}{
+					dao.GameReviewClaim.Columns().ReviewerID:      userInfo.ID,
+					dao.GameReviewClaim.Columns().ReviewerName:    userInfo.Name,
+					dao.GameReviewClaim.Columns().LeaseExpireTime: leaseExpireTime,
+					dao.GameReviewClaim.Columns().Version:         version,
+				}).Update()
+			if err != nil {
+				return err
+			}
+		}
+
+		return addClaimExpireTask(ctx, tx, gameID, version, leaseExpireTime)
+	})
+	if err != nil {
+		return
+	}
+
+	service.AsyncTask().WakeUp(model.AsyncTaskTypeReviewClaimExpire)
+	return r.GetClaim(ctx, gameID)
+}
+
+// requiredApprovals 游戏需要的审核通过次数
+func (r *review) requiredApprovals(ctx context.Context, gameInfo *model.Game) (int, error) {
+	if !g.Cfg().MustGet(ctx, "review.twoReviewerForNewDeveloper", false).Bool() {
+		return 1, nil
+	}
+
+	// 开发商已有审核通过的游戏，不是首次提交
+	exists, err := dao.Game.Ctx(ctx).
+		Where(dao.Game.Columns().Developer, gameInfo.Developer).
+		WhereNot(dao.Game.Columns().ID, gameInfo.ID).
+		WhereIn(dao.Game.Columns().Status, []int{
+			int(model.GameStatusApproved),
+			int(model.GameStatusPreRegister),
+			int(model.GameStatusPublished),
+			int(model.GameStatusUnpublished),
+		}).
+		Exist()
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return 1, nil
+	}
+	return 2, nil
+}
+
+func (r *review) claimLease(ctx context.Context) time.Duration {
+	lease := g.Cfg().MustGet(ctx, "review.claimLease", defaultClaimLease).Duration()
+	if lease <= 0 {
+		return defaultClaimLease
+	}
+	return lease
+}
+
+// addClaimExpireTask 添加租约到期自动释放的定时任务
+func addClaimExpireTask(ctx context.Context, tx gdb.TX, gameID int64, version int, leaseExpireTime *gtime.Time) error {
+	contentBytes, err := model.MarshalAsyncTaskPayload(&model.ReviewClaimExpireTask{
+		GameID:       gameID,
+		ClaimVersion: version,
+	})
+	if err != nil {
+		return fmt.Errorf("序列化任务内容失败: %v", err)
+	}
+
+	customID := fmt.Sprintf("review_claim_expire_%d", gameID)
+	// 重新领取或续期时替换上一次的释放任务
+	err = service.AsyncTask().Upsert(ctx, tx, model.AsyncTaskTypeReviewClaimExpire, customID, contentBytes, leaseExpireTime)
+	if err != nil {
+		return fmt.Errorf("添加审核任务自动释放任务失败: %v", err)
+	}
+	return nil
+}
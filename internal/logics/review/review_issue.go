@@ -0,0 +1,207 @@
+package review
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"GameEngine/internal/service"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+var (
+	ErrReviewIssueNotExists = errors.New("审核问题不存在")
+)
+
+// AddIssues 记录审核拒绝时的问题
+// 拒绝原因必须来自启用的拒绝原因目录，未指定字段时使用拒绝原因的默认字段
+func (r *review) AddIssues(ctx context.Context, gameID int64, issues []*model.ReviewIssue) (err error) {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	rejectCodes, err := r.getEnabledRejectCodes(ctx)
+	if err != nil {
+		return
+	}
+
+	var (
+		reviewerID   int64
+		reviewerName string
+	)
+	if userInfo, err := model.GetUserInfo(ctx); err == nil {
+		reviewerID = userInfo.ID
+		reviewerName = userInfo.Name
+	}
+
+	dataInsert := make([]map[string]interface{}, 0, len(issues))
+	for _, issue := range issues {
+		rejectCode, ok := rejectCodes[issue.Code]
+		if !ok {
+			return fmt.Errorf("拒绝原因不存在或已停用: %s", issue.Code)
+		}
+		field := issue.Field
+		if field == "" {
+			field = rejectCode.Field
+		}
+		if field == "" {
+			field = model.ReviewFieldOther
+		}
+		if !model.IsValidReviewField(field) {
+			return fmt.Errorf("不支持的字段: %s", field)
+		}
+
+		dataInsert = append(dataInsert, map[string]interface{}{
+			dao.GameReviewIssue.Columns().GameID:       gameID,
+			dao.GameReviewIssue.Columns().Code:         issue.Code,
+			dao.GameReviewIssue.Columns().Field:        string(field),
+			dao.GameReviewIssue.Columns().Comment:      issue.Comment,
+			dao.GameReviewIssue.Columns().Status:       int(model.ReviewIssueStatusOpen),
+			dao.GameReviewIssue.Columns().ReviewerID:   reviewerID,
+			dao.GameReviewIssue.Columns().ReviewerName: reviewerName,
+		})
+	}
+
+	_, err = dao.GameReviewIssue.Ctx(ctx).Data(dataInsert).Insert()
+	return
+}
+
+// ListIssues 获取游戏的审核问题及讨论记录，按时间倒序
+func (r *review) ListIssues(ctx context.Context, gameID int64, onlyOpen bool) (outs []*model.ReviewIssue, err error) {
+	query := dao.GameReviewIssue.Ctx(ctx).Where(dao.GameReviewIssue.Columns().GameID, gameID)
+	if onlyOpen {
+		query = query.Where(dao.GameReviewIssue.Columns().Status, int(model.ReviewIssueStatusOpen))
+	}
+
+	var entities []*entity.GameReviewIssue
+	err = query.OrderDesc(dao.GameReviewIssue.Columns().ID).Scan(&entities)
+	if err != nil {
+		return
+	}
+	if len(entities) == 0 {
+		return
+	}
+
+	issueIDs := make([]int64, 0, len(entities))
+	issueMap := make(map[int64]*model.ReviewIssue, len(entities))
+	for _, entity := range entities {
+		issue := model.ConvertReviewIssueEntityToModel(entity)
+		outs = append(outs, issue)
+		issueIDs = append(issueIDs, issue.ID)
+		issueMap[issue.ID] = issue
+	}
+
+	var comments []*entity.GameReviewComment
+	err = dao.GameReviewComment.Ctx(ctx).
+		WhereIn(dao.GameReviewComment.Columns().IssueID, issueIDs).
+		OrderAsc(dao.GameReviewComment.Columns().ID).
+		Scan(&comments)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments {
+		if issue, ok := issueMap[comment.IssueID]; ok {
+			issue.Comments = append(issue.Comments, model.ConvertReviewCommentEntityToModel(comment))
+		}
+	}
+	return
+}
+
+// ResolveIssue 标记审核问题为已解决
+func (r *review) ResolveIssue(ctx context.Context, gameID, issueID int64) (err error) {
+	userInfo, err := model.GetUserInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	result, err := dao.GameReviewIssue.Ctx(ctx).
+		Where(dao.GameReviewIssue.Columns().ID, issueID).
+		Where(dao.GameReviewIssue.Columns().GameID, gameID).
+		Where(dao.GameReviewIssue.Columns().Status, int(model.ReviewIssueStatusOpen)).
+		Data(map[string]interface{}{
+			dao.GameReviewIssue.Columns().Status:      int(model.ReviewIssueStatusResolved),
+			dao.GameReviewIssue.Columns().ResolvedBy:  userInfo.ID,
+			dao.GameReviewIssue.Columns().ResolveTime: gtime.Now(),
+		}).Update()
+	if err != nil {
+		return
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("审核问题不存在或已解决")
+	}
+	return
+}
+
+// AddComment 回复审核问题，审核人员和开发者都可以回复
+func (r *review) AddComment(ctx context.Context, gameID, issueID int64, content string) (id int64, err error) {
+	userInfo, err := model.GetUserInfo(ctx)
+	if err != nil {
+		return
+	}
+
+	exists, err := dao.GameReviewIssue.Ctx(ctx).
+		Where(dao.GameReviewIssue.Columns().ID, issueID).
+		Where(dao.GameReviewIssue.Columns().GameID, gameID).
+		Exist()
+	if err != nil {
+		return
+	}
+	if !exists {
+		return 0, ErrReviewIssueNotExists
+	}
+
+	id, err = dao.GameReviewComment.Ctx(ctx).Data(map[string]interface{}{
+		dao.GameReviewComment.Columns().IssueID:  issueID,
+		dao.GameReviewComment.Columns().GameID:   gameID,
+		dao.GameReviewComment.Columns().UserID:   userInfo.ID,
+		dao.GameReviewComment.Columns().UserName: userInfo.Name,
+		dao.GameReviewComment.Columns().Content:  service.SensitiveWord().Mask(ctx, content),
+	}).InsertAndGetId()
+	return
+}
+
+// NotifyRejected 审核拒绝后通过消息队列通知开发者
+// 游戏没有记录所属开发者账号，通知最近一次提交审核的用户
+func (r *review) NotifyRejected(ctx context.Context, gameInfo *model.Game, reason string, issues []*model.ReviewIssue) (err error) {
+	submitterID, err := dao.GameStatusHistory.Ctx(ctx).
+		Where(dao.GameStatusHistory.Columns().GameID, gameInfo.ID).
+		Where(dao.GameStatusHistory.Columns().Event, int(model.SubmitForReview)).
+		WhereGT(dao.GameStatusHistory.Columns().OperatorID, 0).
+		OrderDesc(dao.GameStatusHistory.Columns().ID).
+		Value(dao.GameStatusHistory.Columns().OperatorID)
+	if err != nil {
+		return
+	}
+	if submitterID.IsEmpty() {
+		return nil
+	}
+
+	issueList := make([]map[string]interface{}, 0, len(issues))
+	for _, issue := range issues {
+		issueList = append(issueList, map[string]interface{}{
+			"code":    issue.Code,
+			"field":   issue.Field,
+			"comment": issue.Comment,
+		})
+	}
+
+	var body map[string]interface{} = make(map[string]interface{})
+	body["user_ids"] = []string{submitterID.String()}
+	body["content"] = map[string]interface{}{
+		"title":     "游戏审核未通过",
+		"game_id":   gameInfo.ID,
+		"game_name": gameInfo.Name,
+		"reason":    reason,
+		"issues":    issueList,
+		"message":   "游戏审核未通过，请登录游戏引擎查看审核意见",
+	}
+
+	return service.MQ().Publish(ctx, "core.push.users", body)
+}
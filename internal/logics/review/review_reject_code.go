@@ -0,0 +1,121 @@
+package review
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrRejectCodeExists    = errors.New("拒绝原因编码已存在")
+	ErrRejectCodeNotExists = errors.New("拒绝原因不存在")
+)
+
+func (r *review) CreateRejectCode(ctx context.Context, in *model.ReviewRejectCode) (id int64, err error) {
+	if in.Field != "" && !model.IsValidReviewField(in.Field) {
+		return 0, fmt.Errorf("不支持的字段: %s", in.Field)
+	}
+
+	id, err = dao.ReviewRejectCode.Ctx(ctx).Data(map[string]interface{}{
+		dao.ReviewRejectCode.Columns().Code:        in.Code,
+		dao.ReviewRejectCode.Columns().Title:       in.Title,
+		dao.ReviewRejectCode.Columns().Description: in.Description,
+		dao.ReviewRejectCode.Columns().Field:       string(in.Field),
+		dao.ReviewRejectCode.Columns().Enabled:     boolToInt(in.Enabled),
+	}).InsertAndGetId()
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			err = ErrRejectCodeExists
+		}
+		return
+	}
+	return
+}
+
+// UpdateRejectCode 更新拒绝原因，编码不可修改，已记录的问题通过编码关联
+func (r *review) UpdateRejectCode(ctx context.Context, in *model.ReviewRejectCode) (err error) {
+	if in.Field != "" && !model.IsValidReviewField(in.Field) {
+		return fmt.Errorf("不支持的字段: %s", in.Field)
+	}
+
+	result, err := dao.ReviewRejectCode.Ctx(ctx).
+		Where(dao.ReviewRejectCode.Columns().ID, in.ID).
+		Data(map[string]interface{}{
+			dao.ReviewRejectCode.Columns().Title:       in.Title,
+			dao.ReviewRejectCode.Columns().Description: in.Description,
+			dao.ReviewRejectCode.Columns().Field:       string(in.Field),
+			dao.ReviewRejectCode.Columns().Enabled:     boolToInt(in.Enabled),
+		}).Update()
+	if err != nil {
+		return
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		return ErrRejectCodeNotExists
+	}
+	return
+}
+
+// DeleteRejectCode 删除拒绝原因，已被引用的拒绝原因只能停用
+func (r *review) DeleteRejectCode(ctx context.Context, id int64) (err error) {
+	var rejectCode entity.ReviewRejectCode
+	err = dao.ReviewRejectCode.Ctx(ctx).Where(dao.ReviewRejectCode.Columns().ID, id).Scan(&rejectCode)
+	if err != nil {
+		return ErrRejectCodeNotExists
+	}
+
+	used, err := dao.GameReviewIssue.Ctx(ctx).Where(dao.GameReviewIssue.Columns().Code, rejectCode.Code).Exist()
+	if err != nil {
+		return
+	}
+	if used {
+		return fmt.Errorf("拒绝原因已被使用，请停用")
+	}
+
+	_, err = dao.ReviewRejectCode.Ctx(ctx).Where(dao.ReviewRejectCode.Columns().ID, id).Delete()
+	return
+}
+
+func (r *review) ListRejectCodes(ctx context.Context, onlyEnabled bool) (outs []*model.ReviewRejectCode, err error) {
+	query := dao.ReviewRejectCode.Ctx(ctx)
+	if onlyEnabled {
+		query = query.Where(dao.ReviewRejectCode.Columns().Enabled, 1)
+	}
+
+	var entities []*entity.ReviewRejectCode
+	err = query.OrderAsc(dao.ReviewRejectCode.Columns().Code).Scan(&entities)
+	if err != nil {
+		return
+	}
+	for _, entity := range entities {
+		outs = append(outs, model.ConvertReviewRejectCodeEntityToModel(entity))
+	}
+	return
+}
+
+// getEnabledRejectCodes 获取启用的拒绝原因，key 为编码
+func (r *review) getEnabledRejectCodes(ctx context.Context) (out map[string]*model.ReviewRejectCode, err error) {
+	rejectCodes, err := r.ListRejectCodes(ctx, true)
+	if err != nil {
+		return
+	}
+	out = make(map[string]*model.ReviewRejectCode, len(rejectCodes))
+	for _, rejectCode := range rejectCodes {
+		out[rejectCode.Code] = rejectCode
+	}
+	return
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,198 @@
+package search
+
+import (
+	"GameEngine/internal/model"
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// facet 参与分面统计的筛选条件
+type facet int
+
+const (
+	facetNone facet = iota
+	facetCategory
+	facetTag
+	facetDistributeType
+)
+
+// filter 搜索条件编译后的筛选器
+type filter struct {
+	statuses        []model.GameStatus
+	categoryIDs     []int64
+	tagIDs          []int64
+	distributeTypes []model.GameDistributeType
+	minRating       float64
+	maxRating       float64
+	publishFrom     int64
+	publishTo       int64
+	developer       string
+}
+
+func newFilter(in *model.GameSearchQuery) *filter {
+	f := &filter{
+		statuses:        in.Statuses,
+		categoryIDs:     in.CategoryIDs,
+		tagIDs:          in.TagIDs,
+		distributeTypes: in.DistributeTypes,
+		minRating:       in.MinRating,
+		maxRating:       in.MaxRating,
+		developer:       strings.TrimSpace(normalizeText(in.Developer)),
+	}
+	if in.PublishFrom != nil {
+		f.publishFrom = in.PublishFrom.Unix()
+	}
+	if in.PublishTo != nil {
+		f.publishTo = in.PublishTo.Unix()
+	}
+	return f
+}
+
+// match 游戏是否满足除 skip 以外的所有条件，skip 为 facetNone 时检查所有条件
+func (f *filter) match(doc *document, skip facet) bool {
+	if len(f.statuses) > 0 && !slices.Contains(f.statuses, doc.Status) {
+		return false
+	}
+	if skip != facetCategory && len(f.categoryIDs) > 0 && !containsAny(doc.Categories, f.categoryIDs) {
+		return false
+	}
+	if skip != facetTag && len(f.tagIDs) > 0 && !containsAny(doc.Tags, f.tagIDs) {
+		return false
+	}
+	if skip != facetDistributeType && len(f.distributeTypes) > 0 && !slices.Contains(f.distributeTypes, doc.DistributeType) {
+		return false
+	}
+	if f.minRating > 0 && doc.AverageRating < f.minRating {
+		return false
+	}
+	if f.maxRating > 0 && doc.AverageRating > f.maxRating {
+		return false
+	}
+	if f.publishFrom > 0 && (doc.PublishTime == 0 || doc.PublishTime < f.publishFrom) {
+		return false
+	}
+	if f.publishTo > 0 && (doc.PublishTime == 0 || doc.PublishTime > f.publishTo) {
+		return false
+	}
+	if f.developer != "" && doc.Developer != f.developer {
+		return false
+	}
+	return true
+}
+
+func containsAny(values []facetValue, ids []int64) bool {
+	for _, value := range values {
+		if slices.Contains(ids, value.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// facetCounter 统计各筛选项的游戏数量
+type facetCounter struct {
+	counts map[int64]int
+	names  map[int64]string
+}
+
+func newFacetCounter() *facetCounter {
+	return &facetCounter{
+		counts: make(map[int64]int),
+		names:  make(map[int64]string),
+	}
+}
+
+func (c *facetCounter) add(value int64, name string) {
+	c.counts[value]++
+	c.names[value] = name
+}
+
+// result 按数量从多到少排序，数量相同时按值从小到大
+func (c *facetCounter) result() (out []*model.GameSearchFacet) {
+	out = make([]*model.GameSearchFacet, 0, len(c.counts))
+	for value, count := range c.counts {
+		out = append(out, &model.GameSearchFacet{
+			Value: value,
+			Name:  c.names[value],
+			Count: count,
+		})
+	}
+	slices.SortFunc(out, func(a, b *model.GameSearchFacet) int {
+		if result := cmp.Compare(b.Count, a.Count); result != 0 {
+			return result
+		}
+		return cmp.Compare(a.Value, b.Value)
+	})
+	return
+}
+
+// apply 筛选命中的游戏并统计分面，每个分面不使用自身的条件统计，选中的分面之间是“且”的关系
+func (f *filter) apply(matches []*match) (hits []*match, facets *model.GameSearchFacets) {
+	categories, tags, distributeTypes := newFacetCounter(), newFacetCounter(), newFacetCounter()
+	hits = make([]*match, 0, len(matches))
+	for _, m := range matches {
+		doc := m.doc
+		if f.match(doc, facetNone) {
+			hits = append(hits, m)
+		}
+		if f.match(doc, facetCategory) {
+			for _, category := range doc.Categories {
+				categories.add(category.ID, category.Name)
+			}
+		}
+		if f.match(doc, facetTag) {
+			for _, tag := range doc.Tags {
+				tags.add(tag.ID, tag.Name)
+			}
+		}
+		if f.match(doc, facetDistributeType) {
+			distributeTypes.add(int64(doc.DistributeType), model.GetGameDistributeTypeText(doc.DistributeType))
+		}
+	}
+
+	facets = &model.GameSearchFacets{
+		Categories:      categories.result(),
+		Tags:            tags.result(),
+		DistributeTypes: distributeTypes.result(),
+	}
+	return
+}
+
+// sortMatches 排序，排序字段相同时按相关度，再按游戏ID从大到小，保证分页稳定
+func sortMatches(matches []*match, sort model.GameSearchSort) {
+	var primary func(a, b *document) int
+	switch sort {
+	case model.GameSearchSortNewest:
+		primary = func(a, b *document) int {
+			return cmp.Compare(b.PublishTime, a.PublishTime)
+		}
+	case model.GameSearchSortRating:
+		primary = func(a, b *document) int {
+			if c := cmp.Compare(b.AverageRating, a.AverageRating); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.RatingCount, a.RatingCount)
+		}
+	case model.GameSearchSortDownloads:
+		primary = func(a, b *document) int {
+			return cmp.Compare(b.DownloadCount, a.DownloadCount)
+		}
+	case model.GameSearchSortHot:
+		primary = func(a, b *document) int {
+			return cmp.Compare(b.HotScore, a.HotScore)
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b *match) int {
+		if primary != nil {
+			if c := primary(a.doc, b.doc); c != 0 {
+				return c
+			}
+		}
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.doc.GameID, a.doc.GameID)
+	})
+}
@@ -0,0 +1,152 @@
+package search
+
+import (
+	"GameEngine/internal/model"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+func testMatches() []*match {
+	docs := []*document{
+		{GameID: 1, DistributeType: model.GameDistributeTypeAPK, Categories: []facetValue{{1, "动作"}}, Tags: []facetValue{{10, "单机"}},
+			AverageRating: 4.5, PublishTime: 1000, Developer: "tencent"},
+		{GameID: 2, DistributeType: model.GameDistributeTypeAPK, Categories: []facetValue{{1, "动作"}, {2, "策略"}}, Tags: []facetValue{{11, "联机"}},
+			AverageRating: 3, PublishTime: 2000},
+		{GameID: 3, DistributeType: model.GameDistributeTypeLink, Categories: []facetValue{{2, "策略"}}, Tags: []facetValue{{10, "单机"}},
+			AverageRating: 4},
+		{GameID: 4, DistributeType: model.GameDistributeTypeLink, Categories: []facetValue{{3, "休闲"}}},
+	}
+	matches := make([]*match, 0, len(docs))
+	for _, doc := range docs {
+		matches = append(matches, &match{doc: doc, score: 1})
+	}
+	return matches
+}
+
+// facetCounts 分面统计结果，格式为 值:数量
+func facetCounts(facets []*model.GameSearchFacet) (out []string) {
+	for _, facet := range facets {
+		out = append(out, fmt.Sprintf("%d:%d", facet.Value, facet.Count))
+	}
+	return
+}
+
+func TestFilterApply(t *testing.T) {
+	apk, link := fmt.Sprint(int(model.GameDistributeTypeAPK)), fmt.Sprint(int(model.GameDistributeTypeLink))
+
+	tests := []struct {
+		name            string
+		query           *model.GameSearchQuery
+		wantHits        []int64
+		wantCategories  []string
+		wantTags        []string
+		wantDistributes []string
+	}{
+		{
+			name:            "没有条件",
+			query:           &model.GameSearchQuery{},
+			wantHits:        []int64{1, 2, 3, 4},
+			wantCategories:  []string{"1:2", "2:2", "3:1"},
+			wantTags:        []string{"10:2", "11:1"},
+			wantDistributes: []string{apk + ":2", link + ":2"},
+		},
+		{
+			name:            "分类不使用自身的条件统计",
+			query:           &model.GameSearchQuery{CategoryIDs: []int64{1}},
+			wantHits:        []int64{1, 2},
+			wantCategories:  []string{"1:2", "2:2", "3:1"},
+			wantTags:        []string{"10:1", "11:1"},
+			wantDistributes: []string{apk + ":2"},
+		},
+		{
+			name:            "选中的分面之间是且的关系",
+			query:           &model.GameSearchQuery{CategoryIDs: []int64{1}, DistributeTypes: []model.GameDistributeType{model.GameDistributeTypeLink}},
+			wantHits:        []int64{},
+			wantCategories:  []string{"2:1", "3:1"},
+			wantDistributes: []string{apk + ":2"},
+		},
+		{
+			name:            "同一分面的多个值满足任一即可",
+			query:           &model.GameSearchQuery{TagIDs: []int64{10, 11}},
+			wantHits:        []int64{1, 2, 3},
+			wantCategories:  []string{"1:2", "2:2"},
+			wantTags:        []string{"10:2", "11:1"},
+			wantDistributes: []string{apk + ":2", link + ":1"},
+		},
+		{
+			name:            "评分范围",
+			query:           &model.GameSearchQuery{MinRating: 4, MaxRating: 4.5},
+			wantHits:        []int64{1, 3},
+			wantCategories:  []string{"1:1", "2:1"},
+			wantTags:        []string{"10:2"},
+			wantDistributes: []string{apk + ":1", link + ":1"},
+		},
+		{
+			name:            "未发布的游戏不满足发布时间条件",
+			query:           &model.GameSearchQuery{PublishFrom: gtime.NewFromTimeStamp(1500)},
+			wantHits:        []int64{2},
+			wantCategories:  []string{"1:1", "2:1"},
+			wantTags:        []string{"11:1"},
+			wantDistributes: []string{apk + ":1"},
+		},
+		{
+			name:            "开发商忽略大小写完全匹配",
+			query:           &model.GameSearchQuery{Developer: " Tencent "},
+			wantHits:        []int64{1},
+			wantCategories:  []string{"1:1"},
+			wantTags:        []string{"10:1"},
+			wantDistributes: []string{apk + ":1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, facets := newFilter(tt.query).apply(testMatches())
+
+			gotHits := make([]int64, 0, len(hits))
+			for _, hit := range hits {
+				gotHits = append(gotHits, hit.doc.GameID)
+			}
+			if !reflect.DeepEqual(gotHits, tt.wantHits) {
+				t.Errorf("hits = %v, want %v", gotHits, tt.wantHits)
+			}
+			if got := facetCounts(facets.Categories); !reflect.DeepEqual(got, tt.wantCategories) {
+				t.Errorf("categories = %v, want %v", got, tt.wantCategories)
+			}
+			if got := facetCounts(facets.Tags); !reflect.DeepEqual(got, tt.wantTags) {
+				t.Errorf("tags = %v, want %v", got, tt.wantTags)
+			}
+			if got := facetCounts(facets.DistributeTypes); !reflect.DeepEqual(got, tt.wantDistributes) {
+				t.Errorf("distribute types = %v, want %v", got, tt.wantDistributes)
+			}
+		})
+	}
+}
+
+func TestSortMatches(t *testing.T) {
+	tests := []struct {
+		name string
+		sort model.GameSearchSort
+		want []int64
+	}{
+		{"相关度相同时按游戏ID从大到小", model.GameSearchSortRelevance, []int64{4, 3, 2, 1}},
+		{"未发布的游戏排在最后", model.GameSearchSortNewest, []int64{2, 1, 4, 3}},
+		{"评分", model.GameSearchSortRating, []int64{1, 3, 2, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := testMatches()
+			sortMatches(matches, tt.sort)
+
+			got := make([]int64, 0, len(matches))
+			for _, m := range matches {
+				got = append(got, m.doc.GameID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortMatches(%s) = %v, want %v", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
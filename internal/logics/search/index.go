@@ -0,0 +1,423 @@
+package search
+
+import (
+	"GameEngine/internal/common/pinyin"
+	"GameEngine/internal/model"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// BM25 参数
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// 各字段的权重，同一词项在权重高的字段中出现时词频更高
+const (
+	fieldWeightName        = 5.0
+	fieldWeightCategory    = 2.0
+	fieldWeightTag         = 2.0
+	fieldWeightDeveloper   = 1.5
+	fieldWeightPublisher   = 1.5
+	fieldWeightDescription = 1.0
+	fieldWeightDetails     = 0.5
+)
+
+// boostConfig 相关度加权，在 BM25 得分的基础上相乘
+type boostConfig struct {
+	NameExact    float64 // 游戏名称与查询完全相同
+	NamePrefix   float64 // 游戏名称以查询开头
+	NameContains float64 // 游戏名称包含查询
+	Popularity   float64 // 热度加权系数，得分乘以 1 + Popularity*log10(1+热度)
+	Pinyin       float64 // 拼音匹配的得分系数
+	MinMatch     float64 // 至少命中查询词项的比例
+}
+
+// 拼音匹配
+const (
+	// minPinyinQueryLength 拼音查询的最小长度，过短的查询按拼音匹配没有区分度
+	minPinyinQueryLength = 2
+	// minPinyinContainsLength 拼音查询至少多长时才匹配名称中间的拼音
+	minPinyinContainsLength = 3
+	// pinyinContainsRatio 匹配中间的拼音时，得分相对于前缀匹配的比例
+	pinyinContainsRatio = 0.5
+)
+
+// source 建立索引所需的游戏数据
+type source struct {
+	Game            *model.Game
+	NamePinyin      *pinyin.Forms
+	DeveloperPinyin *pinyin.Forms
+	Categories      []*model.Category
+	Tags            []*tag
+}
+
+// tag 标签及其拼音
+type tag struct {
+	*model.Tag
+	Pinyin *pinyin.Forms
+}
+
+// pinyinText 游戏名称、开发商、标签的一种拼音形式(全拼或首字母)
+type pinyinText struct {
+	Text   string
+	Weight float64
+	Name   bool // 游戏名称的拼音，匹配时按名称加权
+}
+
+// facetValue 分类或标签
+type facetValue struct {
+	ID   int64
+	Name string
+}
+
+// document 已索引的游戏，创建后不再修改，游戏变更时整体替换
+type document struct {
+	GameID     int64
+	Status     model.GameStatus
+	Name       string // compactText 后的名称
+	Popularity float64
+	Length     float64            // 加权后的词项总数
+	Terms      map[string]float64 // 词项及加权词频，删除时用于清理倒排表
+	Pinyin     []pinyinText
+
+	// 筛选和排序
+	DistributeType model.GameDistributeType
+	Categories     []facetValue
+	Tags           []facetValue
+	Developer      string // normalizeText 后的开发商
+	AverageRating  float64
+	RatingCount    int64
+	DownloadCount  int64
+	HotScore       float64
+	PublishTime    int64 // 发布时间戳，未发布时为 0
+	CreateTime     int64
+}
+
+// index 倒排索引，整体重建时创建新的索引替换，单个游戏变更时在当前索引上更新
+type index struct {
+	mutex       sync.RWMutex
+	dict        *dictionary
+	docs        map[int64]*document
+	postings    map[string]map[int64]float64 // 词项 -> 游戏ID -> 加权词频
+	totalLength float64
+}
+
+func newIndex(dict *dictionary) *index {
+	return &index{
+		dict:     dict,
+		docs:     make(map[int64]*document),
+		postings: make(map[string]map[int64]float64),
+	}
+}
+
+// popularity 热度：下载、收藏、评分、预约次数加权求和
+func popularity(game *model.Game) float64 {
+	return float64(game.DownloadCount) + 3*float64(game.FavoriteCount) + 2*float64(game.RatingCount) + float64(game.ReserveCount)
+}
+
+// hotScore 与热门榜单的排序相同
+func hotScore(game *model.Game) float64 {
+	return float64(game.DownloadCount)*0.5 + float64(game.FavoriteCount)*0.3 + float64(game.RatingScore)*0.2
+}
+
+func (idx *index) analyze(src *source) *document {
+	game := src.Game
+	doc := &document{
+		GameID:     game.ID,
+		Status:     game.Status,
+		Name:       compactText(game.Name),
+		Popularity: popularity(game),
+		Terms:      make(map[string]float64),
+
+		DistributeType: game.DistributeType,
+		Developer:      strings.TrimSpace(normalizeText(game.Developer)),
+		AverageRating:  game.AverageRating,
+		RatingCount:    game.RatingCount,
+		DownloadCount:  game.DownloadCount,
+		HotScore:       hotScore(game),
+	}
+	if game.PublishTime != nil {
+		doc.PublishTime = game.PublishTime.Unix()
+	}
+	if game.CreateTime != nil {
+		doc.CreateTime = game.CreateTime.Unix()
+	}
+
+	addField := func(text string, weight float64) {
+		for _, term := range tokenize(text, idx.dict, true) {
+			doc.Terms[term] += weight
+			doc.Length += weight
+		}
+	}
+	addField(game.Name, fieldWeightName)
+	for _, category := range src.Categories {
+		addField(category.Name, fieldWeightCategory)
+		doc.Categories = append(doc.Categories, facetValue{ID: category.ID, Name: category.Name})
+	}
+	for _, tag := range src.Tags {
+		addField(tag.Name, fieldWeightTag)
+		doc.Tags = append(doc.Tags, facetValue{ID: tag.ID, Name: tag.Name})
+	}
+	addField(game.Developer, fieldWeightDeveloper)
+	addField(game.Publisher, fieldWeightPublisher)
+	addField(game.Description, fieldWeightDescription)
+	addField(game.Details, fieldWeightDetails)
+
+	addPinyin := func(forms *pinyin.Forms, weight float64, name bool) {
+		if forms == nil {
+			return
+		}
+		for _, text := range append(forms.Full, forms.Initials...) {
+			doc.Pinyin = append(doc.Pinyin, pinyinText{Text: text, Weight: weight, Name: name})
+		}
+	}
+	addPinyin(src.NamePinyin, fieldWeightName, true)
+	addPinyin(src.DeveloperPinyin, fieldWeightDeveloper, false)
+	for _, tag := range src.Tags {
+		addPinyin(tag.Pinyin, fieldWeightTag, false)
+	}
+	return doc
+}
+
+// put 添加或替换游戏
+func (idx *index) put(src *source) {
+	doc := idx.analyze(src)
+
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.removeLocked(doc.GameID)
+	idx.docs[doc.GameID] = doc
+	idx.totalLength += doc.Length
+	for term, tf := range doc.Terms {
+		postings, ok := idx.postings[term]
+		if !ok {
+			postings = make(map[int64]float64)
+			idx.postings[term] = postings
+		}
+		postings[doc.GameID] = tf
+	}
+}
+
+func (idx *index) remove(gameID int64) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	idx.removeLocked(gameID)
+}
+
+func (idx *index) removeLocked(gameID int64) {
+	doc, ok := idx.docs[gameID]
+	if !ok {
+		return
+	}
+	for term := range doc.Terms {
+		postings := idx.postings[term]
+		delete(postings, gameID)
+		if len(postings) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLength -= doc.Length
+	delete(idx.docs, gameID)
+}
+
+func (idx *index) size() (documents int, terms int) {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	return len(idx.docs), len(idx.postings)
+}
+
+// match 命中关键词的游戏及加权后的得分，关键词为空时返回所有游戏，得分只有热度加权
+// 关键词包含字母时同时按拼音匹配游戏名称、开发商和标签，得分与 BM25 得分相加
+func (idx *index) match(keyword string, boost *boostConfig) (matches []*match) {
+	idx.mutex.RLock()
+	defer idx.mutex.RUnlock()
+
+	var scores map[int64]float64
+	if strings.TrimSpace(keyword) == "" {
+		scores = make(map[int64]float64, len(idx.docs))
+		for gameID := range idx.docs {
+			scores[gameID] = 1
+		}
+	} else {
+		scores = idx.bm25(keyword, boost)
+	}
+
+	pinyinHits := idx.matchPinyin(pinyinQueries(keyword))
+	if len(pinyinHits) > 0 && scores == nil {
+		scores = make(map[int64]float64, len(pinyinHits))
+	}
+	for gameID, hit := range pinyinHits {
+		scores[gameID] += hit.score * boost.Pinyin
+	}
+
+	query := compactText(keyword)
+	matches = make([]*match, 0, len(scores))
+	for gameID, score := range scores {
+		doc := idx.docs[gameID]
+		level := matchName(doc.Name, query)
+		if hit, ok := pinyinHits[gameID]; ok && hit.nameLevel > level {
+			level = hit.nameLevel
+		}
+		switch level {
+		case nameMatchExact:
+			score *= 1 + boost.NameExact
+		case nameMatchPrefix:
+			score *= 1 + boost.NamePrefix
+		case nameMatchContains:
+			score *= 1 + boost.NameContains
+		}
+		score *= 1 + boost.Popularity*math.Log10(1+doc.Popularity)
+		matches = append(matches, &match{doc: doc, score: score})
+	}
+	return matches
+}
+
+// nameMatchLevel 游戏名称与查询的匹配程度
+type nameMatchLevel int
+
+const (
+	nameMatchNone nameMatchLevel = iota
+	nameMatchContains
+	nameMatchPrefix
+	nameMatchExact
+)
+
+func matchName(name string, query string) nameMatchLevel {
+	switch {
+	case query == "":
+		return nameMatchNone
+	case name == query:
+		return nameMatchExact
+	case strings.HasPrefix(name, query):
+		return nameMatchPrefix
+	case strings.Contains(name, query):
+		return nameMatchContains
+	}
+	return nameMatchNone
+}
+
+// pinyinQueries 按拼音匹配时使用的查询：不包含字母时不按拼音匹配；包含汉字时(如“王者rongyao”“wz荣耀”)把汉字转换为拼音，
+// 分别按全拼和首字母组合
+func pinyinQueries(keyword string) (queries []string) {
+	query := compactText(keyword)
+	if strings.IndexFunc(query, isLatin) < 0 {
+		return nil
+	}
+	candidates := []string{query}
+	if pinyin.ContainsHan(query) {
+		forms := pinyin.Convert(query)
+		candidates = append(forms.Full, forms.Initials...)
+	}
+	for _, candidate := range candidates {
+		if len(candidate) >= minPinyinQueryLength {
+			queries = append(queries, candidate)
+		}
+	}
+	return
+}
+
+func isLatin(r rune) bool {
+	return r < unicode.MaxASCII && unicode.IsLetter(r)
+}
+
+// pinyinHit 按拼音命中的游戏
+type pinyinHit struct {
+	score     float64
+	nameLevel nameMatchLevel
+}
+
+// matchPinyin 查询是拼音的前缀时按字段权重计分，是拼音中间的一部分时得分减半；每个游戏取得分最高的匹配
+func (idx *index) matchPinyin(queries []string) (hits map[int64]*pinyinHit) {
+	if len(queries) == 0 {
+		return nil
+	}
+
+	hits = make(map[int64]*pinyinHit)
+	for gameID, doc := range idx.docs {
+		var best *pinyinHit
+		for _, text := range doc.Pinyin {
+			for _, query := range queries {
+				level := matchName(text.Text, query)
+				score := text.Weight
+				switch {
+				case level == nameMatchNone:
+					continue
+				case level == nameMatchContains && len(query) < minPinyinContainsLength:
+					continue
+				case level == nameMatchContains:
+					score *= pinyinContainsRatio
+				}
+				if best == nil {
+					best = &pinyinHit{}
+				}
+				best.score = max(best.score, score)
+				if text.Name && level > best.nameLevel {
+					best.nameLevel = level
+				}
+			}
+		}
+		if best != nil {
+			hits[gameID] = best
+		}
+	}
+	return
+}
+
+// match 命中的游戏，document 不会被修改，释放读锁后仍可以使用
+type match struct {
+	doc   *document
+	score float64
+}
+
+// bm25 计算命中的游戏的 BM25 得分，命中的词项比例低于 MinMatch 的游戏不返回
+func (idx *index) bm25(keyword string, boost *boostConfig) (scores map[int64]float64) {
+	terms := uniqueTerms(tokenize(keyword, idx.dict, false))
+	if len(terms) == 0 || len(idx.docs) == 0 {
+		return nil
+	}
+
+	total := float64(len(idx.docs))
+	avgLength := idx.totalLength / total
+	scores = make(map[int64]float64)
+	matched := make(map[int64]int)
+	for _, term := range terms {
+		postings := idx.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (total-df+0.5)/(df+0.5))
+		for gameID, tf := range postings {
+			norm := bm25K1 * (1 - bm25B + bm25B*idx.docs[gameID].Length/avgLength)
+			scores[gameID] += idf * tf * (bm25K1 + 1) / (tf + norm)
+			matched[gameID]++
+		}
+	}
+
+	minMatch := max(int(math.Floor(boost.MinMatch*float64(len(terms)))), 1)
+	for gameID, count := range matched {
+		if count < minMatch {
+			delete(scores, gameID)
+		}
+	}
+	return scores
+}
+
+func uniqueTerms(terms []string) (out []string) {
+	seen := make(map[string]struct{}, len(terms))
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		out = append(out, term)
+	}
+	return
+}
@@ -0,0 +1,142 @@
+package search
+
+import (
+	"GameEngine/internal/common/pinyin"
+	"GameEngine/internal/model"
+	"reflect"
+	"testing"
+)
+
+// testBoost 不使用热度加权，只比较相关度
+var testBoost = &boostConfig{
+	NameExact:    2,
+	NamePrefix:   1,
+	NameContains: 0.5,
+	Pinyin:       1,
+	MinMatch:     0.7,
+}
+
+type testGame struct {
+	id          int64
+	name        string
+	developer   string
+	description string
+	categories  []string
+}
+
+func newTestIndex(games ...testGame) *index {
+	idx := newIndex(newDictionary(nil))
+	for _, game := range games {
+		src := &source{
+			Game: &model.Game{
+				ID:          game.id,
+				Name:        game.name,
+				Developer:   game.developer,
+				Description: game.description,
+			},
+			NamePinyin:      pinyin.Convert(game.name),
+			DeveloperPinyin: pinyin.Convert(game.developer),
+		}
+		for i, name := range game.categories {
+			src.Categories = append(src.Categories, &model.Category{ID: int64(i + 1), Name: name})
+		}
+		idx.put(src)
+	}
+	return idx
+}
+
+// searchIDs 按相关度排序后的游戏ID
+func searchIDs(idx *index, keyword string) (ids []int64) {
+	matches := idx.match(keyword, testBoost)
+	sortMatches(matches, model.GameSearchSortRelevance)
+	for _, m := range matches {
+		ids = append(ids, m.doc.GameID)
+	}
+	return
+}
+
+func TestIndexBM25(t *testing.T) {
+	idx := newTestIndex(
+		testGame{id: 1, name: "王者荣耀", description: "多人在线竞技"},
+		testGame{id: 2, name: "荣耀战场", description: "一款射击游戏"},
+		testGame{id: 3, name: "星际前线", categories: []string{"射击"}},
+		testGame{id: 4, name: "开心消消乐", description: "休闲益智，三消玩法"},
+		testGame{id: 5, name: "三国志", description: "王者归来，历史策略"},
+		testGame{id: 6, name: "PUBG Mobile"},
+	)
+
+	tests := []struct {
+		name    string
+		keyword string
+		want    []int64
+	}{
+		{"只返回命中大部分词项的游戏", "王者荣耀", []int64{1}},
+		{"命中词项比例不足的游戏不返回", "荣耀战场", []int64{2}},
+		{"分类的权重高于描述", "射击", []int64{3, 2}},
+		{"名称前缀加权", "荣耀", []int64{2, 1}},
+		{"单个汉字按单字匹配", "消", []int64{4}},
+		{"全角和大小写归一化", "ＰＵＢＧ", []int64{6}},
+		{"没有命中", "赛车", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchIDs(idx, tt.keyword); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search %q = %v, want %v", tt.keyword, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexPutRemove(t *testing.T) {
+	idx := newTestIndex(
+		testGame{id: 1, name: "王者荣耀"},
+		testGame{id: 2, name: "荣耀战场"},
+	)
+
+	idx.put(&source{Game: &model.Game{ID: 1, Name: "三国志"}})
+	if got := searchIDs(idx, "王者"); got != nil {
+		t.Errorf("search after replace = %v, want nil", got)
+	}
+	idx.remove(2)
+	if got := searchIDs(idx, "荣耀"); got != nil {
+		t.Errorf("search after remove = %v, want nil", got)
+	}
+	if documents, terms := idx.size(); documents != 1 || terms != len(idx.docs[1].Terms) {
+		t.Errorf("size = %d, %d, want 1, %d", documents, terms, len(idx.docs[1].Terms))
+	}
+}
+
+func TestIndexPinyin(t *testing.T) {
+	idx := newTestIndex(
+		testGame{id: 1, name: "王者荣耀"},
+		testGame{id: 2, name: "荣耀战场"},
+		testGame{id: 3, name: "重庆火锅"},
+		testGame{id: 4, name: "星际前线", developer: "腾讯"},
+		testGame{id: 5, name: "PUBG刺激战场"},
+	)
+
+	tests := []struct {
+		name    string
+		keyword string
+		want    []int64
+	}{
+		{"首字母", "wzry", []int64{1}},
+		{"全拼前缀忽略大小写", "WangZhe", []int64{1}},
+		{"前缀匹配排在中间匹配之前", "rongyao", []int64{2, 1}},
+		{"过短的查询只匹配前缀", "ry", []int64{2}},
+		{"多音字的常用读法", "chongqing", []int64{3}},
+		{"多音字的其他读法", "zhongqing", []int64{3}},
+		{"开发商拼音", "tengxun", []int64{4}},
+		{"首字母保留字母串", "pubgcjzc", []int64{5}},
+		{"汉字和拼音混合", "王者rongyao", []int64{1}},
+		{"单个字母不按拼音匹配", "w", nil},
+		{"没有命中", "xiaoxiaole", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchIDs(idx, tt.keyword); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search %q = %v, want %v", tt.keyword, got, tt.want)
+			}
+		})
+	}
+}
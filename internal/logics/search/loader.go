@@ -0,0 +1,306 @@
+package search
+
+import (
+	"GameEngine/internal/common/pinyin"
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// loadBatchSize 重建索引时每次加载的游戏数量
+const loadBatchSize = 500
+
+// loadAllSources 按ID分批加载所有游戏，返回游戏数据和最大更新时间
+func loadAllSources(ctx context.Context) (sources []*source, maxUpdateTime *gtime.Time, err error) {
+	var lastID int64
+	for {
+		var games []*entity.Game
+		err = dao.Game.Ctx(ctx).
+			WhereGT(dao.Game.Columns().ID, lastID).
+			OrderAsc(dao.Game.Columns().ID).
+			Limit(loadBatchSize).
+			Scan(&games)
+		if err != nil {
+			return
+		}
+		if len(games) == 0 {
+			return
+		}
+
+		batch, err := loadSources(ctx, games)
+		if err != nil {
+			return nil, nil, err
+		}
+		sources = append(sources, batch...)
+		maxUpdateTime = latestUpdateTime(maxUpdateTime, games)
+		if len(games) < loadBatchSize {
+			return sources, maxUpdateTime, nil
+		}
+		lastID = games[len(games)-1].ID
+	}
+}
+
+// loadSourcesByIDs 加载指定游戏，已删除的游戏不返回
+func loadSourcesByIDs(ctx context.Context, gameIDs []int64) (sources []*source, err error) {
+	var games []*entity.Game
+	err = dao.Game.Ctx(ctx).WhereIn(dao.Game.Columns().ID, gameIDs).Scan(&games)
+	if err != nil {
+		return
+	}
+	return loadSources(ctx, games)
+}
+
+// loadUpdatedSources 加载更新时间不早于 since 的游戏
+func loadUpdatedSources(ctx context.Context, since *gtime.Time) (sources []*source, maxUpdateTime *gtime.Time, err error) {
+	var games []*entity.Game
+	err = dao.Game.Ctx(ctx).WhereGTE(dao.Game.Columns().UpdateTime, since).Scan(&games)
+	if err != nil {
+		return
+	}
+	sources, err = loadSources(ctx, games)
+	if err != nil {
+		return
+	}
+	return sources, latestUpdateTime(since, games), nil
+}
+
+// loadSources 补充游戏的分类和标签
+func loadSources(ctx context.Context, games []*entity.Game) (sources []*source, err error) {
+	if len(games) == 0 {
+		return nil, nil
+	}
+	gameIDs := make([]int64, 0, len(games))
+	for _, game := range games {
+		gameIDs = append(gameIDs, game.ID)
+	}
+
+	categories, err := loadCategories(ctx, gameIDs)
+	if err != nil {
+		return
+	}
+	tags, err := loadTags(ctx, gameIDs)
+	if err != nil {
+		return
+	}
+
+	sources = make([]*source, 0, len(games))
+	for _, game := range games {
+		sources = append(sources, &source{
+			Game:            model.ConvertGameEntityToModel(game),
+			NamePinyin:      storedPinyin(game.Name, game.NamePinyin, game.NameInitials),
+			DeveloperPinyin: storedPinyin(game.Developer, game.DeveloperPinyin, game.DeveloperInitials),
+			Categories:      categories[game.ID],
+			Tags:            tags[game.ID],
+		})
+	}
+	return
+}
+
+func loadCategories(ctx context.Context, gameIDs []int64) (out map[int64][]*model.Category, err error) {
+	var associations []*entity.GameCategory
+	err = dao.GameCategory.Ctx(ctx).WhereIn(dao.GameCategory.Columns().GameID, gameIDs).Scan(&associations)
+	if err != nil || len(associations) == 0 {
+		return
+	}
+
+	categoryIDs := make([]int64, 0, len(associations))
+	for _, association := range associations {
+		categoryIDs = append(categoryIDs, association.CategoryID)
+	}
+	var categories []*entity.Category
+	err = dao.Category.Ctx(ctx).WhereIn(dao.Category.Columns().ID, categoryIDs).Scan(&categories)
+	if err != nil {
+		return
+	}
+	categoryMap := make(map[int64]*model.Category, len(categories))
+	for _, category := range categories {
+		categoryMap[category.ID] = &model.Category{ID: category.ID, Name: category.Name}
+	}
+
+	out = make(map[int64][]*model.Category)
+	for _, association := range associations {
+		if category, ok := categoryMap[association.CategoryID]; ok {
+			out[association.GameID] = append(out[association.GameID], category)
+		}
+	}
+	return
+}
+
+func loadTags(ctx context.Context, gameIDs []int64) (out map[int64][]*tag, err error) {
+	var associations []*entity.GameTag
+	err = dao.GameTag.Ctx(ctx).WhereIn(dao.GameTag.Columns().GameID, gameIDs).Scan(&associations)
+	if err != nil || len(associations) == 0 {
+		return
+	}
+
+	tagIDs := make([]int64, 0, len(associations))
+	for _, association := range associations {
+		tagIDs = append(tagIDs, association.TagID)
+	}
+	var tags []*entity.Tag
+	err = dao.Tag.Ctx(ctx).WhereIn(dao.Tag.Columns().ID, tagIDs).Scan(&tags)
+	if err != nil {
+		return
+	}
+	tagMap := make(map[int64]*tag, len(tags))
+	for _, t := range tags {
+		tagMap[t.ID] = &tag{
+			Tag:    &model.Tag{ID: t.ID, Name: t.Name},
+			Pinyin: storedPinyin(t.Name, t.Pinyin, t.Initials),
+		}
+	}
+
+	out = make(map[int64][]*tag)
+	for _, association := range associations {
+		if t, ok := tagMap[association.TagID]; ok {
+			out[association.GameID] = append(out[association.GameID], t)
+		}
+	}
+	return
+}
+
+// storedPinyin 解析保存的拼音，拼音字段上线前保存、尚未补齐的数据直接转换
+func storedPinyin(text string, full string, initials string) *pinyin.Forms {
+	if full == "" && initials == "" {
+		return pinyin.Convert(text)
+	}
+	return &pinyin.Forms{
+		Full:     pinyin.Split(full),
+		Initials: pinyin.Split(initials),
+	}
+}
+
+// backfillPinyin 补齐拼音字段上线前保存的游戏和标签的拼音，不修改更新时间
+func backfillPinyin(ctx context.Context) (games int, tags int, err error) {
+	columns := dao.Game.Columns()
+	var lastID int64
+	for {
+		var entities []*entity.Game
+		err = dao.Game.Ctx(ctx).
+			Fields(columns.ID, columns.Name, columns.Developer).
+			WhereGT(columns.ID, lastID).
+			Where(columns.NamePinyin, "").
+			Where(columns.DeveloperPinyin, "").
+			OrderAsc(columns.ID).
+			Limit(loadBatchSize).
+			Scan(&entities)
+		if err != nil || len(entities) == 0 {
+			return
+		}
+		for _, game := range entities {
+			name, developer := pinyin.Convert(game.Name), pinyin.Convert(game.Developer)
+			if len(name.Full) == 0 && len(developer.Full) == 0 {
+				continue
+			}
+			_, err = dao.Game.Ctx(ctx).Where(columns.ID, game.ID).Data(map[string]interface{}{
+				columns.NamePinyin:        pinyin.Join(name.Full),
+				columns.NameInitials:      pinyin.Join(name.Initials),
+				columns.DeveloperPinyin:   pinyin.Join(developer.Full),
+				columns.DeveloperInitials: pinyin.Join(developer.Initials),
+				columns.UpdateTime:        gdb.Raw(columns.UpdateTime),
+			}).Update()
+			if err != nil {
+				return
+			}
+			games++
+		}
+		if len(entities) < loadBatchSize {
+			break
+		}
+		lastID = entities[len(entities)-1].ID
+	}
+
+	var entities []*entity.Tag
+	err = dao.Tag.Ctx(ctx).Where(dao.Tag.Columns().Pinyin, "").Scan(&entities)
+	if err != nil {
+		return
+	}
+	for _, t := range entities {
+		forms := pinyin.Convert(t.Name)
+		if len(forms.Full) == 0 {
+			continue
+		}
+		_, err = dao.Tag.Ctx(ctx).Where(dao.Tag.Columns().ID, t.ID).Data(map[string]interface{}{
+			dao.Tag.Columns().Pinyin:     pinyin.Join(forms.Full),
+			dao.Tag.Columns().Initials:   pinyin.Join(forms.Initials),
+			dao.Tag.Columns().UpdateTime: gdb.Raw(dao.Tag.Columns().UpdateTime),
+		}).Update()
+		if err != nil {
+			return
+		}
+		tags++
+	}
+	return
+}
+
+// loadDictionaryWords 分类、标签名称作为分词词典
+func loadDictionaryWords(ctx context.Context) (words []string, err error) {
+	categories, err := dao.Category.Ctx(ctx).Array(dao.Category.Columns().Name)
+	if err != nil {
+		return
+	}
+	tags, err := dao.Tag.Ctx(ctx).Array(dao.Tag.Columns().Name)
+	if err != nil {
+		return
+	}
+	for _, name := range append(categories, tags...) {
+		words = append(words, name.String())
+	}
+	return
+}
+
+// loadPopularQueries 加载 window 内至少 minUsers 个用户搜索过的关键词，归一化后相同的关键词合并，使用用户数最多的写法
+// 包含敏感词(已打码)或过长的关键词不作为搜索建议
+func loadPopularQueries(ctx context.Context, config *suggestConfig) (queries map[string]int64, err error) {
+	counts, err := dao.UserBehavior.PopularSearchKeywords(ctx, gtime.Now().Add(-config.QueryWindow), config.MinQueryUsers, config.MaxQueries)
+	if err != nil {
+		return
+	}
+
+	type query struct {
+		text  string
+		max   int64
+		users int64
+	}
+	merged := make(map[string]*query)
+	for _, count := range counts {
+		text := strings.TrimSpace(count.Keyword)
+		if strings.Contains(text, "*") || utf8.RuneCountInString(text) > maxSuggestQueryLength {
+			continue
+		}
+		key := compactText(text)
+		if key == "" {
+			continue
+		}
+		q, ok := merged[key]
+		if !ok {
+			q = &query{}
+			merged[key] = q
+		}
+		if count.Users > q.max {
+			q.text, q.max = text, count.Users
+		}
+		q.users += count.Users
+	}
+
+	queries = make(map[string]int64, len(merged))
+	for _, q := range merged {
+		queries[q.text] = q.users
+	}
+	return
+}
+
+func latestUpdateTime(current *gtime.Time, games []*entity.Game) *gtime.Time {
+	for _, game := range games {
+		if game.UpdateTime != nil && (current == nil || game.UpdateTime.After(current)) {
+			current = game.UpdateTime
+		}
+	}
+	return current
+}
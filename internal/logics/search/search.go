@@ -0,0 +1,368 @@
+package search
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+const (
+	defaultSyncInterval    = 30 * time.Second
+	defaultRebuildInterval = time.Hour
+	defaultSearchPageSize  = 20
+	maxSearchPageSize      = 100
+
+	// syncLookback 同步时多查询的时长，避免遗漏更新时间早于上次同步、但在上次同步之后才提交的事务
+	syncLookback = time.Minute
+)
+
+var (
+	searchOnce     sync.Once
+	searchInstance *search
+)
+
+type search struct {
+	index     atomic.Pointer[index] // 当前生效的索引，重建时整体替换；未加载完成时为空
+	suggester atomic.Pointer[suggester]
+	boost     *boostConfig
+	suggest   *suggestConfig
+
+	syncMutex       sync.Mutex  // 重建和同步互斥
+	watermark       *gtime.Time // 已同步的最大更新时间
+	lastRebuild     time.Time
+	rebuildInterval time.Duration
+	lastQueryLoad   time.Time // 最近一次加载热门搜索词的时间
+	stats           atomic.Pointer[model.SearchIndexStats]
+	startOnce       sync.Once
+	cancel          context.CancelFunc // 停机时取消，同步和补齐拼音的协程退出
+	wg              sync.WaitGroup
+}
+
+func NewSearch() service.ISearch {
+	searchOnce.Do(func() {
+		searchInstance = &search{
+			boost: &boostConfig{
+				NameExact:    2,
+				NamePrefix:   1,
+				NameContains: 0.5,
+				Popularity:   0.1,
+				Pinyin:       1,
+				MinMatch:     0.7,
+			},
+			suggest: &suggestConfig{
+				QueryWindow:          30 * 24 * time.Hour,
+				MinQueryUsers:        3,
+				MaxQueries:           5000,
+				QueryWeight:          10,
+				QueryRefreshInterval: 10 * time.Minute,
+			},
+			rebuildInterval: defaultRebuildInterval,
+		}
+		searchInstance.stats.Store(&model.SearchIndexStats{})
+	})
+	return searchInstance
+}
+
+// 确保search实现了ISearch接口
+var _ service.ISearch = (*search)(nil)
+
+// Start 加载索引，之后每 search.syncInterval 同步更新时间变化的游戏；游戏数量与索引不一致(其他实例删除了游戏)
+// 或距上次重建超过 search.rebuildInterval(分类、标签的修改不会更新游戏的更新时间)时重建索引
+func (s *search) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		ctx, s.cancel = context.WithCancel(ctx)
+		s.loadConfig(ctx)
+		if _, err := s.Rebuild(ctx); err != nil {
+			g.Log().Errorf(ctx, "加载搜索索引失败: %v", err)
+		}
+		// 补齐前的数据在加载时直接转换拼音，不影响搜索；停机时中断，下次启动继续补齐
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			games, tags, err := backfillPinyin(ctx)
+			if err != nil && ctx.Err() == nil {
+				g.Log().Errorf(ctx, "补齐拼音失败: %v", err)
+			}
+			if games > 0 || tags > 0 {
+				g.Log().Infof(ctx, "已补齐拼音: games=%d, tags=%d", games, tags)
+			}
+		}()
+
+		interval := g.Cfg().MustGet(ctx, "search.syncInterval", defaultSyncInterval).Duration()
+		if interval <= 0 {
+			interval = defaultSyncInterval
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				if err := s.sync(ctx); err != nil && ctx.Err() == nil {
+					g.Log().Errorf(ctx, "同步搜索索引失败: %v", err)
+				}
+			}
+		}()
+	})
+}
+
+// Shutdown 停止同步索引和补齐拼音，等待执行中的同步完成；ctx 到期后直接返回
+func (s *search) Shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait search index sync exit: %w", ctx.Err())
+	}
+}
+
+func (s *search) loadConfig(ctx context.Context) {
+	if v := g.Cfg().MustGet(ctx, "search.rebuildInterval"); !v.IsNil() && v.Duration() > 0 {
+		s.rebuildInterval = v.Duration()
+	}
+	if v := g.Cfg().MustGet(ctx, "search.minMatch"); !v.IsNil() {
+		s.boost.MinMatch = min(max(v.Float64(), 0), 1)
+	}
+	for key, value := range map[string]*float64{
+		"search.boost.nameExact":    &s.boost.NameExact,
+		"search.boost.namePrefix":   &s.boost.NamePrefix,
+		"search.boost.nameContains": &s.boost.NameContains,
+		"search.boost.popularity":   &s.boost.Popularity,
+		"search.boost.pinyin":       &s.boost.Pinyin,
+	} {
+		if v := g.Cfg().MustGet(ctx, key); !v.IsNil() {
+			*value = max(v.Float64(), 0)
+		}
+	}
+
+	if v := g.Cfg().MustGet(ctx, "search.suggest.queryWindow"); !v.IsNil() && v.Duration() > 0 {
+		s.suggest.QueryWindow = v.Duration()
+	}
+	if v := g.Cfg().MustGet(ctx, "search.suggest.minQueryUsers"); !v.IsNil() && v.Int() > 0 {
+		s.suggest.MinQueryUsers = v.Int()
+	}
+	if v := g.Cfg().MustGet(ctx, "search.suggest.maxQueries"); !v.IsNil() {
+		s.suggest.MaxQueries = max(v.Int(), 0)
+	}
+	if v := g.Cfg().MustGet(ctx, "search.suggest.queryWeight"); !v.IsNil() {
+		s.suggest.QueryWeight = max(v.Float64(), 0)
+	}
+	if v := g.Cfg().MustGet(ctx, "search.suggest.queryRefreshInterval"); !v.IsNil() && v.Duration() > 0 {
+		s.suggest.QueryRefreshInterval = v.Duration()
+	}
+}
+
+// Rebuild 加载所有游戏建立新的索引后替换当前索引，重建期间搜索使用旧索引
+func (s *search) Rebuild(ctx context.Context) (stats *model.SearchIndexStats, err error) {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	return s.rebuild(ctx)
+}
+
+func (s *search) rebuild(ctx context.Context) (stats *model.SearchIndexStats, err error) {
+	start := time.Now()
+	words, err := loadDictionaryWords(ctx)
+	if err != nil {
+		return
+	}
+	sources, maxUpdateTime, err := loadAllSources(ctx)
+	if err != nil {
+		return
+	}
+
+	// 游戏名称、开发商、发行商也作为分词词典，完整匹配时得分更高
+	for _, src := range sources {
+		words = append(words, src.Game.Name, src.Game.Developer, src.Game.Publisher)
+	}
+	idx := newIndex(newDictionary(words))
+	for _, src := range sources {
+		idx.put(src)
+	}
+	// 热门搜索词加载失败不影响索引，下次同步时重试
+	queries, err := s.loadQueries(ctx)
+	if err != nil {
+		g.Log().Errorf(ctx, "加载热门搜索词失败: %v", err)
+	}
+	sug := newSuggester(sources, queries, s.suggest.QueryWeight)
+
+	// 先替换建议，Refresh 读到索引时建议一定已加载
+	s.suggester.Store(sug)
+	s.index.Store(idx)
+	s.watermark = maxUpdateTime
+	s.lastRebuild = start
+
+	documents, terms := idx.size()
+	stats = &model.SearchIndexStats{
+		Documents:   documents,
+		Terms:       terms,
+		Dictionary:  idx.dict.size(),
+		BuildTime:   gtime.New(start),
+		Duration:    time.Since(start).String(),
+		Suggestions: sug.size(),
+	}
+	s.stats.Store(stats)
+	g.Log().Infof(ctx, "搜索索引已重建: documents=%d, terms=%d, dictionary=%d, suggestions=%d, duration=%s",
+		stats.Documents, stats.Terms, stats.Dictionary, stats.Suggestions, stats.Duration)
+	return stats, nil
+}
+
+// loadQueries 加载热门搜索词，失败时也更新加载时间，按 search.suggest.queryRefreshInterval 重试
+func (s *search) loadQueries(ctx context.Context) (queries map[string]int64, err error) {
+	s.lastQueryLoad = time.Now()
+	if s.suggest.MaxQueries == 0 {
+		return nil, nil
+	}
+	return loadPopularQueries(ctx, s.suggest)
+}
+
+// sync 同步其他实例的修改，以及本实例 Refresh 失败的游戏
+func (s *search) sync(ctx context.Context) (err error) {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	idx := s.index.Load()
+	if idx == nil || time.Since(s.lastRebuild) >= s.rebuildInterval {
+		_, err = s.rebuild(ctx)
+		return
+	}
+
+	if s.watermark != nil {
+		sources, maxUpdateTime, err := loadUpdatedSources(ctx, s.watermark.Add(-syncLookback))
+		if err != nil {
+			return err
+		}
+		sug := s.suggester.Load()
+		for _, src := range sources {
+			idx.put(src)
+			sug.putGame(src)
+		}
+		if maxUpdateTime.After(s.watermark) {
+			s.watermark = maxUpdateTime
+		}
+	}
+
+	if time.Since(s.lastQueryLoad) >= s.suggest.QueryRefreshInterval {
+		queries, err := s.loadQueries(ctx)
+		if err != nil {
+			return err
+		}
+		s.suggester.Load().setQueries(queries)
+	}
+
+	count, err := dao.Game.Ctx(ctx).Count()
+	if err != nil {
+		return
+	}
+	if documents, _ := idx.size(); documents != count {
+		g.Log().Infof(ctx, "搜索索引的游戏数量与数据库不一致，重建索引: index=%d, database=%d", documents, count)
+		_, err = s.rebuild(ctx)
+	}
+	return
+}
+
+// Refresh 失败时只记录日志，由定期同步补齐
+func (s *search) Refresh(ctx context.Context, gameIDs ...int64) {
+	idx, sug := s.index.Load(), s.suggester.Load()
+	if idx == nil || len(gameIDs) == 0 {
+		return
+	}
+
+	sources, err := loadSourcesByIDs(ctx, gameIDs)
+	if err != nil {
+		g.Log().Errorf(ctx, "更新搜索索引失败: gameIDs=%v, %v", gameIDs, err)
+		return
+	}
+	found := make(map[int64]bool, len(sources))
+	for _, src := range sources {
+		idx.put(src)
+		sug.putGame(src)
+		found[src.Game.ID] = true
+	}
+	for _, gameID := range gameIDs {
+		if !found[gameID] {
+			idx.remove(gameID)
+			sug.removeGame(gameID)
+		}
+	}
+}
+
+func (s *search) Search(ctx context.Context, in *model.GameSearchQuery) (out *model.GameSearchResult, err error) {
+	idx := s.index.Load()
+	if idx == nil {
+		return nil, model.ErrSearchIndexNotReady
+	}
+
+	page, size := in.Page, in.Size
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = defaultSearchPageSize
+	}
+	size = min(size, maxSearchPageSize)
+
+	hits, facets := newFilter(in).apply(idx.match(in.Keyword, s.boost))
+	sortMatches(hits, in.Sort)
+
+	out = &model.GameSearchResult{
+		Hits:   []*model.GameSearchHit{},
+		Total:  len(hits),
+		Facets: facets,
+	}
+	if offset := (page - 1) * size; offset < len(hits) {
+		for _, hit := range hits[offset:min(offset+size, len(hits))] {
+			out.Hits = append(out.Hits, &model.GameSearchHit{
+				GameID: hit.doc.GameID,
+				Score:  hit.score,
+			})
+		}
+	}
+	return
+}
+
+func (s *search) Suggest(ctx context.Context, prefix string, limit int) (out []*model.SearchSuggestion, err error) {
+	sug := s.suggester.Load()
+	if sug == nil {
+		return nil, model.ErrSearchIndexNotReady
+	}
+	if limit <= 0 {
+		limit = defaultSuggestLimit
+	}
+	return sug.suggest(prefix, min(limit, maxSuggestLimit)), nil
+}
+
+func (s *search) Stats(ctx context.Context) (stats *model.SearchIndexStats) {
+	stats = &model.SearchIndexStats{}
+	*stats = *s.stats.Load()
+	if idx := s.index.Load(); idx != nil {
+		stats.Documents, stats.Terms = idx.size()
+	}
+	if sug := s.suggester.Load(); sug != nil {
+		stats.Suggestions = sug.size()
+	}
+	return
+}
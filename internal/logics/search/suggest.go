@@ -0,0 +1,378 @@
+package search
+
+import (
+	"GameEngine/internal/common/pinyin"
+	"GameEngine/internal/model"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 20
+	// suggestCacheSize 每个前缀缓存的建议数量，多于返回数量，去掉同名的建议后仍然足够
+	suggestCacheSize = 2 * maxSuggestLimit
+	// maxSuggestQueryLength 热门搜索词的最大长度(字符)
+	maxSuggestQueryLength = 30
+)
+
+// suggestConfig 热门搜索词的配置
+type suggestConfig struct {
+	QueryWindow          time.Duration // 统计最近多长时间的搜索
+	MinQueryUsers        int           // 至少多少个用户搜索过，避免个人的搜索内容出现在建议中
+	MaxQueries           int           // 最多加载的搜索词数量
+	QueryWeight          float64       // 搜索词的权重为用户数乘以该系数
+	QueryRefreshInterval time.Duration // 重新统计的间隔
+}
+
+// suggestion 搜索建议，游戏按热度排序，开发商按旗下游戏的热度之和，热门搜索词按搜索过的用户数乘以 queryWeight
+type suggestion struct {
+	id      string   // 来源+ID，用于替换和删除
+	display string   // compactText 后的文本，文本相同的建议只返回一条
+	keys    []string // 按前缀匹配的文本：compactText 后的文本，以及游戏名称、开发商的全拼和首字母
+	text    string
+	kind    model.SearchSuggestionType
+	gameID  int64
+	weight  float64
+}
+
+// suggestKeys compactText 后的文本及拼音，去掉重复和空的
+func suggestKeys(text string, forms *pinyin.Forms) (keys []string) {
+	candidates := []string{compactText(text)}
+	if forms != nil {
+		candidates = append(candidates, forms.Full...)
+		candidates = append(candidates, forms.Initials...)
+	}
+	for _, key := range candidates {
+		if key != "" && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return
+}
+
+// trieNode 前缀树节点，top 缓存以该节点为前缀的权重最高的建议，查询时不需要遍历子树
+type trieNode struct {
+	children map[rune]*trieNode
+	entries  []*suggestion // 文本与该节点完全相同的建议
+	top      []*suggestion
+}
+
+// developerGames 开发商旗下线上游戏的热度
+type developerGames struct {
+	text   string
+	pinyin *pinyin.Forms
+	games  map[int64]float64
+}
+
+// suggester 搜索建议的前缀树，只包含线上(可预约、已上架)游戏，游戏变更时增量更新
+type suggester struct {
+	mutex       sync.RWMutex
+	root        *trieNode
+	suggestions map[string]*suggestion
+
+	developers     map[string]*developerGames // 开发商 compactText -> 旗下游戏
+	gameDevelopers map[int64]string           // 游戏ID -> 开发商 compactText
+	queryIDs       []string                   // 当前的热门搜索词，整体替换
+	queryWeight    float64
+	loading        bool // 批量加载时不更新缓存，加载完成后统一计算
+}
+
+// newSuggester 加载所有游戏和热门搜索词
+func newSuggester(sources []*source, queries map[string]int64, queryWeight float64) *suggester {
+	s := &suggester{
+		root:           &trieNode{},
+		suggestions:    make(map[string]*suggestion),
+		developers:     make(map[string]*developerGames),
+		gameDevelopers: make(map[int64]string),
+		queryWeight:    queryWeight,
+		loading:        true,
+	}
+	for _, src := range sources {
+		s.putGame(src)
+	}
+	s.setQueries(queries)
+	s.loading = false
+	for _, child := range s.root.children {
+		child.updateTop()
+	}
+	return s
+}
+
+// putGame 添加或更新游戏名称和开发商，游戏不在线上时删除
+func (s *suggester) putGame(src *source) {
+	game := src.Game
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.removeGameLocked(game.ID)
+	if !model.IsGameLive(game.Status) {
+		return
+	}
+
+	weight := popularity(game)
+	s.putLocked(&suggestion{
+		id:      fmt.Sprintf("game:%d", game.ID),
+		display: compactText(game.Name),
+		keys:    suggestKeys(game.Name, src.NamePinyin),
+		text:    game.Name,
+		kind:    model.SearchSuggestionTypeGame,
+		gameID:  game.ID,
+		weight:  weight,
+	})
+
+	key := compactText(game.Developer)
+	if key == "" {
+		return
+	}
+	developer, ok := s.developers[key]
+	if !ok {
+		developer = &developerGames{games: make(map[int64]float64)}
+		s.developers[key] = developer
+	}
+	developer.text, developer.pinyin = game.Developer, src.DeveloperPinyin
+	// 没有热度的游戏也计入，旗下游戏多的开发商排在前面
+	developer.games[game.ID] = weight + 1
+	s.gameDevelopers[game.ID] = key
+	s.putDeveloperLocked(key)
+}
+
+func (s *suggester) removeGame(gameID int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.removeGameLocked(gameID)
+}
+
+func (s *suggester) removeGameLocked(gameID int64) {
+	s.removeLocked(fmt.Sprintf("game:%d", gameID))
+
+	key, ok := s.gameDevelopers[gameID]
+	if !ok {
+		return
+	}
+	delete(s.gameDevelopers, gameID)
+	delete(s.developers[key].games, gameID)
+	s.putDeveloperLocked(key)
+}
+
+// putDeveloperLocked 按旗下游戏重新计算开发商的权重，没有线上游戏时删除
+func (s *suggester) putDeveloperLocked(key string) {
+	developer := s.developers[key]
+	if len(developer.games) == 0 {
+		delete(s.developers, key)
+		s.removeLocked("developer:" + key)
+		return
+	}
+
+	var weight float64
+	for _, gameWeight := range developer.games {
+		weight += gameWeight
+	}
+	s.putLocked(&suggestion{
+		id:      "developer:" + key,
+		display: key,
+		keys:    suggestKeys(developer.text, developer.pinyin),
+		text:    developer.text,
+		kind:    model.SearchSuggestionTypeDeveloper,
+		weight:  weight,
+	})
+}
+
+// setQueries 替换热门搜索词，queries 为搜索词及搜索过的用户数
+func (s *suggester) setQueries(queries map[string]int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, id := range s.queryIDs {
+		s.removeLocked(id)
+	}
+	s.queryIDs = make([]string, 0, len(queries))
+	for text, users := range queries {
+		key := compactText(text)
+		if key == "" {
+			continue
+		}
+		id := "query:" + key
+		s.putLocked(&suggestion{
+			id:      id,
+			display: key,
+			keys:    []string{key},
+			text:    text,
+			kind:    model.SearchSuggestionTypeQuery,
+			weight:  float64(users) * s.queryWeight,
+		})
+		s.queryIDs = append(s.queryIDs, id)
+	}
+}
+
+func (s *suggester) putLocked(sug *suggestion) {
+	s.removeLocked(sug.id)
+	if len(sug.keys) == 0 {
+		return
+	}
+
+	s.suggestions[sug.id] = sug
+	for _, key := range sug.keys {
+		path := s.pathLocked(key, true)
+		node := path[len(path)-1]
+		node.entries = append(node.entries, sug)
+		s.updatePathLocked(key, path)
+	}
+}
+
+func (s *suggester) removeLocked(id string) {
+	sug, ok := s.suggestions[id]
+	if !ok {
+		return
+	}
+	delete(s.suggestions, id)
+
+	for _, key := range sug.keys {
+		path := s.pathLocked(key, false)
+		node := path[len(path)-1]
+		node.entries = slices.DeleteFunc(node.entries, func(entry *suggestion) bool {
+			return entry.id == id
+		})
+		s.updatePathLocked(key, path)
+	}
+}
+
+// pathLocked 返回从根节点到 key 对应节点的路径，create 为 false 时 key 对应的节点必须存在
+func (s *suggester) pathLocked(key string, create bool) (path []*trieNode) {
+	node := s.root
+	path = append(path, node)
+	for _, r := range key {
+		child, ok := node.children[r]
+		if !ok {
+			if !create {
+				panic("suggest trie path not found: " + key)
+			}
+			child = &trieNode{}
+			if node.children == nil {
+				node.children = make(map[rune]*trieNode)
+			}
+			node.children[r] = child
+		}
+		node = child
+		path = append(path, node)
+	}
+	return
+}
+
+// updatePathLocked 自下而上重新计算路径上各节点的缓存，并删除没有建议的节点；根节点不缓存
+func (s *suggester) updatePathLocked(key string, path []*trieNode) {
+	runes := []rune(key)
+	for i := len(path) - 1; i > 0; i-- {
+		node := path[i]
+		if len(node.entries) == 0 && len(node.children) == 0 {
+			delete(path[i-1].children, runes[i-1])
+			continue
+		}
+		if !s.loading {
+			node.mergeTop()
+		}
+	}
+}
+
+// mergeTop 合并自身和子节点的缓存，同一建议的多个文本(如全拼和首字母)都在子树中时只保留一条
+func (node *trieNode) mergeTop() {
+	candidates := slices.Clone(node.entries)
+	for _, child := range node.children {
+		candidates = append(candidates, child.top...)
+	}
+	node.top = topSuggestions(candidates, suggestCacheSize)
+}
+
+// topSuggestions 去掉重复的建议后按权重返回前 n 条
+func topSuggestions(candidates []*suggestion, n int) (top []*suggestion) {
+	slices.SortFunc(candidates, compareSuggestions)
+	candidates = slices.CompactFunc(candidates, func(a, b *suggestion) bool {
+		return a.id == b.id
+	})
+	return slices.Clone(candidates[:min(len(candidates), n)])
+}
+
+// updateTop 自下而上计算整棵子树的缓存
+func (node *trieNode) updateTop() {
+	for _, child := range node.children {
+		child.updateTop()
+	}
+	node.mergeTop()
+}
+
+// compareSuggestions 按权重从高到低，权重相同时文本短的在前
+func compareSuggestions(a, b *suggestion) int {
+	if c := cmp.Compare(b.weight, a.weight); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(len(a.display), len(b.display)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+// suggest 返回以 prefix 开头的建议，文本相同的建议只保留权重最高的一条
+// prefix 同时包含汉字和字母时(如“王者rong”)，还按汉字转换为拼音后的全拼和首字母匹配
+func (s *suggester) suggest(prefix string, limit int) (out []*model.SearchSuggestion) {
+	out = []*model.SearchSuggestion{}
+	key := compactText(prefix)
+	if key == "" {
+		return
+	}
+	keys := []string{key}
+	if pinyin.ContainsHan(key) && strings.IndexFunc(key, isLatin) >= 0 {
+		keys = suggestKeys(key, pinyin.Convert(key))
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var candidates []*suggestion
+	for _, key := range keys {
+		if node := s.lookupLocked(key); node != nil {
+			candidates = append(candidates, node.top...)
+		}
+	}
+	if len(keys) > 1 {
+		candidates = topSuggestions(candidates, len(candidates))
+	}
+
+	seen := make(map[string]struct{}, limit)
+	for _, sug := range candidates {
+		if len(out) >= limit {
+			break
+		}
+		if _, ok := seen[sug.display]; ok {
+			continue
+		}
+		seen[sug.display] = struct{}{}
+		out = append(out, &model.SearchSuggestion{
+			Text:   sug.text,
+			Type:   sug.kind,
+			GameID: sug.gameID,
+		})
+	}
+	return
+}
+
+func (s *suggester) lookupLocked(key string) (node *trieNode) {
+	node = s.root
+	for _, r := range key {
+		if node = node.children[r]; node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func (s *suggester) size() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.suggestions)
+}
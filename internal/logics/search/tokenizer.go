@@ -0,0 +1,160 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 分词：英文和数字按连续的字母数字切分；中文在二元切分(相邻两个字)的基础上，
+// 按词典做正向最大匹配，三个字及以上的词作为额外的词项，使完整匹配词语的游戏得分更高。
+// 索引时额外保留单字，用于匹配只输入一个汉字的查询。
+
+// maxDictionaryWordLength 词典中词的最大长度，超过的词不参与分词
+const maxDictionaryWordLength = 16
+
+// builtinWords 常见的游戏类型和题材，与标签、分类、游戏名称、开发商一起组成分词词典
+var builtinWords = []string{
+	"角色扮演", "动作冒险", "即时战略", "回合制", "开放世界", "模拟经营", "休闲益智", "多人在线", "大逃杀",
+	"二次元", "消消乐", "三消", "塔防", "卡牌", "射击", "格斗", "跑酷", "竞速", "赛车", "体育", "音乐",
+	"节奏", "解谜", "冒险", "策略", "战棋", "放置", "挂机", "沙盒", "生存", "恐怖", "武侠", "仙侠",
+	"修仙", "三国", "魔幻", "科幻", "末日", "像素", "单机", "联机", "对战", "竞技", "养成", "经营",
+	"模拟", "益智", "休闲", "动作", "派对", "棋牌", "麻将", "斗地主", "小游戏",
+}
+
+// normalizeText 全角转半角、大写转小写
+func normalizeText(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '　':
+			return ' '
+		case r >= '！' && r <= '～':
+			r -= 0xFEE0
+		}
+		return unicode.ToLower(r)
+	}, text)
+}
+
+// compactText 归一化后只保留文字和数字，用于比较游戏名称与查询
+func compactText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, normalizeText(text))
+}
+
+func isHan(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// dictionary 分词词典
+type dictionary struct {
+	words     map[string]struct{}
+	maxLength int
+}
+
+func newDictionary(words []string) *dictionary {
+	d := &dictionary{words: make(map[string]struct{})}
+	for _, word := range builtinWords {
+		d.add(word)
+	}
+	for _, word := range words {
+		d.add(word)
+	}
+	return d
+}
+
+// add 只收录文本中的中文片段，两个字及以上
+func (d *dictionary) add(text string) {
+	for _, run := range splitRuns(normalizeText(text)) {
+		if !run.han {
+			continue
+		}
+		length := len(run.runes)
+		if length < 2 || length > maxDictionaryWordLength {
+			continue
+		}
+		d.words[string(run.runes)] = struct{}{}
+		if length > d.maxLength {
+			d.maxLength = length
+		}
+	}
+}
+
+func (d *dictionary) size() int {
+	return len(d.words)
+}
+
+// segment 正向最大匹配，返回三个字及以上的词
+func (d *dictionary) segment(runes []rune) (words []string) {
+	for i := 0; i < len(runes); {
+		matched := 1
+		for length := min(d.maxLength, len(runes)-i); length >= 2; length-- {
+			if _, ok := d.words[string(runes[i:i+length])]; ok {
+				matched = length
+				break
+			}
+		}
+		if matched >= 3 {
+			words = append(words, string(runes[i:i+matched]))
+		}
+		i += matched
+	}
+	return
+}
+
+type textRun struct {
+	runes []rune
+	han   bool
+}
+
+// splitRuns 按中文、字母数字切分，其他字符作为分隔符
+func splitRuns(text string) (runs []textRun) {
+	var current []rune
+	currentHan := false
+	flush := func() {
+		if len(current) > 0 {
+			runs = append(runs, textRun{runes: current, han: currentHan})
+			current = nil
+		}
+	}
+	for _, r := range text {
+		switch {
+		case isHan(r):
+			if !currentHan {
+				flush()
+			}
+			current, currentHan = append(current, r), true
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if currentHan {
+				flush()
+			}
+			current, currentHan = append(current, r), false
+		default:
+			flush()
+		}
+	}
+	flush()
+	return
+}
+
+// tokenize 切分为词项，forIndex 为 true 时保留单字
+func tokenize(text string, dict *dictionary, forIndex bool) (terms []string) {
+	for _, run := range splitRuns(normalizeText(text)) {
+		if !run.han {
+			terms = append(terms, string(run.runes))
+			continue
+		}
+		if len(run.runes) == 1 || forIndex {
+			for _, r := range run.runes {
+				terms = append(terms, string(r))
+			}
+		}
+		for i := 0; i+1 < len(run.runes); i++ {
+			terms = append(terms, string(run.runes[i:i+2]))
+		}
+		terms = append(terms, dict.segment(run.runes)...)
+	}
+	return
+}
@@ -0,0 +1,133 @@
+package sensitive
+
+// matcher 基于 Aho-Corasick 自动机的多模式匹配，构建后只读，可并发使用
+// 词库更新时重新构建新的 matcher 并整体替换
+type matcher struct {
+	nodes []acNode
+	words []string // 原始敏感词，用于返回命中结果
+}
+
+type acNode struct {
+	children map[rune]int32
+	fail     int32
+	word     int32 // 以该节点结尾的敏感词下标，-1 表示不是词尾
+	length   int32 // 以该节点结尾的敏感词长度(归一化后的字符数)
+	dictLink int32 // 沿失败指针最近的词尾节点，-1 表示没有
+}
+
+// match 一次命中，start/end 为归一化文本中的下标(闭区间)
+type match struct {
+	start int
+	end   int
+	word  int32
+}
+
+func newMatcher(words []string) *matcher {
+	m := &matcher{
+		nodes: []acNode{{fail: 0, word: -1, dictLink: -1}},
+		words: make([]string, 0, len(words)),
+	}
+
+	for _, word := range words {
+		runes, _ := normalize(word)
+		if len(runes) == 0 {
+			continue
+		}
+		m.insert(runes, int32(len(m.words)))
+		m.words = append(m.words, word)
+	}
+	m.build()
+	return m
+}
+
+func (m *matcher) insert(runes []rune, wordIndex int32) {
+	var cur int32
+	for _, r := range runes {
+		next, ok := m.nodes[cur].children[r]
+		if !ok {
+			m.nodes = append(m.nodes, acNode{word: -1, dictLink: -1})
+			next = int32(len(m.nodes) - 1)
+			if m.nodes[cur].children == nil {
+				m.nodes[cur].children = make(map[rune]int32)
+			}
+			m.nodes[cur].children[r] = next
+		}
+		cur = next
+	}
+	// 归一化后相同的词只保留第一个
+	if m.nodes[cur].word < 0 {
+		m.nodes[cur].word = wordIndex
+		m.nodes[cur].length = int32(len(runes))
+	}
+}
+
+// build 按层次遍历构建失败指针和输出链接
+func (m *matcher) build() {
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].children {
+		m.nodes[child].fail = 0
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for r, child := range m.nodes[cur].children {
+			fail := m.nodes[cur].fail
+			for {
+				if next, ok := m.nodes[fail].children[r]; ok && next != child {
+					m.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					m.nodes[child].fail = 0
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+
+			failNode := m.nodes[m.nodes[child].fail]
+			if failNode.word >= 0 {
+				m.nodes[child].dictLink = m.nodes[child].fail
+			} else {
+				m.nodes[child].dictLink = failNode.dictLink
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// find 在归一化后的文本中查找所有命中
+func (m *matcher) find(text []rune) (matches []match) {
+	if len(m.words) == 0 {
+		return nil
+	}
+
+	var cur int32
+	for i, r := range text {
+		for {
+			if next, ok := m.nodes[cur].children[r]; ok {
+				cur = next
+				break
+			}
+			if cur == 0 {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+
+		for out := cur; out > 0; out = m.nodes[out].dictLink {
+			node := m.nodes[out]
+			if node.word < 0 {
+				continue
+			}
+			matches = append(matches, match{
+				start: i - int(node.length) + 1,
+				end:   i,
+				word:  node.word,
+			})
+		}
+	}
+	return
+}
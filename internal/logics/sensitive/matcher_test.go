@@ -0,0 +1,61 @@
+package sensitive
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMatcherFind(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		text  string
+		want  []string // 敏感词@开始-结束
+	}{
+		{"没有敏感词", nil, "任意文本", nil},
+		{"没有命中", []string{"赌博"}, "正常的游戏介绍", nil},
+		{"重叠命中", []string{"he", "she", "his", "hers"}, "ushers", []string{"he@2-3", "hers@2-5", "she@1-3"}},
+		{"中文重叠命中", []string{"赌博", "博彩"}, "赌博彩票", []string{"博彩@1-2", "赌博@0-1"}},
+		{"短词是长词的后缀", []string{"a", "aa"}, "aaa", []string{"a@0-0", "a@1-1", "a@2-2", "aa@0-1", "aa@1-2"}},
+		{"失败指针跳转后命中", []string{"abcd", "bce"}, "abce", []string{"bce@1-3"}},
+		{"归一化后相同的词只保留第一个", []string{"ABC", "abc"}, "xabcx", []string{"ABC@1-3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMatcher(tt.words)
+			text, _ := normalize(tt.text)
+
+			var got []string
+			for _, hit := range m.find(text) {
+				got = append(got, fmt.Sprintf("%s@%d-%d", m.words[hit.word], hit.start, hit.end))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("find(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		wantRunes     string
+		wantPositions []int
+	}{
+		{"全角转半角并转小写", "ＡＢｃ", "abc", []int{0, 1, 2}},
+		{"繁体转简体", "賭博", "赌博", []int{0, 1}},
+		{"忽略空白、标点和零宽字符", "赌 *博\u200b彩", "赌博彩", []int{0, 3, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runes, positions := normalize(tt.text)
+			if string(runes) != tt.wantRunes || !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("normalize(%q) = %q %v, want %q %v", tt.text, string(runes), positions, tt.wantRunes, tt.wantPositions)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package sensitive
+
+import (
+	"strings"
+	"unicode"
+)
+
+// normalize 文本归一化：全角转半角、大写转小写、繁体转简体，并忽略空白、标点、符号和零宽字符
+// 返回归一化后的字符，以及每个字符在原文中的下标(按字符计算)，用于打码时定位原文
+func normalize(text string) (runes []rune, positions []int) {
+	runes = make([]rune, 0, len(text))
+	positions = make([]int, 0, len(text))
+
+	i := 0
+	for _, r := range text {
+		r = normalizeRune(r)
+		if !ignoreRune(r) {
+			runes = append(runes, r)
+			positions = append(positions, i)
+		}
+		i++
+	}
+	return
+}
+
+func normalizeRune(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case r >= '！' && r <= '～':
+		r -= 0xFEE0
+	}
+	r = unicode.ToLower(r)
+	if s, ok := traditionalToSimplified[r]; ok {
+		return s
+	}
+	return r
+}
+
+// ignoreRune 插入在敏感词中间用于规避过滤的字符
+func ignoreRune(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.Is(unicode.Cf, r)
+}
+
+// traditionalToSimplified 常用繁体字到简体字的映射
+var traditionalToSimplified = func() map[rune]rune {
+	pairs := strings.Fields(`
+		個个 們们 來来 國国 會会 說说 這这 時时 對对 學学 後后 點点 還还 過过 發发 經经 動动 當当 體体 種种
+		現现 實实 機机 關关 問问 題题 開开 長长 電电 書书 見见 聽听 車车 門门 東东 間间 讓让 話话 語语 認认
+		讀读 寫写 買买 賣卖 錢钱 銀银 價价 費费 貨货 資资 質质 貿贸 賭赌 賽赛 贏赢 輸输 錯错 誤误 請请 調调
+		談谈 論论 計计 設设 許许 訪访 證证 識识 護护 變变 網网 絡络 給给 統统 結结 線线 組组 織织 練练 細细
+		終终 紅红 級级 納纳 紙纸 約约 總总 續续 緊紧 絕绝 緣缘 編编 縮缩 繼继 獎奖 將将 專专 導导 師师 歸归
+		彈弹 強强 彎弯 歲岁 歷历 曆历 壓压 廣广 廳厅 廠厂 應应 態态 戰战 戲戏 戶户 擇择 擊击 擔担 據据 擁拥
+		擴扩 舉举 搶抢 掃扫 掛挂 換换 揮挥 損损 搖摇 攜携 敵敌 數数 斷断 無无 晝昼 曉晓 暫暂 術术 樂乐 樓楼
+		標标 樣样 樹树 橋桥 權权 歡欢 殺杀 殼壳 氣气 漢汉 濟济 減减 測测 湯汤 溫温 滿满 灣湾 災灾 燈灯 爐炉
+		爭争 爺爷 牆墙 獨独 獄狱 獲获 狀状 猶犹 環环 瑪玛 產产 畫画 療疗 瘋疯 盡尽 監监 盤盘 眾众 礦矿 碼码
+		確确 禮礼 禍祸 離离 稱称 穩稳 競竞 筆笔 節节 範范 築筑 簡简 糧粮 糾纠 紀纪 綁绑 綠绿 緒绪 緩缓 縣县
+		繩绳 繪绘 罰罚 罷罢 義义 習习 聞闻 聯联 聲声 聰聪 職职 肅肃 腦脑 脫脱 腳脚 膽胆 臉脸 興兴 舊旧 艦舰
+		藝艺 蘇苏 蘋苹 號号 蟲虫 衛卫 衝冲 補补 裝装 製制 複复 規规 覺觉 親亲 觀观 覽览 訂订 訊讯 討讨 訓训
+		記记 詐诈 評评 詞词 試试 詩诗 該该 詳详 誇夸 誘诱 誰谁 課课 諸诸 諾诺 謀谋 謝谢 謠谣 講讲 謹谨 譯译
+		議议 豐丰 豬猪 貓猫 貝贝 負负 貢贡 財财 責责 敗败 販贩 購购 貸贷 貼贴 貴贵 賀贺 賊贼 賓宾 賞赏 賠赔
+		賤贱 賦赋 賬账 賴赖 賺赚 贈赠 贊赞 趕赶 躍跃 軍军 軌轨 軟软 軸轴 較较 載载 輕轻 輛辆 輝辉 輩辈 輪轮
+		輯辑 轉转 轟轰 辦办 辭辞 農农 週周 進进 運运 達达 違违 遠远 適适 選选 遺遗 邊边 郵邮 鄉乡 醜丑 醫医
+		釋释 針针 釣钓 鈔钞 鈴铃 鉛铅 銅铜 銷销 鋒锋 鋪铺 鋼钢 錄录 錦锦 錫锡 鍋锅 鍵键 鍾钟 鎖锁 鎮镇 鏡镜
+		鐘钟 鐵铁 鑰钥 閃闪 閉闭 閒闲 閘闸 閣阁 閱阅 闆板 闊阔 闡阐 陣阵 陰阴 陳陈 陸陆 陽阳 隊队 階阶 際际
+		隨随 險险 隱隐 隻只 雖虽 雙双 雜杂 雞鸡 難难 雲云 霧雾 靈灵 靜静 韓韩 韻韵 響响 頁页 頂顶 項项 順顺
+		須须 預预 頑顽 頒颁 頓顿 領领 頭头 頻频 額额 顏颜 願愿 類类 顧顾 顯显 風风 飄飘 飛飞 飯饭 飲饮 飽饱
+		飾饰 餅饼 養养 餘余 館馆 騎骑 騙骗 騰腾 驗验 驚惊 髮发 鬥斗 鬧闹 魚鱼 鮮鲜 鳥鸟 鳴鸣 鴨鸭 鴻鸿 鵝鹅
+		鷹鹰 鹽盐 麥麦 麗丽 黃黄 黨党 齊齐 齒齿 龍龙 龜龟 愛爱 塊块 壞坏 聖圣 臺台 檯台 颱台 麼么 嗎吗 獸兽
+		槍枪 藥药 穢秽 騷骚 姦奸 覓觅 廢废 殘残 屍尸 傷伤 勢势 務务 勞劳 勵励 區区 協协 單单 參参 嚴严 團团
+		園园 圖图 圓圆 場场 塵尘 墮堕 夢梦 奪夺 奮奋 婦妇 媽妈 孫孙 寧宁 寶宝 尋寻 層层 島岛 幣币 幫帮 幹干
+		幾几 庫库 廟庙 異异 徑径 從从 徵征 恥耻 惡恶 慘惨 慣惯 憂忧 憑凭 懷怀 懸悬 懶懒 戀恋 撐撑 撥拨 擠挤
+		攝摄 攤摊 敘叙 斂敛 於于 棄弃 極极 構构 槓杠 樁桩 檢检 櫃柜 欄栏 歐欧 殲歼 毀毁 滅灭 漲涨 潔洁 潛潜
+		澀涩 濕湿 灑洒 熱热 燒烧 營营 爛烂 牽牵 犧牺 狹狭 獅狮 獻献 甕瓮 畢毕 瘡疮 癢痒 盜盗 睏困 瞭了 矯矫
+		碩硕 祕秘 禦御 稅税 穀谷 窮穷 竊窃 筍笋 籃篮 籤签 籲吁 紛纷 紡纺 紹绍 絲丝 綱纲 綿绵 緝缉 縱纵 繞绕
+		纏缠 罵骂 羅罗 翹翘 聳耸 脅胁 腫肿 膚肤 蔔卜 蕩荡 薦荐 薩萨 藍蓝 蘭兰 虛虚 蝦虾 蠟蜡 袞衮 裡里 褲裤
+		襪袜
+	`)
+	m := make(map[rune]rune, len(pairs))
+	for _, pair := range pairs {
+		runes := []rune(pair)
+		if len(runes) == 2 && runes[0] != runes[1] {
+			m[runes[0]] = runes[1]
+		}
+	}
+	return m
+}()
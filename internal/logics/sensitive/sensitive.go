@@ -0,0 +1,202 @@
+package sensitive
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+const defaultReloadInterval = 30 * time.Second
+
+var (
+	sensitiveWordOnce     sync.Once
+	sensitiveWordInstance *sensitiveWord
+)
+
+type sensitiveWord struct {
+	matcher     atomic.Pointer[matcher] // 当前生效的词库，重新加载时整体替换
+	fingerprint atomic.Value            // 词库指纹，用于判断数据库中的词库是否变更
+	reloadMutex sync.Mutex
+	startOnce   sync.Once
+	cancel      context.CancelFunc // 停机时取消，检查词库变更的协程退出
+	wg          sync.WaitGroup
+}
+
+func NewSensitiveWord() service.ISensitiveWord {
+	sensitiveWordOnce.Do(func() {
+		sensitiveWordInstance = &sensitiveWord{}
+		sensitiveWordInstance.matcher.Store(newMatcher(nil))
+		sensitiveWordInstance.fingerprint.Store("")
+	})
+	return sensitiveWordInstance
+}
+
+// 确保sensitiveWord实现了ISensitiveWord接口
+var _ service.ISensitiveWord = (*sensitiveWord)(nil)
+
+// Start 加载词库，并定期检查词库变更，多实例部署时由各实例自行热更新
+func (s *sensitiveWord) Start(ctx context.Context) {
+	s.startOnce.Do(func() {
+		ctx, s.cancel = context.WithCancel(ctx)
+		if err := s.Reload(ctx); err != nil {
+			g.Log().Errorf(ctx, "加载敏感词库失败: %v", err)
+		}
+
+		interval := g.Cfg().MustGet(ctx, "sensitiveWord.reloadInterval", defaultReloadInterval).Duration()
+		if interval <= 0 {
+			interval = defaultReloadInterval
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				if err := s.reloadIfChanged(ctx); err != nil && ctx.Err() == nil {
+					g.Log().Errorf(ctx, "检查敏感词库变更失败: %v", err)
+				}
+			}
+		}()
+	})
+}
+
+// Shutdown 停止检查词库变更，等待执行中的检查完成；ctx 到期后直接返回
+func (s *sensitiveWord) Shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait sensitive word reload exit: %w", ctx.Err())
+	}
+}
+
+// Reload 从数据库重新加载启用的敏感词
+func (s *sensitiveWord) Reload(ctx context.Context) (err error) {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+
+	fingerprint, err := s.getFingerprint(ctx)
+	if err != nil {
+		return
+	}
+	return s.load(ctx, fingerprint)
+}
+
+func (s *sensitiveWord) reloadIfChanged(ctx context.Context) (err error) {
+	s.reloadMutex.Lock()
+	defer s.reloadMutex.Unlock()
+
+	fingerprint, err := s.getFingerprint(ctx)
+	if err != nil {
+		return
+	}
+	if fingerprint == s.fingerprint.Load().(string) {
+		return nil
+	}
+	return s.load(ctx, fingerprint)
+}
+
+func (s *sensitiveWord) load(ctx context.Context, fingerprint string) (err error) {
+	words, err := dao.SensitiveWord.Ctx(ctx).
+		Where(dao.SensitiveWord.Columns().Enabled, 1).
+		Array(dao.SensitiveWord.Columns().Word)
+	if err != nil {
+		return
+	}
+
+	list := make([]string, 0, len(words))
+	for _, word := range words {
+		list = append(list, word.String())
+	}
+	s.matcher.Store(newMatcher(list))
+	s.fingerprint.Store(fingerprint)
+
+	g.Log().Infof(ctx, "敏感词库已加载: count=%d", len(list))
+	return nil
+}
+
+// getFingerprint 词库指纹：记录数、最大ID、最近更新时间，新增、删除、修改都会改变指纹
+func (s *sensitiveWord) getFingerprint(ctx context.Context) (fingerprint string, err error) {
+	record, err := dao.SensitiveWord.Ctx(ctx).Fields(
+		fmt.Sprintf("COUNT(*) AS cnt, IFNULL(MAX(%s), 0) AS max_id, IFNULL(MAX(%s), '') AS max_update_time",
+			dao.SensitiveWord.Columns().ID, dao.SensitiveWord.Columns().UpdateTime),
+	).One()
+	if err != nil {
+		return
+	}
+	return fmt.Sprintf("%d-%d-%s", record["cnt"].Int64(), record["max_id"].Int64(), record["max_update_time"].String()), nil
+}
+
+// Reject 包含敏感词时返回错误
+func (s *sensitiveWord) Reject(ctx context.Context, field, text string) (err error) {
+	hits := s.Match(ctx, text)
+	if len(hits) > 0 {
+		return &model.SensitiveWordError{Field: field, Hits: hits}
+	}
+	return nil
+}
+
+// Mask 敏感词替换为 *，敏感词中间插入的标点、空白等字符一并替换
+func (s *sensitiveWord) Mask(ctx context.Context, text string) (out string) {
+	if text == "" {
+		return text
+	}
+	runes, positions := normalize(text)
+	matches := s.matcher.Load().find(runes)
+	if len(matches) == 0 {
+		return text
+	}
+
+	original := []rune(text)
+	for _, m := range matches {
+		for i := positions[m.start]; i <= positions[m.end]; i++ {
+			original[i] = '*'
+		}
+	}
+	return string(original)
+}
+
+// Match 返回命中的敏感词(去重，按首次出现的顺序)
+func (s *sensitiveWord) Match(ctx context.Context, text string) (hits []string) {
+	if text == "" {
+		return nil
+	}
+	m := s.matcher.Load()
+	runes, _ := normalize(text)
+	matches := m.find(runes)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	seen := make(map[int32]bool, len(matches))
+	for _, match := range matches {
+		if seen[match.word] {
+			continue
+		}
+		seen[match.word] = true
+		hits = append(hits, m.words[match.word])
+	}
+	return
+}
@@ -0,0 +1,117 @@
+package sensitive
+
+import (
+	"GameEngine/internal/dao"
+	"GameEngine/internal/model"
+	"GameEngine/internal/model/entity"
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrSensitiveWordExists    = errors.New("敏感词已存在")
+	ErrSensitiveWordNotExists = errors.New("敏感词不存在")
+	ErrSensitiveWordEmpty     = errors.New("敏感词不能只包含空白或标点")
+)
+
+func (s *sensitiveWord) CreateWord(ctx context.Context, in *model.SensitiveWord) (id int64, err error) {
+	word := strings.TrimSpace(in.Word)
+	if runes, _ := normalize(word); len(runes) == 0 {
+		return 0, ErrSensitiveWordEmpty
+	}
+
+	id, err = dao.SensitiveWord.Ctx(ctx).Data(map[string]interface{}{
+		dao.SensitiveWord.Columns().Word:     word,
+		dao.SensitiveWord.Columns().Category: in.Category,
+		dao.SensitiveWord.Columns().Enabled:  boolToInt(in.Enabled),
+	}).InsertAndGetId()
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			err = ErrSensitiveWordExists
+		}
+		return
+	}
+
+	err = s.Reload(ctx)
+	return
+}
+
+// UpdateWord 更新敏感词分类和启用状态，敏感词本身不可修改
+func (s *sensitiveWord) UpdateWord(ctx context.Context, in *model.SensitiveWord) (err error) {
+	result, err := dao.SensitiveWord.Ctx(ctx).
+		Where(dao.SensitiveWord.Columns().ID, in.ID).
+		Data(map[string]interface{}{
+			dao.SensitiveWord.Columns().Category: in.Category,
+			dao.SensitiveWord.Columns().Enabled:  boolToInt(in.Enabled),
+		}).Update()
+	if err != nil {
+		return
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		return ErrSensitiveWordNotExists
+	}
+
+	return s.Reload(ctx)
+}
+
+func (s *sensitiveWord) DeleteWord(ctx context.Context, id int64) (err error) {
+	result, err := dao.SensitiveWord.Ctx(ctx).Where(dao.SensitiveWord.Columns().ID, id).Delete()
+	if err != nil {
+		return
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		return ErrSensitiveWordNotExists
+	}
+
+	return s.Reload(ctx)
+}
+
+func (s *sensitiveWord) ListWords(ctx context.Context, keyword string, pageReq *model.PageReq) (outs []*model.SensitiveWord, pageRes *model.PageRes, err error) {
+	if pageReq.Page == 0 {
+		pageReq.Page = 1
+	}
+	if pageReq.Size == 0 {
+		pageReq.Size = 10
+	}
+
+	query := dao.SensitiveWord.Ctx(ctx)
+	if keyword != "" {
+		query = query.WhereLike(dao.SensitiveWord.Columns().Word, "%"+keyword+"%")
+	}
+
+	total, err := query.Count()
+	if err != nil {
+		return
+	}
+
+	var entities []*entity.SensitiveWord
+	err = query.OrderDesc(dao.SensitiveWord.Columns().ID).Page(pageReq.Page, pageReq.Size).Scan(&entities)
+	if err != nil {
+		return
+	}
+	for _, entity := range entities {
+		outs = append(outs, model.ConvertSensitiveWordEntityToModel(entity))
+	}
+
+	pageRes = &model.PageRes{
+		Total:       total,
+		CurrentPage: pageReq.Page,
+	}
+	return
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -4,9 +4,14 @@ import (
 	"GameEngine/internal/dao"
 	"GameEngine/internal/model"
 	"GameEngine/internal/model/entity"
+	"GameEngine/internal/model/events"
 	"GameEngine/internal/service"
 	"context"
+	"fmt"
 	"sync"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
 )
 
 var (
@@ -26,15 +31,36 @@ func NewUserBehavier() service.IUserBehavior {
 
 // 记录游戏行为
 func (df *userBehavier) RecordBehavior(ctx context.Context, userID int64, gameID int64, behaviorType model.BehaviorType, ipAddress string, searchKeyword string) error {
-	_, err := dao.UserBehavior.Ctx(ctx).Data(map[string]interface{}{
-		dao.UserBehavior.Columns().UserID:        userID,
-		dao.UserBehavior.Columns().GameID:        gameID,
-		dao.UserBehavior.Columns().BehaviorType:  behaviorType,
-		dao.UserBehavior.Columns().IPAddress:     ipAddress,
-		dao.UserBehavior.Columns().SearchKeyword: searchKeyword,
-	}).Insert()
+	// 搜索关键词会出现在搜索历史和热门搜索中，敏感词打码后再记录
+	if behaviorType == model.BehaviorSearch {
+		searchKeyword = service.SensitiveWord().Mask(ctx, searchKeyword)
+	}
+
+	err := g.DB().Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		behaviorID, err := dao.UserBehavior.Ctx(ctx).TX(tx).Data(map[string]interface{}{
+			dao.UserBehavior.Columns().UserID:        userID,
+			dao.UserBehavior.Columns().GameID:        gameID,
+			dao.UserBehavior.Columns().BehaviorType:  behaviorType,
+			dao.UserBehavior.Columns().IPAddress:     ipAddress,
+			dao.UserBehavior.Columns().SearchKeyword: searchKeyword,
+		}).InsertAndGetId()
+		if err != nil {
+			return err
+		}
+
+		return service.Outbox().AddEvent(ctx, tx, fmt.Sprintf("user_behavior_recorded_%d", behaviorID), &events.BehaviorRecorded{
+			UserID:        userID,
+			GameID:        gameID,
+			BehaviorType:  behaviorType,
+			SearchKeyword: searchKeyword,
+		})
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	service.Outbox().WakeUp()
+	return nil
 }
 
 // 获取搜索历史
@@ -83,6 +109,15 @@ func (df *userBehavier) ClearSearchHistory(ctx context.Context, userID int64) er
 	return err
 }
 
+// 用户注销时删除用户的所有行为记录
+func (df *userBehavier) PurgeUserBehavior(ctx context.Context, userID int64) error {
+	_, err := dao.UserBehavior.Ctx(ctx).
+		Where(dao.UserBehavior.Columns().UserID, userID).
+		Delete()
+
+	return err
+}
+
 // 获取玩过游戏历史
 func (df *userBehavier) GetPlayHistory(ctx context.Context, userID int64, pageReq *model.PageReq) (outs []*model.UserBehavior, pageRes *model.PageRes, err error) {
 	if pageReq.Page == 0 {
@@ -0,0 +1,27 @@
+package logics
+
+import (
+	"context"
+
+	"GameEngine/internal/model"
+	"GameEngine/internal/service"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// HandleUserDeleted 用户注销后删除该用户的收藏、评分、预约和行为记录，游戏的收藏数、评分和预约数同步扣减
+// 在消息去重的事务中执行，任一步骤失败时全部回滚后重试
+func HandleUserDeleted(ctx context.Context, e *model.UserDeletedEvent) error {
+	if err := service.Game().PurgeUserData(ctx, e.UserID); err != nil {
+		return err
+	}
+	if err := service.Reservation().PurgeUserReservations(ctx, e.UserID); err != nil {
+		return err
+	}
+	if err := service.UserBehavior().PurgeUserBehavior(ctx, e.UserID); err != nil {
+		return err
+	}
+
+	g.Log().Infof(ctx, "[MQConsumer]: purged data of deleted user %d", e.UserID)
+	return nil
+}
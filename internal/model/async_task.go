@@ -18,6 +18,11 @@ const (
 	_                                    AsyncTaskType = iota
 	AsyncTaskTypeGameAutoPublish                       // 游戏预约，到时发布
 	AsyncTaskTypeGameNotifyReservedUsers               // 游戏发布后，通知预约用户游戏已上线
+	AsyncTaskTypeReviewClaimExpire                     // 审核领取租约到期，自动释放
+	AsyncTaskTypeGameSLACheck                          // 已废弃，SLA检查改为周期任务(game_sla_check)，保留以免改变后续类型的值
+	AsyncTaskTypeGameApkParse                          // APK安全扫描通过后，解析包信息和签名证书
+	AsyncTaskTypeMediaContentScan                      // 文件上传成功后，扫描恶意内容
+	AsyncTaskTypeRecurring                             // 周期任务的一次执行，按名称分发到注册的处理函数
 )
 
 // 任务执行状态
@@ -27,14 +32,52 @@ const (
 	AsyncTaskStatusPending    AsyncTaskStatus = iota // 待执行
 	AsyncTaskStatusProcessing                        // 执行中
 	AsyncTaskStatusSuccess                           // 执行成功
+	AsyncTaskStatusFailed                            // 执行失败，等待重试
+	AsyncTaskStatusDead                              // 重试次数耗尽，不再执行
+	AsyncTaskStatusCanceled                          // 管理员取消，不再执行
 )
 
+// RunnableAsyncTaskStatuses 到达下次处理时间后可以被工作线程获取的任务状态
+var RunnableAsyncTaskStatuses = []AsyncTaskStatus{
+	AsyncTaskStatusPending,
+	AsyncTaskStatusFailed,
+}
+
+func GetAsyncTaskStatusText(status AsyncTaskStatus) string {
+	switch status {
+	case AsyncTaskStatusPending:
+		return "待执行"
+	case AsyncTaskStatusProcessing:
+		return "执行中"
+	case AsyncTaskStatusSuccess:
+		return "执行成功"
+	case AsyncTaskStatusFailed:
+		return "执行失败"
+	case AsyncTaskStatusDead:
+		return "重试耗尽"
+	case AsyncTaskStatusCanceled:
+		return "已取消"
+	default:
+		return "未知状态"
+	}
+}
+
 func GetAsyncTaskType(op AsyncTaskType) string {
 	switch op {
 	case AsyncTaskTypeGameAutoPublish:
 		return "GameAutoPublish"
 	case AsyncTaskTypeGameNotifyReservedUsers:
 		return "GameNotifyReservedUsers"
+	case AsyncTaskTypeReviewClaimExpire:
+		return "ReviewClaimExpire"
+	case AsyncTaskTypeGameSLACheck:
+		return "GameSLACheck"
+	case AsyncTaskTypeGameApkParse:
+		return "GameApkParse"
+	case AsyncTaskTypeMediaContentScan:
+		return "MediaContentScan"
+	case AsyncTaskTypeRecurring:
+		return "Recurring"
 	default:
 		return "Unknown"
 	}
@@ -43,30 +86,38 @@ func GetAsyncTaskType(op AsyncTaskType) string {
 type AsyncTaskHandler func(ctx context.Context, in *AsyncTask) error
 
 type AsyncTask struct {
-	ID            int64           `json:"id"`
-	CustomID      string          `json:"custom_id"`
-	TaskType      AsyncTaskType   `json:"task_type"`
-	Status        AsyncTaskStatus `json:"status"`
-	RetryCount    int             `json:"retry_count"`
-	Content       interface{}     `json:"content"`
-	Version       int             `json:"version"`
-	NextRetryTime int64           `json:"next_retry_time"`
-	CreateTime    int64           `json:"create_time"`
-	UpdateTime    int64           `json:"update_time"`
+	ID              int64           `json:"id"`
+	CustomID        string          `json:"custom_id"`
+	TaskType        AsyncTaskType   `json:"task_type"`
+	Status          AsyncTaskStatus `json:"status"`
+	RetryCount      int             `json:"retry_count"`
+	Content         interface{}     `json:"content"`
+	Version         int             `json:"version"`
+	NextRetryTime   int64           `json:"next_retry_time"`
+	LastError       string          `json:"last_error"`
+	LastErrorStack  string          `json:"last_error_stack"`
+	LeaseOwner      string          `json:"lease_owner"`
+	LeaseExpireTime int64           `json:"lease_expire_time"`
+	CreateTime      int64           `json:"create_time"`
+	UpdateTime      int64           `json:"update_time"`
 }
 
 func ConvertAsyncTaskEntityToModel(in *entity.AsyncTask) (out *AsyncTask, err error) {
 	out = &AsyncTask{
-		ID:            in.ID,
-		CustomID:      in.CustomID,
-		TaskType:      AsyncTaskType(in.TaskType),
-		Status:        AsyncTaskStatus(in.Status),
-		RetryCount:    in.RetryCount,
-		Content:       in.Content,
-		Version:       in.Version,
-		NextRetryTime: in.NextRetryTime,
-		CreateTime:    in.CreateTime,
-		UpdateTime:    in.UpdateTime,
+		ID:              in.ID,
+		CustomID:        in.CustomID,
+		TaskType:        AsyncTaskType(in.TaskType),
+		Status:          AsyncTaskStatus(in.Status),
+		RetryCount:      in.RetryCount,
+		Content:         in.Content,
+		Version:         in.Version,
+		NextRetryTime:   in.NextRetryTime,
+		LastError:       in.LastError,
+		LastErrorStack:  in.LastErrorStack,
+		LeaseOwner:      in.LeaseOwner,
+		LeaseExpireTime: in.LeaseExpireTime,
+		CreateTime:      in.CreateTime,
+		UpdateTime:      in.UpdateTime,
 	}
 	err = json.Unmarshal([]byte(in.Content), &out.Content)
 	if err != nil {
@@ -75,3 +126,17 @@ func ConvertAsyncTaskEntityToModel(in *entity.AsyncTask) (out *AsyncTask, err er
 
 	return out, nil
 }
+
+// AsyncTaskFilter 管理端查询任务的过滤条件，为空表示不过滤
+type AsyncTaskFilter struct {
+	TaskType *AsyncTaskType
+	Status   *AsyncTaskStatus
+	CustomID string
+}
+
+// AsyncTaskStats 单个任务类型的任务数量和积压情况
+type AsyncTaskStats struct {
+	TaskType         AsyncTaskType
+	StatusCounts     map[AsyncTaskStatus]int // 各状态的任务数量
+	OldestPendingAge int64                   // 已到处理时间但未执行的任务中，最早的处理时间距今的秒数
+}
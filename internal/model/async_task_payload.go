@@ -0,0 +1,219 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+/*
+带版本号的任务内容
+1、任务内容是 JSON 对象，schema_version 字段记录写入时的版本号，没有该字段的历史任务按版本 1 处理。
+2、修改任务内容结构时版本号加 1，并在 AsyncTaskUpgrades 中提供旧版本升级到下一版本的函数，执行时逐级升级到当前版本后再解析。
+3、内容无法解析、升级失败或校验不通过的任务不会重试，直接标记为重试耗尽并告警；版本号高于当前版本(新版本实例写入)的任务按普通错误重试。
+*/
+
+// AsyncTaskSchemaVersionKey 任务内容中记录版本号的字段
+const AsyncTaskSchemaVersionKey = "schema_version"
+
+// ErrMalformedAsyncTaskContent 任务内容格式错误，重试也无法执行
+var ErrMalformedAsyncTaskContent = errors.New("malformed async task content")
+
+// AsyncTaskPayload 带版本号的任务内容
+type AsyncTaskPayload interface {
+	// AsyncTaskSchemaVersion 当前版本号，从 1 开始
+	AsyncTaskSchemaVersion() int
+}
+
+// AsyncTaskUpgrader 把任务内容从某个版本升级到下一版本，直接修改传入的内容
+type AsyncTaskUpgrader func(content map[string]interface{}) error
+
+// AsyncTaskPayloadUpgrades 任务内容有历史版本时实现，按旧版本号提供升级函数
+type AsyncTaskPayloadUpgrades interface {
+	AsyncTaskUpgrades() map[int]AsyncTaskUpgrader
+}
+
+// AsyncTaskPayloadValidator 任务内容需要校验时实现，校验不通过的任务不再重试
+type AsyncTaskPayloadValidator interface {
+	Validate() error
+}
+
+// MarshalAsyncTaskPayload 序列化任务内容，写入当前版本号
+func MarshalAsyncTaskPayload(payload AsyncTaskPayload) ([]byte, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	content := make(map[string]interface{})
+	if err = json.Unmarshal(payloadBytes, &content); err != nil {
+		return nil, fmt.Errorf("任务内容必须是JSON对象: %v", err)
+	}
+	content[AsyncTaskSchemaVersionKey] = payload.AsyncTaskSchemaVersion()
+	return json.Marshal(content)
+}
+
+// DecodeAsyncTaskPayload 把任务内容升级到当前版本后解析
+func DecodeAsyncTaskPayload[T AsyncTaskPayload](task *AsyncTask) (*T, error) {
+	var payload T
+	currentVersion := payload.AsyncTaskSchemaVersion()
+
+	content, ok := task.Content.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: 任务内容不是JSON对象", ErrMalformedAsyncTaskContent)
+	}
+	version := 1
+	if v, ok := content[AsyncTaskSchemaVersionKey]; ok {
+		number, ok := v.(float64)
+		if !ok || number < 1 || number != float64(int(number)) {
+			return nil, fmt.Errorf("%w: 版本号格式错误: %v", ErrMalformedAsyncTaskContent, v)
+		}
+		version = int(number)
+	}
+	if version > currentVersion {
+		return nil, fmt.Errorf("任务内容版本%d高于当前支持的版本%d，等待新版本实例执行", version, currentVersion)
+	}
+
+	var upgrades map[int]AsyncTaskUpgrader
+	if u, ok := any(payload).(AsyncTaskPayloadUpgrades); ok {
+		upgrades = u.AsyncTaskUpgrades()
+	}
+	for ; version < currentVersion; version++ {
+		upgrade, ok := upgrades[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: 缺少版本%d的升级函数", ErrMalformedAsyncTaskContent, version)
+		}
+		if err := upgrade(content); err != nil {
+			return nil, fmt.Errorf("%w: 从版本%d升级失败: %v", ErrMalformedAsyncTaskContent, version, err)
+		}
+	}
+
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedAsyncTaskContent, err)
+	}
+	if err = json.Unmarshal(contentBytes, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedAsyncTaskContent, err)
+	}
+	if v, ok := any(&payload).(AsyncTaskPayloadValidator); ok {
+		if err = v.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformedAsyncTaskContent, err)
+		}
+	}
+	return &payload, nil
+}
+
+// TypedAsyncTaskHandler 把按类型处理任务内容的函数转换为任务处理函数
+func TypedAsyncTaskHandler[T AsyncTaskPayload](handler func(ctx context.Context, payload *T) error) AsyncTaskHandler {
+	return func(ctx context.Context, task *AsyncTask) error {
+		payload, err := DecodeAsyncTaskPayload[T](task)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, payload)
+	}
+}
+
+// GameAutoPublishTask 游戏预约到时发布
+type GameAutoPublishTask struct {
+	GameID      int64       `json:"game_id"`
+	PublishTime *gtime.Time `json:"publish_time"`
+}
+
+func (GameAutoPublishTask) AsyncTaskSchemaVersion() int { return 1 }
+
+func (t *GameAutoPublishTask) Validate() error {
+	if t.GameID <= 0 {
+		return fmt.Errorf("游戏ID格式错误")
+	}
+	return nil
+}
+
+// GameNotifyReservedUsersTask 游戏发布后通知预约用户
+type GameNotifyReservedUsersTask struct {
+	GameID int64 `json:"game_id"`
+}
+
+func (GameNotifyReservedUsersTask) AsyncTaskSchemaVersion() int { return 1 }
+
+func (t *GameNotifyReservedUsersTask) Validate() error {
+	if t.GameID <= 0 {
+		return fmt.Errorf("游戏ID格式错误")
+	}
+	return nil
+}
+
+// ReviewClaimExpireTask 审核领取租约到期自动释放
+// 版本 2：领取版本号 version 改名为 claim_version，避免与任务版本号混淆
+type ReviewClaimExpireTask struct {
+	GameID       int64 `json:"game_id"`
+	ClaimVersion int   `json:"claim_version"`
+}
+
+func (ReviewClaimExpireTask) AsyncTaskSchemaVersion() int { return 2 }
+
+func (ReviewClaimExpireTask) AsyncTaskUpgrades() map[int]AsyncTaskUpgrader {
+	return map[int]AsyncTaskUpgrader{
+		1: func(content map[string]interface{}) error {
+			version, ok := content["version"]
+			if !ok {
+				return fmt.Errorf("缺少领取版本")
+			}
+			content["claim_version"] = version
+			delete(content, "version")
+			return nil
+		},
+	}
+}
+
+func (t *ReviewClaimExpireTask) Validate() error {
+	if t.GameID <= 0 {
+		return fmt.Errorf("游戏ID格式错误")
+	}
+	return nil
+}
+
+// GameApkParseTask 解析APK包信息和签名证书
+type GameApkParseTask struct {
+	FileID string `json:"file_id"`
+}
+
+func (GameApkParseTask) AsyncTaskSchemaVersion() int { return 1 }
+
+func (t *GameApkParseTask) Validate() error {
+	if t.FileID == "" {
+		return fmt.Errorf("文件ID格式错误")
+	}
+	return nil
+}
+
+// MediaContentScanTask 扫描上传文件的恶意内容
+type MediaContentScanTask struct {
+	FileID string `json:"file_id"`
+}
+
+func (MediaContentScanTask) AsyncTaskSchemaVersion() int { return 1 }
+
+func (t *MediaContentScanTask) Validate() error {
+	if t.FileID == "" {
+		return fmt.Errorf("文件ID格式错误")
+	}
+	return nil
+}
+
+// RecurringTaskRun 周期任务的一次执行
+type RecurringTaskRun struct {
+	Name          string `json:"name"`
+	ScheduledTime int64  `json:"scheduled_time"` // 计划执行时间
+}
+
+func (RecurringTaskRun) AsyncTaskSchemaVersion() int { return 1 }
+
+func (t *RecurringTaskRun) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("周期任务名称格式错误")
+	}
+	return nil
+}
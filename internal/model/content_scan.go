@@ -0,0 +1,8 @@
+package model
+
+// ContentScanResult 文件内容安全扫描结果
+type ContentScanResult struct {
+	Infected  bool   `json:"infected" dc:"是否发现恶意内容"`
+	Signature string `json:"signature" dc:"命中的病毒特征名称"`
+	Engine    string `json:"engine" dc:"扫描引擎"`
+}
@@ -11,6 +11,11 @@ type Game struct {
 	Description    string `orm:"description" dc:"描述"`
 	Details        string `orm:"details" dc:"详情"`
 
+	NamePinyin        string `orm:"name_pinyin" dc:"名称全拼"`
+	NameInitials      string `orm:"name_initials" dc:"名称拼音首字母"`
+	DeveloperPinyin   string `orm:"developer_pinyin" dc:"开发商全拼"`
+	DeveloperInitials string `orm:"developer_initials" dc:"开发商拼音首字母"`
+
 	Status       int         `orm:"status" dc:"状态"`
 	PublishTime  *gtime.Time `orm:"publish_time" dc:"发布时间"`
 	ReserveCount int64       `orm:"reserve_count" dc:"预约次数"`
@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameApkInfo APK安装包解析结果实体
+type GameApkInfo struct {
+	ID              int64       `orm:"id" dc:"主键"`
+	GameID          int64       `orm:"game_id" dc:"游戏ID"`
+	FileID          string      `orm:"file_id" dc:"文件ID"`
+	Status          int         `orm:"status" dc:"解析状态"`
+	PackageName     string      `orm:"package_name" dc:"包名"`
+	VersionCode     int64       `orm:"version_code" dc:"版本号"`
+	VersionName     string      `orm:"version_name" dc:"版本名称"`
+	MinSdk          int         `orm:"min_sdk" dc:"最低SDK版本"`
+	TargetSdk       int         `orm:"target_sdk" dc:"目标SDK版本"`
+	Permissions     string      `orm:"permissions" dc:"申请的权限"`
+	SignatureScheme string      `orm:"signature_scheme" dc:"签名方案"`
+	CertSha256      string      `orm:"cert_sha256" dc:"签名证书SHA-256指纹"`
+	CertSubject     string      `orm:"cert_subject" dc:"签名证书主题"`
+	Error           string      `orm:"error" dc:"解析失败原因"`
+	ApproveTime     *gtime.Time `orm:"approve_time" dc:"审核通过时间"`
+	CreateTime      *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime      *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameBuild 游戏构建版本实体
+type GameBuild struct {
+	ID           int64       `orm:"id" dc:"主键"`
+	GameID       int64       `orm:"game_id" dc:"游戏ID"`
+	VersionName  string      `orm:"version_name" dc:"版本名称"`
+	VersionCode  int64       `orm:"version_code" dc:"版本号"`
+	ReleaseNotes string      `orm:"release_notes" dc:"更新说明"`
+	FileID       string      `orm:"file_id" dc:"文件ID"`
+	FileSize     int64       `orm:"file_size" dc:"文件大小"`
+	Checksum     string      `orm:"checksum" dc:"文件校验和"`
+	MediaUrl     string      `orm:"media_url" dc:"文件URL"`
+	FileStatus   int         `orm:"file_status" dc:"文件上传状态"`
+	Status       int         `orm:"status" dc:"审核状态"`
+	RejectReason string      `orm:"reject_reason" dc:"拒绝原因"`
+	IsCurrent    int         `orm:"is_current" dc:"是否为当前版本"`
+	CreateTime   *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime   *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
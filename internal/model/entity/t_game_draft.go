@@ -0,0 +1,22 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameDraft 游戏草稿实体
+type GameDraft struct {
+	ID             int64  `orm:"id" dc:"主键"`
+	GameID         int64  `orm:"game_id" dc:"游戏ID"`
+	Name           string `orm:"name" dc:"名称"`
+	DistributeType int    `orm:"distribute_type" dc:"类型"`
+	Developer      string `orm:"developer" dc:"开发商"`
+	Publisher      string `orm:"publisher" dc:"发行商"`
+	Description    string `orm:"description" dc:"描述"`
+	Details        string `orm:"details" dc:"详情"`
+	CategoryID     int64  `orm:"category_id" dc:"分类ID"`
+	TagIDs         string `orm:"tag_ids" dc:"标签ID列表(JSON)"`
+
+	Status     int         `orm:"status" dc:"草稿状态"`
+	Version    int         `orm:"version" dc:"版本"`
+	CreateTime *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
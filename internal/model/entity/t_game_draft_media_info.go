@@ -0,0 +1,18 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameDraftMediaInfo 游戏草稿媒体信息实体
+type GameDraftMediaInfo struct {
+	ID         int64       `orm:"id" dc:"主键"`
+	GameID     int64       `orm:"game_id" dc:"游戏ID"`
+	FileID     string      `orm:"file_id" dc:"文件ID"`
+	MediaType  int         `orm:"media_type" dc:"媒体类型"`
+	MediaUrl   string      `orm:"media_url" dc:"媒体URL"`
+	FileSize   int64       `orm:"file_size" dc:"文件大小(字节)"`
+	Width      int         `orm:"width" dc:"宽度(像素)"`
+	Height     int         `orm:"height" dc:"高度(像素)"`
+	Status     int         `orm:"status" dc:"状态"`
+	CreateTime *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
@@ -9,6 +9,9 @@ type GameMediaInfo struct {
 	FileID     string      `orm:"file_id" dc:"文件ID"`
 	MediaType  int         `orm:"media_type" dc:"媒体类型"`
 	MediaUrl   string      `orm:"media_url" dc:"媒体URL"`
+	FileSize   int64       `orm:"file_size" dc:"文件大小(字节)"`
+	Width      int         `orm:"width" dc:"宽度(像素)"`
+	Height     int         `orm:"height" dc:"高度(像素)"`
 	Status     int         `orm:"status" dc:"状态"`
 	CreateTime *gtime.Time `orm:"create_time" dc:"创建时间"`
 	UpdateTime *gtime.Time `orm:"update_time" dc:"更新时间"`
@@ -0,0 +1,12 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameReviewApproval 游戏审核通过记录实体
+type GameReviewApproval struct {
+	ID           int64       `orm:"id" dc:"主键"`
+	GameID       int64       `orm:"game_id" dc:"游戏ID"`
+	ReviewerID   int64       `orm:"reviewer_id" dc:"审核人ID"`
+	ReviewerName string      `orm:"reviewer_name" dc:"审核人名称"`
+	CreateTime   *gtime.Time `orm:"create_time" dc:"创建时间"`
+}
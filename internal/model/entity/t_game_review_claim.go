@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameReviewClaim 游戏审核领取实体
+type GameReviewClaim struct {
+	ID              int64       `orm:"id" dc:"主键"`
+	GameID          int64       `orm:"game_id" dc:"游戏ID"`
+	ReviewerID      int64       `orm:"reviewer_id" dc:"审核人ID"`
+	ReviewerName    string      `orm:"reviewer_name" dc:"审核人名称"`
+	LeaseExpireTime *gtime.Time `orm:"lease_expire_time" dc:"租约到期时间"`
+	Version         int         `orm:"version" dc:"并发版本控制"`
+	CreateTime      *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime      *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameReviewComment 游戏审核问题讨论实体
+type GameReviewComment struct {
+	ID         int64       `orm:"id" dc:"主键"`
+	IssueID    int64       `orm:"issue_id" dc:"问题ID"`
+	GameID     int64       `orm:"game_id" dc:"游戏ID"`
+	UserID     int64       `orm:"user_id" dc:"用户ID"`
+	UserName   string      `orm:"user_name" dc:"用户名"`
+	Content    string      `orm:"content" dc:"内容"`
+	CreateTime *gtime.Time `orm:"create_time" dc:"创建时间"`
+}
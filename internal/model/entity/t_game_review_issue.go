@@ -0,0 +1,19 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameReviewIssue 游戏审核问题实体
+type GameReviewIssue struct {
+	ID           int64       `orm:"id" dc:"主键"`
+	GameID       int64       `orm:"game_id" dc:"游戏ID"`
+	Code         string      `orm:"code" dc:"拒绝原因编码"`
+	Field        string      `orm:"field" dc:"问题字段"`
+	Comment      string      `orm:"comment" dc:"审核意见"`
+	Status       int         `orm:"status" dc:"问题状态"`
+	ReviewerID   int64       `orm:"reviewer_id" dc:"审核人ID"`
+	ReviewerName string      `orm:"reviewer_name" dc:"审核人名称"`
+	ResolvedBy   int64       `orm:"resolved_by" dc:"解决人ID"`
+	ResolveTime  *gtime.Time `orm:"resolve_time" dc:"解决时间"`
+	CreateTime   *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime   *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameStatusHistory 游戏状态变更历史实体
+type GameStatusHistory struct {
+	ID           int64       `orm:"id" dc:"主键"`
+	GameID       int64       `orm:"game_id" dc:"游戏ID"`
+	FromStatus   int         `orm:"from_status" dc:"变更前状态"`
+	ToStatus     int         `orm:"to_status" dc:"变更后状态"`
+	Event        int         `orm:"event" dc:"触发事件"`
+	OperatorID   int64       `orm:"operator_id" dc:"操作人ID"`
+	OperatorName string      `orm:"operator_name" dc:"操作人名称"`
+	Reason       string      `orm:"reason" dc:"原因"`
+	EventData    string      `orm:"event_data" dc:"事件数据"`
+	CreateTime   *gtime.Time `orm:"create_time" dc:"创建时间"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameStatusPeriod 游戏状态停留区间实体
+type GameStatusPeriod struct {
+	ID             int64       `orm:"id" dc:"主键"`
+	GameID         int64       `orm:"game_id" dc:"游戏ID"`
+	Status         int         `orm:"status" dc:"状态"`
+	EnterTime      *gtime.Time `orm:"enter_time" dc:"进入状态时间"`
+	LeaveTime      *gtime.Time `orm:"leave_time" dc:"离开状态时间"`
+	Duration       int64       `orm:"duration" dc:"停留时长(秒)"`
+	OverdueAlerted int         `orm:"overdue_alerted" dc:"是否已发送超时告警"`
+	CreateTime     *gtime.Time `orm:"create_time" dc:"创建时间"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// GameValidationFinding 提交审核检查结果实体
+type GameValidationFinding struct {
+	ID         int64       `orm:"id" dc:"主键"`
+	GameID     int64       `orm:"game_id" dc:"游戏ID"`
+	Draft      int         `orm:"draft" dc:"是否为草稿提交"`
+	Validator  string      `orm:"validator" dc:"检查项"`
+	Severity   int         `orm:"severity" dc:"严重程度"`
+	Field      string      `orm:"field" dc:"问题字段"`
+	Message    string      `orm:"message" dc:"问题描述"`
+	CreateTime *gtime.Time `orm:"create_time" dc:"创建时间"`
+}
@@ -0,0 +1,8 @@
+package entity
+
+type MQConsumedMessage struct {
+	ID         int64  `orm:"id"`
+	Consumer   string `orm:"consumer"`
+	MessageID  string `orm:"message_id"`
+	CreateTime int64  `orm:"create_time"`
+}
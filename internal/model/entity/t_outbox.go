@@ -0,0 +1,18 @@
+package entity
+
+type Outbox struct {
+	ID              int64  `orm:"id"`
+	Topic           string `orm:"topic"`
+	EventType       string `orm:"event_type"`
+	DedupKey        string `orm:"dedup_key"`
+	Payload         string `orm:"payload"`
+	Status          int    `orm:"status"`
+	RetryCount      int    `orm:"retry_count"`
+	NextRetryTime   int64  `orm:"next_retry_time"`
+	LastError       string `orm:"last_error"`
+	LeaseOwner      string `orm:"lease_owner"`
+	LeaseExpireTime int64  `orm:"lease_expire_time"`
+	PublishTime     int64  `orm:"publish_time"`
+	CreateTime      int64  `orm:"create_time"`
+	UpdateTime      int64  `orm:"update_time"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+type RecurringTask struct {
+	ID          int64  `orm:"id"`
+	Name        string `orm:"name"`
+	CronSpec    string `orm:"cron_spec"`
+	Paused      int    `orm:"paused"`
+	NextRunTime int64  `orm:"next_run_time"`
+	LastRunTime int64  `orm:"last_run_time"`
+	Version     int    `orm:"version"`
+	CreateTime  int64  `orm:"create_time"`
+	UpdateTime  int64  `orm:"update_time"`
+}
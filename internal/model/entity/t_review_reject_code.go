@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// ReviewRejectCode 审核拒绝原因实体
+type ReviewRejectCode struct {
+	ID          int64       `orm:"id" dc:"主键"`
+	Code        string      `orm:"code" dc:"拒绝原因编码"`
+	Title       string      `orm:"title" dc:"标题"`
+	Description string      `orm:"description" dc:"描述"`
+	Field       string      `orm:"field" dc:"默认关联字段"`
+	Enabled     int         `orm:"enabled" dc:"是否启用"`
+	CreateTime  *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime  *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "github.com/gogf/gf/v2/os/gtime"
+
+// SensitiveWord 敏感词实体
+type SensitiveWord struct {
+	ID         int64       `orm:"id" dc:"主键"`
+	Word       string      `orm:"word" dc:"敏感词"`
+	Category   string      `orm:"category" dc:"分类"`
+	Enabled    int         `orm:"enabled" dc:"是否启用"`
+	CreateTime *gtime.Time `orm:"create_time" dc:"创建时间"`
+	UpdateTime *gtime.Time `orm:"update_time" dc:"更新时间"`
+}
@@ -5,6 +5,8 @@ import "github.com/gogf/gf/v2/os/gtime"
 type Tag struct {
 	ID         int64       `orm:"id"`
 	Name       string      `orm:"name"`
+	Pinyin     string      `orm:"pinyin"`
+	Initials   string      `orm:"initials"`
 	CreateTime *gtime.Time `orm:"create_time"`
 	UpdateTime *gtime.Time `orm:"update_time"`
 }
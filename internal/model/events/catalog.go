@@ -0,0 +1,150 @@
+package events
+
+import (
+	"fmt"
+
+	"GameEngine/internal/model"
+)
+
+// 事件类型
+const (
+	TypeGameCreated         = TypePrefix + "game.created.v1"
+	TypeGameStatusChanged   = TypePrefix + "game.status_changed.v1"
+	TypeGameDeleted         = TypePrefix + "game.deleted.v1"
+	TypeMediaScanned        = TypePrefix + "media.scanned.v1"
+	TypeReservationCreated  = TypePrefix + "reservation.created.v1"
+	TypeReservationCanceled = TypePrefix + "reservation.canceled.v1"
+	TypeRatingAdded         = TypePrefix + "rating.added.v1"
+	TypeFavoriteAdded       = TypePrefix + "favorite.added.v1"
+	TypeFavoriteRemoved     = TypePrefix + "favorite.removed.v1"
+	TypeBehaviorRecorded    = TypePrefix + "behavior.recorded.v1"
+)
+
+// catalog 所有事件类型，按业务分组
+var catalog = []*Definition{
+	// 游戏生命周期
+	define(&GameCreated{}, "core.event.game-created", "games/{game_id}", "游戏创建"),
+	define(&GameStatusChanged{}, "core.event.game-status-changed", "games/{game_id}", "游戏状态变更，每条状态变更历史对应一个事件"),
+	define(&GameDeleted{}, "core.event.game-deleted", "games/{game_id}", "游戏删除"),
+	// 媒体文件
+	define(&MediaScanned{}, "core.event.media-scanned", "files/{file_id}", "媒体文件内容安全扫描完成，发现恶意内容的文件已删除"),
+	// 预约
+	define(&ReservationCreated{}, "core.event.game-reservation-created", "games/{game_id}", "用户预约游戏"),
+	define(&ReservationCanceled{}, "core.event.game-reservation-canceled", "games/{game_id}", "用户取消预约"),
+	// 评分
+	define(&RatingAdded{}, "core.event.game-rating-added", "games/{game_id}", "用户评分，每个用户对每个游戏只能评分一次"),
+	// 收藏
+	define(&FavoriteAdded{}, "core.event.game-favorite-added", "games/{game_id}", "用户收藏游戏"),
+	define(&FavoriteRemoved{}, "core.event.game-favorite-removed", "games/{game_id}", "用户取消收藏"),
+	// 用户行为
+	define(&BehaviorRecorded{}, "core.event.user-behavior-recorded", "users/{user_id}", "用户搜索、游玩、下载行为"),
+}
+
+func gameSubject(gameID int64) string {
+	return fmt.Sprintf("games/%d", gameID)
+}
+
+// GameCreated 游戏创建
+type GameCreated struct {
+	GameID         int64                    `json:"game_id" dc:"游戏ID"`
+	Name           string                   `json:"name" dc:"游戏名称"`
+	DistributeType model.GameDistributeType `json:"distribute_type" dc:"分发类型(1:APK,2:链接)"`
+	Developer      string                   `json:"developer" dc:"开发商"`
+	Publisher      string                   `json:"publisher" dc:"发行商"`
+	CategoryID     int64                    `json:"category_id" dc:"分类ID"`
+	TagIDs         []int64                  `json:"tag_ids" dc:"标签ID列表"`
+}
+
+func (GameCreated) EventType() string      { return TypeGameCreated }
+func (e GameCreated) EventSubject() string { return gameSubject(e.GameID) }
+
+// GameStatusChanged 游戏状态变更
+type GameStatusChanged struct {
+	GameID       int64            `json:"game_id" dc:"游戏ID"`
+	FromStatus   model.GameStatus `json:"from_status" dc:"变更前状态(0:初始,1:审核中,2:审核通过,3:可预约,4:已上架,5:已下架)"`
+	ToStatus     model.GameStatus `json:"to_status" dc:"变更后状态，取值同 from_status"`
+	Event        model.GameEvent  `json:"event" dc:"触发状态变更的事件(0:提交审核,1:审核通过,2:审核失败,3:预约发布,4:立即发布,5:更新游戏信息,6:取消预约发布,7:立即下架,8:更新游戏版本,9:自动发布,10:草稿提交审核,11:草稿审核通过,12:草稿审核失败)"`
+	OperatorID   int64            `json:"operator_id" dc:"操作人ID，0 表示系统操作"`
+	OperatorName string           `json:"operator_name" dc:"操作人名称"`
+	Reason       string           `json:"reason" dc:"变更原因(驳回、下架原因等)"`
+}
+
+func (GameStatusChanged) EventType() string      { return TypeGameStatusChanged }
+func (e GameStatusChanged) EventSubject() string { return gameSubject(e.GameID) }
+
+// GameDeleted 游戏删除
+type GameDeleted struct {
+	GameID int64 `json:"game_id" dc:"游戏ID"`
+}
+
+func (GameDeleted) EventType() string      { return TypeGameDeleted }
+func (e GameDeleted) EventSubject() string { return gameSubject(e.GameID) }
+
+// MediaScanned 媒体文件内容安全扫描完成
+type MediaScanned struct {
+	FileID    string                `json:"file_id" dc:"文件ID"`
+	Status    model.GameMediaStatus `json:"status" dc:"扫描后的文件状态(5:存在安全风险,6:安全扫描通过)"`
+	Infected  bool                  `json:"infected" dc:"是否发现恶意内容"`
+	Engine    string                `json:"engine" dc:"扫描引擎"`
+	Signature string                `json:"signature,omitempty" dc:"命中的病毒特征名称"`
+}
+
+func (MediaScanned) EventType() string      { return TypeMediaScanned }
+func (e MediaScanned) EventSubject() string { return "files/" + e.FileID }
+
+// ReservationCreated 用户预约游戏
+type ReservationCreated struct {
+	GameID int64 `json:"game_id" dc:"游戏ID"`
+	UserID int64 `json:"user_id" dc:"用户ID"`
+}
+
+func (ReservationCreated) EventType() string      { return TypeReservationCreated }
+func (e ReservationCreated) EventSubject() string { return gameSubject(e.GameID) }
+
+// ReservationCanceled 用户取消预约
+type ReservationCanceled struct {
+	GameID int64 `json:"game_id" dc:"游戏ID"`
+	UserID int64 `json:"user_id" dc:"用户ID"`
+}
+
+func (ReservationCanceled) EventType() string      { return TypeReservationCanceled }
+func (e ReservationCanceled) EventSubject() string { return gameSubject(e.GameID) }
+
+// RatingAdded 用户评分
+type RatingAdded struct {
+	GameID int64 `json:"game_id" dc:"游戏ID"`
+	UserID int64 `json:"user_id" dc:"用户ID"`
+	Score  int   `json:"score" dc:"评分(1-5)"`
+}
+
+func (RatingAdded) EventType() string      { return TypeRatingAdded }
+func (e RatingAdded) EventSubject() string { return gameSubject(e.GameID) }
+
+// FavoriteAdded 用户收藏游戏
+type FavoriteAdded struct {
+	GameID int64 `json:"game_id" dc:"游戏ID"`
+	UserID int64 `json:"user_id" dc:"用户ID"`
+}
+
+func (FavoriteAdded) EventType() string      { return TypeFavoriteAdded }
+func (e FavoriteAdded) EventSubject() string { return gameSubject(e.GameID) }
+
+// FavoriteRemoved 用户取消收藏
+type FavoriteRemoved struct {
+	GameID int64 `json:"game_id" dc:"游戏ID"`
+	UserID int64 `json:"user_id" dc:"用户ID"`
+}
+
+func (FavoriteRemoved) EventType() string      { return TypeFavoriteRemoved }
+func (e FavoriteRemoved) EventSubject() string { return gameSubject(e.GameID) }
+
+// BehaviorRecorded 用户行为，不包含IP地址
+type BehaviorRecorded struct {
+	UserID        int64              `json:"user_id" dc:"用户ID"`
+	GameID        int64              `json:"game_id" dc:"游戏ID，搜索行为为 0"`
+	BehaviorType  model.BehaviorType `json:"behavior_type" dc:"行为类型(1:搜索,2:游玩,3:下载)"`
+	SearchKeyword string             `json:"search_keyword,omitempty" dc:"搜索关键词(已打码)"`
+}
+
+func (BehaviorRecorded) EventType() string      { return TypeBehaviorRecorded }
+func (e BehaviorRecorded) EventSubject() string { return fmt.Sprintf("users/%d", e.UserID) }
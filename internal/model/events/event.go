@@ -0,0 +1,58 @@
+// Package events 领域事件目录
+//
+// 发送到消息队列的领域事件统一使用 CloudEvents 1.0 JSON 格式(structured mode)：
+// id 等于发件箱的去重键，消费方按 id 去重；type 带主版本号(如 gameengine.game.created.v1)，
+// subject 为事件关联的资源(如 games/123)，data 为事件类型对应的结构体。
+//
+// 版本规则：
+// 1、只新增字段时不修改版本号，消费方需要忽略不认识的字段。
+// 2、删除字段、修改字段类型或含义时新增一个版本号加 1 的事件类型，迁移期间新旧版本同时发送，消费方全部迁移后再删除旧版本。
+// 3、所有事件类型都需要在 catalog 中注册，管理接口 /admin/event-catalog 返回事件类型列表和 data 的 JSON Schema。
+package events
+
+import "time"
+
+const (
+	// SpecVersion CloudEvents 规范版本
+	SpecVersion = "1.0"
+	// Source 事件来源
+	Source = "/game-engine"
+	// DataContentType data 的格式
+	DataContentType = "application/json"
+	// TypePrefix 事件类型前缀
+	TypePrefix = "gameengine."
+)
+
+// Event 领域事件，每个实现都需要在 catalog 中注册
+type Event interface {
+	// EventType 事件类型，带主版本号
+	EventType() string
+	// EventSubject 事件关联的资源
+	EventSubject() string
+}
+
+// CloudEvent CloudEvents 1.0 消息体
+type CloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time"` // RFC3339 格式
+	DataContentType string `json:"datacontenttype"`
+	Data            Event  `json:"data"`
+}
+
+// New 生成 CloudEvents 消息体，id 需要在同一事件来源内唯一
+func New(id string, e Event, occurredAt time.Time) *CloudEvent {
+	return &CloudEvent{
+		SpecVersion:     SpecVersion,
+		ID:              id,
+		Source:          Source,
+		Type:            e.EventType(),
+		Subject:         e.EventSubject(),
+		Time:            occurredAt.UTC().Format(time.RFC3339),
+		DataContentType: DataContentType,
+		Data:            e,
+	}
+}
@@ -0,0 +1,131 @@
+package events
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONSchemaDialect 生成的 JSON Schema 版本
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Definition 事件类型定义
+type Definition struct {
+	Type        string                 // 事件类型
+	Topic       string                 // 发送到的消息队列主题
+	Subject     string                 // subject 格式
+	Description string                 // 说明
+	DataSchema  map[string]interface{} // data 的 JSON Schema，由结构体字段的 json、dc 标签生成
+}
+
+var registry = make(map[string]*Definition)
+
+func init() {
+	for _, definition := range catalog {
+		if _, ok := registry[definition.Type]; ok {
+			panic(fmt.Sprintf("[Event]: event type registered twice: %s", definition.Type))
+		}
+		registry[definition.Type] = definition
+	}
+}
+
+// Lookup 查询事件类型定义
+func Lookup(eventType string) (definition *Definition, ok bool) {
+	definition, ok = registry[eventType]
+	return
+}
+
+// Definitions 所有事件类型定义，按 catalog 中的顺序
+func Definitions() []*Definition {
+	return catalog
+}
+
+func define(sample Event, topic string, subject string, description string) *Definition {
+	eventType := sample.EventType()
+	if !strings.HasPrefix(eventType, TypePrefix) {
+		panic(fmt.Sprintf("[Event]: event type must start with %s: %s", TypePrefix, eventType))
+	}
+
+	sampleType := reflect.TypeOf(sample)
+	if sampleType.Kind() == reflect.Pointer {
+		sampleType = sampleType.Elem()
+	}
+	schema := typeSchema(sampleType)
+	schema["$schema"] = JSONSchemaDialect
+	schema["$id"] = eventType
+	schema["title"] = eventType
+	schema["description"] = description
+	return &Definition{
+		Type:        eventType,
+		Topic:       topic,
+		Subject:     subject,
+		Description: description,
+		DataSchema:  schema,
+	}
+}
+
+// jsonSchema 由 Go 类型生成 JSON Schema；没有 omitempty 的字段为必填字段，不限制额外字段以便新增字段
+// 指针、切片、map 为空时序列化为 null，类型中包含 null
+func jsonSchema(t reflect.Type) map[string]interface{} {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	schema := typeSchema(t)
+	if nullable || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		if schemaType, ok := schema["type"].(string); ok {
+			schema["type"] = []string{schemaType, "null"}
+		}
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.Interface:
+		return map[string]interface{}{}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		required := make([]string, 0)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			property := jsonSchema(field.Type)
+			if dc := field.Tag.Get("dc"); dc != "" {
+				property["description"] = dc
+			}
+			properties[name] = property
+			if !strings.Contains(options, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+	default:
+		panic(fmt.Sprintf("[Event]: unsupported type in event data: %s", t))
+	}
+}
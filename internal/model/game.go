@@ -29,12 +29,34 @@ const (
 type GameMediaStatus int
 
 const (
-	_                      GameMediaStatus = iota
-	GameMediaStatusInit                    // 初始化
-	GameMediaStatusSuccess                 // 成功
-	GameMediaStatusFailed                  // 失败
+	_                       GameMediaStatus = iota
+	GameMediaStatusInit                     // 初始化
+	GameMediaStatusSuccess                  // 成功(H5链接，以及内容扫描上线前上传的文件)
+	GameMediaStatusFailed                   // 失败
+	GameMediaStatusScanning                 // 上传成功，内容安全扫描中
+	GameMediaStatusInfected                 // 扫描发现恶意内容，文件已删除
+	GameMediaStatusClean                    // 扫描通过
 )
 
+func GetGameMediaStatusText(status GameMediaStatus) string {
+	switch status {
+	case GameMediaStatusInit:
+		return "未上传"
+	case GameMediaStatusSuccess:
+		return "上传成功"
+	case GameMediaStatusFailed:
+		return "上传失败"
+	case GameMediaStatusScanning:
+		return "安全扫描中"
+	case GameMediaStatusInfected:
+		return "存在安全风险"
+	case GameMediaStatusClean:
+		return "安全扫描通过"
+	default:
+		return "未知状态"
+	}
+}
+
 type GameStatus int
 
 const (
@@ -49,16 +71,19 @@ const (
 type GameEvent int
 
 const (
-	SubmitForReview   GameEvent = iota // 提交审核
-	Approve                            // 审核通过
-	Reject                             // 审核失败
-	PreRegister                        // 预约发布
-	PublishNow                         // 立即发布
-	UpdateInfo                         // 更新游戏信息
-	CancelPreRegister                  // 取消预约发布
-	UnpublishNow                       // 立即下架
-	UpdateVersion                      // 更新游戏版本
-	AutoPublish                        // 自动发布(预约时间到达)
+	SubmitForReview      GameEvent = iota // 提交审核
+	Approve                               // 审核通过
+	Reject                                // 审核失败
+	PreRegister                           // 预约发布
+	PublishNow                            // 立即发布
+	UpdateInfo                            // 更新游戏信息
+	CancelPreRegister                     // 取消预约发布
+	UnpublishNow                          // 立即下架
+	UpdateVersion                         // 更新游戏版本
+	AutoPublish                           // 自动发布(预约时间到达)
+	SubmitDraftForReview                  // 草稿提交审核
+	ApproveDraft                          // 草稿审核通过
+	RejectDraft                           // 草稿审核失败
 )
 
 // 获取事件名称
@@ -84,6 +109,12 @@ func GetGameEventText(event GameEvent) string {
 		return "更新游戏版本"
 	case AutoPublish:
 		return "自动发布"
+	case SubmitDraftForReview:
+		return "草稿提交审核"
+	case ApproveDraft:
+		return "草稿审核通过"
+	case RejectDraft:
+		return "草稿审核失败"
 	default:
 		return "未知事件"
 	}
@@ -109,6 +140,12 @@ func GetGameStatusText(status GameStatus) string {
 	}
 }
 
+// IsGameLive 游戏是否处于对客户端可见的线上状态(可预约/已上架)
+// 线上状态的游戏修改信息需要通过草稿审核，不会影响线上数据
+func IsGameLive(status GameStatus) bool {
+	return status == GameStatusPreRegister || status == GameStatusPublished
+}
+
 func GetGameDistributeTypeText(distributeType GameDistributeType) string {
 	switch distributeType {
 	case GameDistributeTypeAPK:
@@ -119,6 +156,22 @@ func GetGameDistributeTypeText(distributeType GameDistributeType) string {
 	return "未知类型"
 }
 
+func GetGameMediaTypeText(mediaType GameMediaType) string {
+	switch mediaType {
+	case GameMediaTypeIcon:
+		return "图标"
+	case GameMediaTypeScreenshot:
+		return "截图"
+	case GameMediaTypeVideo:
+		return "视频"
+	case GameMediaTypeApkFile:
+		return "APK"
+	case GameMediaTypeH5Link:
+		return "H5链接"
+	}
+	return "未知类型"
+}
+
 type Game struct {
 	ID             int64              `json:"id" dc:"ID"`
 	Name           string             `json:"name" dc:"名称"`
@@ -150,10 +203,27 @@ type GameMediaInfo struct {
 	Status     GameMediaStatus `json:"status" dc:"状态"`
 	MediaType  GameMediaType   `json:"media_type" dc:"媒体类型"`
 	MediaUrl   string          `json:"media_url" dc:"媒体URL"`
+	FileSize   int64           `json:"file_size" dc:"文件大小(字节)"`
+	Width      int             `json:"width" dc:"宽度(像素，图片/视频)"`
+	Height     int             `json:"height" dc:"高度(像素，图片/视频)"`
 	CreateTime *gtime.Time     `json:"create_time" dc:"创建时间"`
 	UpdateTime *gtime.Time     `json:"update_time" dc:"更新时间"`
 }
 
+// GameStatusHistory 游戏状态变更历史
+type GameStatusHistory struct {
+	ID           int64       `json:"id" dc:"ID"`
+	GameID       int64       `json:"game_id" dc:"游戏ID"`
+	FromStatus   GameStatus  `json:"from_status" dc:"变更前状态"`
+	ToStatus     GameStatus  `json:"to_status" dc:"变更后状态"`
+	Event        GameEvent   `json:"event" dc:"触发事件"`
+	OperatorID   int64       `json:"operator_id" dc:"操作人ID(0:系统)"`
+	OperatorName string      `json:"operator_name" dc:"操作人名称"`
+	Reason       string      `json:"reason" dc:"原因"`
+	EventData    string      `json:"event_data" dc:"事件数据"`
+	CreateTime   *gtime.Time `json:"create_time" dc:"创建时间"`
+}
+
 func ConvertGameEntityToModel(in *entity.Game) (out *Game) {
 	out = &Game{
 		ID:             in.ID,
@@ -190,3 +260,19 @@ func CalcRating(totalRatingScore, totalRatingCount int64) (averageRating float64
 	averageRating = math.Round(averageRating*10) / 10
 	return
 }
+
+func ConvertGameStatusHistoryEntityToModel(in *entity.GameStatusHistory) (out *GameStatusHistory) {
+	out = &GameStatusHistory{
+		ID:           in.ID,
+		GameID:       in.GameID,
+		FromStatus:   GameStatus(in.FromStatus),
+		ToStatus:     GameStatus(in.ToStatus),
+		Event:        GameEvent(in.Event),
+		OperatorID:   in.OperatorID,
+		OperatorName: in.OperatorName,
+		Reason:       in.Reason,
+		EventData:    in.EventData,
+		CreateTime:   in.CreateTime,
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"GameEngine/internal/model/entity"
+	"encoding/json"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// GameApkParseStatus APK解析状态
+type GameApkParseStatus int
+
+const (
+	GameApkParseStatusPending GameApkParseStatus = iota // 解析中
+	GameApkParseStatusSuccess                           // 解析成功
+	GameApkParseStatusFailed                            // 解析失败
+)
+
+func GetGameApkParseStatusText(status GameApkParseStatus) string {
+	switch status {
+	case GameApkParseStatusPending:
+		return "解析中"
+	case GameApkParseStatusSuccess:
+		return "解析成功"
+	case GameApkParseStatusFailed:
+		return "解析失败"
+	default:
+		return "未知状态"
+	}
+}
+
+// GameApkInfo APK安装包解析结果，按文件ID保存，线上数据、草稿和构建版本共用
+type GameApkInfo struct {
+	ID              int64              `json:"id" dc:"ID"`
+	GameID          int64              `json:"game_id" dc:"游戏ID"`
+	FileID          string             `json:"file_id" dc:"文件ID"`
+	Status          GameApkParseStatus `json:"status" dc:"解析状态"`
+	PackageName     string             `json:"package_name" dc:"包名"`
+	VersionCode     int64              `json:"version_code" dc:"版本号(versionCode)"`
+	VersionName     string             `json:"version_name" dc:"版本名称(versionName)"`
+	MinSdk          int                `json:"min_sdk" dc:"最低SDK版本"`
+	TargetSdk       int                `json:"target_sdk" dc:"目标SDK版本"`
+	Permissions     []string           `json:"permissions" dc:"申请的权限"`
+	SignatureScheme string             `json:"signature_scheme" dc:"签名方案(v1/v2/v3)"`
+	CertSha256      string             `json:"cert_sha256" dc:"签名证书SHA-256指纹"`
+	CertSubject     string             `json:"cert_subject" dc:"签名证书主题"`
+	Error           string             `json:"error" dc:"解析失败原因"`
+	ApproveTime     *gtime.Time        `json:"approve_time" dc:"审核通过时间"`
+	CreateTime      *gtime.Time        `json:"create_time" dc:"创建时间"`
+	UpdateTime      *gtime.Time        `json:"update_time" dc:"更新时间"`
+}
+
+func ConvertGameApkInfoEntityToModel(in *entity.GameApkInfo) (out *GameApkInfo) {
+	out = &GameApkInfo{
+		ID:              in.ID,
+		GameID:          in.GameID,
+		FileID:          in.FileID,
+		Status:          GameApkParseStatus(in.Status),
+		PackageName:     in.PackageName,
+		VersionCode:     in.VersionCode,
+		VersionName:     in.VersionName,
+		MinSdk:          in.MinSdk,
+		TargetSdk:       in.TargetSdk,
+		SignatureScheme: in.SignatureScheme,
+		CertSha256:      in.CertSha256,
+		CertSubject:     in.CertSubject,
+		Error:           in.Error,
+		ApproveTime:     in.ApproveTime,
+		CreateTime:      in.CreateTime,
+		UpdateTime:      in.UpdateTime,
+	}
+	if in.Permissions != "" {
+		_ = json.Unmarshal([]byte(in.Permissions), &out.Permissions)
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"GameEngine/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// GameBuildStatus 构建版本审核状态
+type GameBuildStatus int
+
+const (
+	GameBuildStatusInit     GameBuildStatus = iota // 待提交审核
+	GameBuildStatusInReview                        // 审核中
+	GameBuildStatusApproved                        // 审核通过
+	GameBuildStatusRejected                        // 审核失败
+)
+
+func GetGameBuildStatusText(status GameBuildStatus) string {
+	switch status {
+	case GameBuildStatusInit:
+		return "待提交审核"
+	case GameBuildStatusInReview:
+		return "审核中"
+	case GameBuildStatusApproved:
+		return "审核通过"
+	case GameBuildStatusRejected:
+		return "审核失败"
+	default:
+		return "未知状态"
+	}
+}
+
+// GameBuild APK游戏的构建版本
+// 每个版本有独立的审核状态，审核通过的版本可以设置为当前版本，下载时使用当前版本的文件。
+type GameBuild struct {
+	ID           int64           `json:"id" dc:"ID"`
+	GameID       int64           `json:"game_id" dc:"游戏ID"`
+	VersionName  string          `json:"version_name" dc:"版本名称"`
+	VersionCode  int64           `json:"version_code" dc:"版本号"`
+	ReleaseNotes string          `json:"release_notes" dc:"更新说明"`
+	FileID       string          `json:"file_id" dc:"文件ID"`
+	FileSize     int64           `json:"file_size" dc:"文件大小"`
+	Checksum     string          `json:"checksum" dc:"文件校验和"`
+	MediaUrl     string          `json:"media_url" dc:"文件URL"`
+	FileStatus   GameMediaStatus `json:"file_status" dc:"文件上传状态"`
+	Status       GameBuildStatus `json:"status" dc:"审核状态"`
+	RejectReason string          `json:"reject_reason" dc:"拒绝原因"`
+	IsCurrent    bool            `json:"is_current" dc:"是否为当前版本"`
+	CreateTime   *gtime.Time     `json:"create_time" dc:"创建时间"`
+	UpdateTime   *gtime.Time     `json:"update_time" dc:"更新时间"`
+}
+
+func ConvertGameBuildEntityToModel(in *entity.GameBuild) (out *GameBuild) {
+	out = &GameBuild{
+		ID:           in.ID,
+		GameID:       in.GameID,
+		VersionName:  in.VersionName,
+		VersionCode:  in.VersionCode,
+		ReleaseNotes: in.ReleaseNotes,
+		FileID:       in.FileID,
+		FileSize:     in.FileSize,
+		Checksum:     in.Checksum,
+		MediaUrl:     in.MediaUrl,
+		FileStatus:   GameMediaStatus(in.FileStatus),
+		Status:       GameBuildStatus(in.Status),
+		RejectReason: in.RejectReason,
+		IsCurrent:    in.IsCurrent == 1,
+		CreateTime:   in.CreateTime,
+		UpdateTime:   in.UpdateTime,
+	}
+	return
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"GameEngine/internal/model/entity"
+	"encoding/json"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// GameDraftStatus 草稿状态
+type GameDraftStatus int
+
+const (
+	GameDraftStatusEditing  GameDraftStatus = iota // 编辑中
+	GameDraftStatusInReview                        // 审核中
+)
+
+func GetGameDraftStatusText(status GameDraftStatus) string {
+	switch status {
+	case GameDraftStatusEditing:
+		return "编辑中"
+	case GameDraftStatusInReview:
+		return "审核中"
+	default:
+		return "未知状态"
+	}
+}
+
+// GameDraft 游戏草稿
+// 已上架/可预约的游戏修改信息时，修改内容先写入草稿，线上数据继续对客户端提供服务，
+// 草稿审核通过后再整体覆盖线上数据。
+type GameDraft struct {
+	ID             int64              `json:"id" dc:"ID"`
+	GameID         int64              `json:"game_id" dc:"游戏ID"`
+	Name           string             `json:"name" dc:"名称"`
+	DistributeType GameDistributeType `json:"distribute_type" dc:"分发类型"`
+	Developer      string             `json:"developer" dc:"开发商"`
+	Publisher      string             `json:"publisher" dc:"发行商"`
+	Description    string             `json:"description" dc:"描述"`
+	Details        string             `json:"details" dc:"详情"`
+	CategoryID     int64              `json:"category_id" dc:"分类ID"`
+	TagIDs         []int64            `json:"tag_ids" dc:"标签ID列表"`
+
+	Status     GameDraftStatus `json:"status" dc:"草稿状态"`
+	Version    int             `json:"version" dc:"版本"`
+	CreateTime *gtime.Time     `json:"create_time" dc:"创建时间"`
+	UpdateTime *gtime.Time     `json:"update_time" dc:"更新时间"`
+}
+
+// GameFieldDiff 草稿与线上数据的字段差异
+type GameFieldDiff struct {
+	Field      string      `json:"field" dc:"字段"`
+	LiveValue  interface{} `json:"live_value" dc:"线上值"`
+	DraftValue interface{} `json:"draft_value" dc:"草稿值"`
+}
+
+// GameDraftDiff 草稿与线上数据的差异
+type GameDraftDiff struct {
+	GameID       int64            `json:"game_id" dc:"游戏ID"`
+	Fields       []*GameFieldDiff `json:"fields" dc:"字段差异"`
+	MediaAdded   []*GameMediaInfo `json:"media_added" dc:"新增的媒体文件"`
+	MediaRemoved []*GameMediaInfo `json:"media_removed" dc:"移除的媒体文件"`
+}
+
+func ConvertGameDraftEntityToModel(in *entity.GameDraft) (out *GameDraft) {
+	out = &GameDraft{
+		ID:             in.ID,
+		GameID:         in.GameID,
+		Name:           in.Name,
+		DistributeType: GameDistributeType(in.DistributeType),
+		Developer:      in.Developer,
+		Publisher:      in.Publisher,
+		Description:    in.Description,
+		Details:        in.Details,
+		CategoryID:     in.CategoryID,
+		TagIDs:         make([]int64, 0),
+
+		Status:     GameDraftStatus(in.Status),
+		Version:    in.Version,
+		CreateTime: in.CreateTime,
+		UpdateTime: in.UpdateTime,
+	}
+	if in.TagIDs != "" {
+		_ = json.Unmarshal([]byte(in.TagIDs), &out.TagIDs)
+	}
+	return
+}
+
+func ConvertGameDraftMediaInfoEntityToModel(in *entity.GameDraftMediaInfo) (out *GameMediaInfo) {
+	out = &GameMediaInfo{
+		ID:         in.ID,
+		GameID:     in.GameID,
+		FileID:     in.FileID,
+		MediaType:  GameMediaType(in.MediaType),
+		MediaUrl:   in.MediaUrl,
+		FileSize:   in.FileSize,
+		Width:      in.Width,
+		Height:     in.Height,
+		Status:     GameMediaStatus(in.Status),
+		CreateTime: in.CreateTime,
+		UpdateTime: in.UpdateTime,
+	}
+	return
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"GameEngine/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// GetGameStatusKey 获取状态在配置文件中使用的名称
+func GetGameStatusKey(status GameStatus) string {
+	switch status {
+	case GameStatusInit:
+		return "init"
+	case GameStatusInReview:
+		return "inReview"
+	case GameStatusApproved:
+		return "approved"
+	case GameStatusPreRegister:
+		return "preRegister"
+	case GameStatusPublished:
+		return "published"
+	case GameStatusUnpublished:
+		return "unpublished"
+	default:
+		return ""
+	}
+}
+
+// GameStatusPeriod 游戏在某个状态的停留区间
+type GameStatusPeriod struct {
+	ID             int64       `json:"id" dc:"ID"`
+	GameID         int64       `json:"game_id" dc:"游戏ID"`
+	Status         GameStatus  `json:"status" dc:"状态"`
+	EnterTime      *gtime.Time `json:"enter_time" dc:"进入状态时间"`
+	LeaveTime      *gtime.Time `json:"leave_time" dc:"离开状态时间"`
+	Duration       int64       `json:"duration" dc:"停留时长(秒)"`
+	OverdueAlerted bool        `json:"overdue_alerted" dc:"是否已发送超时告警"`
+}
+
+// GameSLAOverdue 停留时间超过SLA的游戏
+type GameSLAOverdue struct {
+	GameID    int64       `json:"game_id" dc:"游戏ID"`
+	GameName  string      `json:"game_name" dc:"游戏名称"`
+	Status    GameStatus  `json:"status" dc:"状态"`
+	EnterTime *gtime.Time `json:"enter_time" dc:"进入状态时间"`
+	Elapsed   int64       `json:"elapsed" dc:"已停留时长(秒)"`
+	SLA       int64       `json:"sla" dc:"SLA(秒)"`
+}
+
+// GameSLAStats 某个状态的停留时长统计
+type GameSLAStats struct {
+	Status         GameStatus        `json:"status" dc:"状态"`
+	SLA            int64             `json:"sla" dc:"SLA(秒)，0表示未配置"`
+	QueueDepth     int               `json:"queue_depth" dc:"当前处于该状态的游戏数量"`
+	CompletedCount int               `json:"completed_count" dc:"统计窗口内离开该状态的次数"`
+	MedianDuration int64             `json:"median_duration" dc:"停留时长中位数(秒)"`
+	P95Duration    int64             `json:"p95_duration" dc:"停留时长P95(秒)"`
+	OverdueGames   []*GameSLAOverdue `json:"overdue_games" dc:"超过SLA的游戏"`
+}
+
+func ConvertGameStatusPeriodEntityToModel(in *entity.GameStatusPeriod) (out *GameStatusPeriod) {
+	out = &GameStatusPeriod{
+		ID:             in.ID,
+		GameID:         in.GameID,
+		Status:         GameStatus(in.Status),
+		EnterTime:      in.EnterTime,
+		LeaveTime:      in.LeaveTime,
+		Duration:       in.Duration,
+		OverdueAlerted: in.OverdueAlerted == 1,
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"GameEngine/internal/model/entity"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ValidationSeverity 提交审核检查问题的严重程度
+type ValidationSeverity int
+
+const (
+	ValidationSeverityWarning  ValidationSeverity = 1 // 警告，不影响提交，提示审核人员
+	ValidationSeverityBlocking ValidationSeverity = 2 // 阻断，拒绝提交
+)
+
+func GetValidationSeverityText(severity ValidationSeverity) string {
+	switch severity {
+	case ValidationSeverityWarning:
+		return "警告"
+	case ValidationSeverityBlocking:
+		return "阻断"
+	default:
+		return "未知"
+	}
+}
+
+// ValidationSubject 提交审核检查的对象，线上数据和草稿共用
+type ValidationSubject struct {
+	GameID         int64
+	Draft          bool
+	Name           string
+	DistributeType GameDistributeType
+	Developer      string
+	Publisher      string
+	Description    string
+	Details        string
+	MediaInfos     []*GameMediaInfo
+	H5LinkProbes   map[string]string // H5链接 -> 无法访问时的警告信息，提交审核前在事务之外检查
+}
+
+// ValidationFinding 提交审核检查发现的问题
+type ValidationFinding struct {
+	ID         int64              `json:"id" dc:"ID"`
+	GameID     int64              `json:"game_id" dc:"游戏ID"`
+	Draft      bool               `json:"draft" dc:"是否为草稿提交"`
+	Validator  string             `json:"validator" dc:"检查项"`
+	Severity   ValidationSeverity `json:"severity" dc:"严重程度"`
+	Field      ReviewField        `json:"field" dc:"问题字段"`
+	Message    string             `json:"message" dc:"问题描述"`
+	CreateTime *gtime.Time        `json:"create_time" dc:"创建时间"`
+}
+
+// GameValidator 提交审核检查项，返回发现的问题；返回 error 表示检查过程本身出错
+type GameValidator func(ctx context.Context, in *ValidationSubject) ([]*ValidationFinding, error)
+
+// ValidationError 存在阻断性问题时返回，包含全部阻断性问题
+type ValidationError struct {
+	Findings []*ValidationFinding
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Findings))
+	for i, finding := range e.Findings {
+		messages = append(messages, fmt.Sprintf("%d、%s", i+1, finding.Message))
+	}
+	return "提交审核检查未通过：" + strings.Join(messages, "；")
+}
+
+func ConvertGameValidationFindingEntityToModel(in *entity.GameValidationFinding) (out *ValidationFinding) {
+	out = &ValidationFinding{
+		ID:         in.ID,
+		GameID:     in.GameID,
+		Draft:      in.Draft == 1,
+		Validator:  in.Validator,
+		Severity:   ValidationSeverity(in.Severity),
+		Field:      ReviewField(in.Field),
+		Message:    in.Message,
+		CreateTime: in.CreateTime,
+	}
+	return
+}
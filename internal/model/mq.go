@@ -0,0 +1,88 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// 订阅的其他服务的主题，可以通过 mq.topics 映射到实际主题
+const (
+	MQTopicFileUploadResult = "core.event.file-upload-result" // 文件引擎：文件上传完成或失败
+	MQTopicUserDeleted      = "core.event.user-deleted"       // 用户服务：用户注销
+)
+
+// ErrMalformedMQMessage 消息格式错误，重试也无法处理
+var ErrMalformedMQMessage = errors.New("malformed mq message")
+
+// MQMessage 从消息队列收到的消息
+type MQMessage struct {
+	ID    string // 消息ID，消费方按此去重
+	Topic string
+	Body  []byte // 消息体(JSON)
+}
+
+// MQHandler 消息处理函数，返回错误时消息重新投递
+type MQHandler func(ctx context.Context, message *MQMessage) error
+
+// DecodeMQMessage 解析消息体，CloudEvents 格式的消息解析其中的 data
+func DecodeMQMessage(message *MQMessage, v interface{}) error {
+	var envelope struct {
+		SpecVersion string          `json:"specversion"`
+		Data        json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(message.Body, &envelope); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedMQMessage, err)
+	}
+
+	data := json.RawMessage(message.Body)
+	if envelope.SpecVersion != "" {
+		data = envelope.Data
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedMQMessage, err)
+	}
+	if validator, ok := v.(interface{ Validate() error }); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedMQMessage, err)
+		}
+	}
+	return nil
+}
+
+// TypedMQHandler 把按类型处理消息内容的函数转换为消息处理函数
+func TypedMQHandler[T any](handler func(ctx context.Context, payload *T) error) MQHandler {
+	return func(ctx context.Context, message *MQMessage) error {
+		var payload T
+		if err := DecodeMQMessage(message, &payload); err != nil {
+			return err
+		}
+		return handler(ctx, &payload)
+	}
+}
+
+// FileUploadResultEvent 文件引擎：文件上传完成或失败
+type FileUploadResultEvent struct {
+	FileID  string `json:"file_id"`
+	Success bool   `json:"success"`
+}
+
+func (e *FileUploadResultEvent) Validate() error {
+	if e.FileID == "" {
+		return fmt.Errorf("文件ID格式错误")
+	}
+	return nil
+}
+
+// UserDeletedEvent 用户服务：用户注销
+type UserDeletedEvent struct {
+	UserID int64 `json:"user_id"`
+}
+
+func (e *UserDeletedEvent) Validate() error {
+	if e.UserID <= 0 {
+		return fmt.Errorf("用户ID格式错误")
+	}
+	return nil
+}
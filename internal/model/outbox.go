@@ -0,0 +1,10 @@
+package model
+
+// 发件箱消息状态
+type OutboxStatus int
+
+const (
+	OutboxStatusPending   OutboxStatus = iota // 待发送
+	OutboxStatusPublished                     // 已发送
+	OutboxStatusDead                          // 重试次数耗尽，不再发送
+)
@@ -0,0 +1,28 @@
+package model
+
+import "GameEngine/internal/model/entity"
+
+type RecurringTask struct {
+	ID          int64  `json:"id" dc:"周期任务ID"`
+	Name        string `json:"name" dc:"名称"`
+	CronSpec    string `json:"cron_spec" dc:"cron表达式"`
+	Paused      bool   `json:"paused" dc:"是否暂停"`
+	NextRunTime int64  `json:"next_run_time" dc:"下次执行时间"`
+	LastRunTime int64  `json:"last_run_time" dc:"最近一次执行时间，0表示未执行过"`
+	Registered  bool   `json:"registered" dc:"当前实例是否注册了处理函数"`
+	CreateTime  int64  `json:"create_time" dc:"创建时间"`
+	UpdateTime  int64  `json:"update_time" dc:"更新时间"`
+}
+
+func ConvertRecurringTaskEntityToModel(in *entity.RecurringTask) *RecurringTask {
+	return &RecurringTask{
+		ID:          in.ID,
+		Name:        in.Name,
+		CronSpec:    in.CronSpec,
+		Paused:      in.Paused == 1,
+		NextRunTime: in.NextRunTime,
+		LastRunTime: in.LastRunTime,
+		CreateTime:  in.CreateTime,
+		UpdateTime:  in.UpdateTime,
+	}
+}
@@ -0,0 +1,190 @@
+package model
+
+import (
+	"GameEngine/internal/model/entity"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ReviewField 审核问题关联的字段
+type ReviewField string
+
+const (
+	ReviewFieldName        ReviewField = "name"        // 名称
+	ReviewFieldDescription ReviewField = "description" // 描述
+	ReviewFieldDetails     ReviewField = "details"     // 详情
+	ReviewFieldCategory    ReviewField = "category"    // 分类
+	ReviewFieldTags        ReviewField = "tags"        // 标签
+	ReviewFieldIcon        ReviewField = "icon"        // 图标
+	ReviewFieldScreenshot  ReviewField = "screenshot"  // 截图
+	ReviewFieldVideo       ReviewField = "video"       // 视频
+	ReviewFieldApk         ReviewField = "apk"         // APK文件
+	ReviewFieldH5Link      ReviewField = "h5_link"     // H5链接
+	ReviewFieldOther       ReviewField = "other"       // 其他
+)
+
+func GetReviewFieldText(field ReviewField) string {
+	switch field {
+	case ReviewFieldName:
+		return "名称"
+	case ReviewFieldDescription:
+		return "描述"
+	case ReviewFieldDetails:
+		return "详情"
+	case ReviewFieldCategory:
+		return "分类"
+	case ReviewFieldTags:
+		return "标签"
+	case ReviewFieldIcon:
+		return "图标"
+	case ReviewFieldScreenshot:
+		return "截图"
+	case ReviewFieldVideo:
+		return "视频"
+	case ReviewFieldApk:
+		return "APK文件"
+	case ReviewFieldH5Link:
+		return "H5链接"
+	case ReviewFieldOther:
+		return "其他"
+	default:
+		return "未知字段"
+	}
+}
+
+// IsValidReviewField 检查字段是否合法
+func IsValidReviewField(field ReviewField) bool {
+	return GetReviewFieldText(field) != "未知字段"
+}
+
+// ReviewIssueStatus 审核问题状态
+type ReviewIssueStatus int
+
+const (
+	ReviewIssueStatusOpen     ReviewIssueStatus = iota // 未解决
+	ReviewIssueStatusResolved                          // 已解决
+)
+
+func GetReviewIssueStatusText(status ReviewIssueStatus) string {
+	switch status {
+	case ReviewIssueStatusOpen:
+		return "未解决"
+	case ReviewIssueStatusResolved:
+		return "已解决"
+	default:
+		return "未知状态"
+	}
+}
+
+// ReviewRejectCode 审核拒绝原因，由管理员维护
+type ReviewRejectCode struct {
+	ID          int64       `json:"id" dc:"ID"`
+	Code        string      `json:"code" dc:"拒绝原因编码"`
+	Title       string      `json:"title" dc:"标题"`
+	Description string      `json:"description" dc:"描述"`
+	Field       ReviewField `json:"field" dc:"默认关联字段"`
+	Enabled     bool        `json:"enabled" dc:"是否启用"`
+	CreateTime  *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime  *gtime.Time `json:"update_time" dc:"更新时间"`
+}
+
+// ReviewIssue 审核拒绝时记录的问题
+type ReviewIssue struct {
+	ID           int64             `json:"id" dc:"ID"`
+	GameID       int64             `json:"game_id" dc:"游戏ID"`
+	Code         string            `json:"code" dc:"拒绝原因编码"`
+	Field        ReviewField       `json:"field" dc:"问题字段"`
+	Comment      string            `json:"comment" dc:"审核意见"`
+	Status       ReviewIssueStatus `json:"status" dc:"问题状态"`
+	ReviewerID   int64             `json:"reviewer_id" dc:"审核人ID"`
+	ReviewerName string            `json:"reviewer_name" dc:"审核人名称"`
+	ResolvedBy   int64             `json:"resolved_by" dc:"解决人ID"`
+	ResolveTime  *gtime.Time       `json:"resolve_time" dc:"解决时间"`
+	CreateTime   *gtime.Time       `json:"create_time" dc:"创建时间"`
+	UpdateTime   *gtime.Time       `json:"update_time" dc:"更新时间"`
+
+	Comments []*ReviewComment `json:"comments" dc:"讨论记录"`
+}
+
+// ReviewComment 审核问题的讨论记录
+type ReviewComment struct {
+	ID         int64       `json:"id" dc:"ID"`
+	IssueID    int64       `json:"issue_id" dc:"问题ID"`
+	GameID     int64       `json:"game_id" dc:"游戏ID"`
+	UserID     int64       `json:"user_id" dc:"用户ID"`
+	UserName   string      `json:"user_name" dc:"用户名"`
+	Content    string      `json:"content" dc:"内容"`
+	CreateTime *gtime.Time `json:"create_time" dc:"创建时间"`
+}
+
+func ConvertReviewRejectCodeEntityToModel(in *entity.ReviewRejectCode) (out *ReviewRejectCode) {
+	out = &ReviewRejectCode{
+		ID:          in.ID,
+		Code:        in.Code,
+		Title:       in.Title,
+		Description: in.Description,
+		Field:       ReviewField(in.Field),
+		Enabled:     in.Enabled == 1,
+		CreateTime:  in.CreateTime,
+		UpdateTime:  in.UpdateTime,
+	}
+	return
+}
+
+func ConvertReviewIssueEntityToModel(in *entity.GameReviewIssue) (out *ReviewIssue) {
+	out = &ReviewIssue{
+		ID:           in.ID,
+		GameID:       in.GameID,
+		Code:         in.Code,
+		Field:        ReviewField(in.Field),
+		Comment:      in.Comment,
+		Status:       ReviewIssueStatus(in.Status),
+		ReviewerID:   in.ReviewerID,
+		ReviewerName: in.ReviewerName,
+		ResolvedBy:   in.ResolvedBy,
+		ResolveTime:  in.ResolveTime,
+		CreateTime:   in.CreateTime,
+		UpdateTime:   in.UpdateTime,
+		Comments:     make([]*ReviewComment, 0),
+	}
+	return
+}
+
+func ConvertReviewCommentEntityToModel(in *entity.GameReviewComment) (out *ReviewComment) {
+	out = &ReviewComment{
+		ID:         in.ID,
+		IssueID:    in.IssueID,
+		GameID:     in.GameID,
+		UserID:     in.UserID,
+		UserName:   in.UserName,
+		Content:    in.Content,
+		CreateTime: in.CreateTime,
+	}
+	return
+}
+
+// ReviewClaim 审核领取记录，租约期内只有领取人可以审核
+type ReviewClaim struct {
+	ID              int64       `json:"id" dc:"ID"`
+	GameID          int64       `json:"game_id" dc:"游戏ID"`
+	ReviewerID      int64       `json:"reviewer_id" dc:"审核人ID"`
+	ReviewerName    string      `json:"reviewer_name" dc:"审核人名称"`
+	LeaseExpireTime *gtime.Time `json:"lease_expire_time" dc:"租约到期时间"`
+	Version         int         `json:"version" dc:"版本"`
+	CreateTime      *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime      *gtime.Time `json:"update_time" dc:"更新时间"`
+}
+
+func ConvertReviewClaimEntityToModel(in *entity.GameReviewClaim) (out *ReviewClaim) {
+	out = &ReviewClaim{
+		ID:              in.ID,
+		GameID:          in.GameID,
+		ReviewerID:      in.ReviewerID,
+		ReviewerName:    in.ReviewerName,
+		LeaseExpireTime: in.LeaseExpireTime,
+		Version:         in.Version,
+		CreateTime:      in.CreateTime,
+		UpdateTime:      in.UpdateTime,
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// ErrSearchIndexNotReady 搜索索引未加载完成
+var ErrSearchIndexNotReady = errors.New("搜索索引未加载完成")
+
+// GameSearchSort 搜索结果排序方式
+type GameSearchSort string
+
+const (
+	GameSearchSortRelevance GameSearchSort = "relevance" // 相关度，没有关键词时按热度
+	GameSearchSortNewest    GameSearchSort = "newest"    // 发布时间
+	GameSearchSortRating    GameSearchSort = "rating"    // 平均评分
+	GameSearchSortDownloads GameSearchSort = "downloads" // 下载量
+	GameSearchSortHot       GameSearchSort = "hot"       // 热度，与热门榜单相同
+)
+
+// GameSearchQuery 搜索条件，同一条件的多个值满足任一即可，不同条件需要同时满足
+type GameSearchQuery struct {
+	Keyword         string               // 搜索关键词，为空时返回所有符合条件的游戏
+	Statuses        []GameStatus         // 游戏状态，为空时不限制
+	CategoryIDs     []int64              // 分类
+	TagIDs          []int64              // 标签
+	DistributeTypes []GameDistributeType // 分发类型
+	MinRating       float64              // 平均评分下限，0 表示不限制
+	MaxRating       float64              // 平均评分上限，0 表示不限制
+	PublishFrom     *gtime.Time          // 发布时间下限(包含)
+	PublishTo       *gtime.Time          // 发布时间上限(包含)
+	Developer       string               // 开发商，忽略大小写完全匹配
+	Sort            GameSearchSort       // 为空时按相关度
+	Page            int
+	Size            int
+}
+
+// GameSearchHit 搜索命中的游戏
+type GameSearchHit struct {
+	GameID int64   `json:"game_id" dc:"游戏ID"`
+	Score  float64 `json:"score" dc:"相关度得分"`
+}
+
+// GameSearchFacet 筛选项及命中的游戏数量
+type GameSearchFacet struct {
+	Value int64  `json:"value" dc:"分类ID、标签ID或分发类型"`
+	Name  string `json:"name" dc:"名称"`
+	Count int    `json:"count" dc:"游戏数量"`
+}
+
+// GameSearchFacets 各筛选项的游戏数量，按数量从多到少排序
+// 每一项的数量按除该项自身以外的条件统计，选中某个分类后仍能看到其他分类的数量
+type GameSearchFacets struct {
+	Categories      []*GameSearchFacet `json:"categories" dc:"分类"`
+	Tags            []*GameSearchFacet `json:"tags" dc:"标签"`
+	DistributeTypes []*GameSearchFacet `json:"distribute_types" dc:"分发类型"`
+}
+
+// GameSearchResult 搜索结果
+type GameSearchResult struct {
+	Hits   []*GameSearchHit  `json:"hits" dc:"当前页命中的游戏"`
+	Total  int               `json:"total" dc:"命中总数"`
+	Facets *GameSearchFacets `json:"facets" dc:"筛选项统计"`
+}
+
+// SearchSuggestionType 搜索建议的来源
+type SearchSuggestionType string
+
+const (
+	SearchSuggestionTypeGame      SearchSuggestionType = "game"      // 游戏名称
+	SearchSuggestionTypeDeveloper SearchSuggestionType = "developer" // 开发商
+	SearchSuggestionTypeQuery     SearchSuggestionType = "query"     // 热门搜索词
+)
+
+// SearchSuggestion 搜索建议
+type SearchSuggestion struct {
+	Text   string               `json:"text" dc:"建议的搜索词"`
+	Type   SearchSuggestionType `json:"type" dc:"来源(game:游戏名称,developer:开发商,query:热门搜索词)"`
+	GameID int64                `json:"game_id" dc:"游戏ID，来源为游戏名称时有效"`
+}
+
+// SearchIndexStats 搜索索引状态
+type SearchIndexStats struct {
+	Documents   int         `json:"documents" dc:"已索引的游戏数量"`
+	Terms       int         `json:"terms" dc:"词项数量"`
+	Dictionary  int         `json:"dictionary" dc:"分词词典的词数"`
+	BuildTime   *gtime.Time `json:"build_time" dc:"最近一次重建时间"`
+	Duration    string      `json:"duration" dc:"最近一次重建耗时"`
+	Suggestions int         `json:"suggestions" dc:"搜索建议的数量"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"GameEngine/internal/model/entity"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// SensitiveWord 敏感词
+type SensitiveWord struct {
+	ID         int64       `json:"id" dc:"ID"`
+	Word       string      `json:"word" dc:"敏感词"`
+	Category   string      `json:"category" dc:"分类"`
+	Enabled    bool        `json:"enabled" dc:"是否启用"`
+	CreateTime *gtime.Time `json:"create_time" dc:"创建时间"`
+	UpdateTime *gtime.Time `json:"update_time" dc:"更新时间"`
+}
+
+// SensitiveWordError 拒绝模式下内容包含敏感词时返回
+type SensitiveWordError struct {
+	Field string
+	Hits  []string
+}
+
+func (e *SensitiveWordError) Error() string {
+	return fmt.Sprintf("%s包含敏感词：%s", e.Field, strings.Join(e.Hits, "、"))
+}
+
+func ConvertSensitiveWordEntityToModel(in *entity.SensitiveWord) (out *SensitiveWord) {
+	out = &SensitiveWord{
+		ID:         in.ID,
+		Word:       in.Word,
+		Category:   in.Category,
+		Enabled:    in.Enabled == 1,
+		CreateTime: in.CreateTime,
+		UpdateTime: in.UpdateTime,
+	}
+	return
+}
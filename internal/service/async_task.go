@@ -13,17 +13,59 @@ type IAsyncTask interface {
 	// 注册任务处理函数
 	RegisterHandler(taskType model.AsyncTaskType, handler model.AsyncTaskHandler)
 
-	// 添加任务
+	// 注册周期任务，按 cron 表达式定期生成任务执行，需要在 Start 之前调用
+	RegisterRecurring(name string, cronSpec string, handler model.AsyncTaskHandler)
+
+	// 添加任务，自定义任务ID不为空时，同一任务类型下只能有一个该ID的等待执行任务，需要替换时使用 Upsert
 	AddTask(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, content []byte) error
 
 	// 添加定时任务，指定执行时间
 	AddScheduledTask(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, content []byte, scheduledTime *gtime.Time) error
 
+	// 按自定义任务ID添加任务，已有相同自定义任务ID的等待执行任务时替换其内容和执行时间；scheduledTime 为空时立即执行
+	Upsert(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, content []byte, scheduledTime *gtime.Time) error
+
+	// 取消指定自定义任务ID的等待执行任务，返回 false 表示没有等待执行的任务
+	CancelByKey(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string) (canceled bool, err error)
+
+	// 修改指定自定义任务ID的等待执行任务的执行时间，返回 false 表示没有等待执行的任务
+	RescheduleByKey(ctx context.Context, tx gdb.TX, op model.AsyncTaskType, customID string, scheduledTime *gtime.Time) (rescheduled bool, err error)
+
 	// 唤醒任务
 	WakeUp(taskType model.AsyncTaskType)
 
 	// 启动异步任务处理线程
 	Start()
+
+	// 停止领取新任务并等待执行中的任务完成，ctx 到期后把未完成的任务放回待执行
+	Shutdown(ctx context.Context) error
+
+	// 管理端查询任务列表
+	ListTasks(ctx context.Context, filter *model.AsyncTaskFilter, pageReq *model.PageReq) (outs []*model.AsyncTask, pageRes *model.PageRes, err error)
+
+	// 管理端查询任务详情
+	GetTask(ctx context.Context, id int64) (out *model.AsyncTask, err error)
+
+	// 重新执行重试次数耗尽的任务
+	RerunTask(ctx context.Context, id int64, version int) (err error)
+
+	// 取消待执行或等待重试的任务
+	CancelTask(ctx context.Context, id int64, version int) (err error)
+
+	// 修改待执行或等待重试的任务的下次处理时间
+	RescheduleTask(ctx context.Context, id int64, version int, nextRetryTime *gtime.Time) (err error)
+
+	// 各任务类型的任务数量和积压情况
+	GetTaskStats(ctx context.Context) (outs []*model.AsyncTaskStats, err error)
+
+	// 查询所有周期任务
+	ListRecurringTasks(ctx context.Context) (outs []*model.RecurringTask, err error)
+
+	// 暂停周期任务
+	PauseRecurringTask(ctx context.Context, name string) (err error)
+
+	// 恢复周期任务
+	ResumeRecurringTask(ctx context.Context, name string) (err error)
 }
 
 var (
@@ -40,3 +82,9 @@ func AsyncTask() IAsyncTask {
 func RegisterAsyncTask(i IAsyncTask) {
 	localAsyncTask = i
 }
+
+// RegisterTyped 注册按类型解析任务内容的处理函数，任务内容需要使用 model.MarshalAsyncTaskPayload 写入
+// 内容格式错误的任务不再重试，直接标记为重试耗尽
+func RegisterTyped[T model.AsyncTaskPayload](taskType model.AsyncTaskType, handler func(ctx context.Context, payload *T) error) {
+	AsyncTask().RegisterHandler(taskType, model.TypedAsyncTaskHandler(handler))
+}
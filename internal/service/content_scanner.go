@@ -0,0 +1,51 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+	"io"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// IContentScanner 上传文件的恶意内容扫描，实现需要支持并发调用
+type IContentScanner interface {
+	// Scan 扫描文件内容，扫描引擎不可用等情况返回 error，由调用方重试
+	Scan(ctx context.Context, r io.Reader) (out *model.ContentScanResult, err error)
+}
+
+var localContentScanner IContentScanner
+
+func ContentScanner() IContentScanner {
+	if localContentScanner == nil {
+		panic("implement not found for interface IContentScanner, forgot register?")
+	}
+	return localContentScanner
+}
+
+func RegisterContentScanner(i IContentScanner) {
+	localContentScanner = i
+}
+
+const (
+	ContentScannerDriverClamd = "clamd"
+	ContentScannerDriverFake  = "fake"
+)
+
+// NewContentScanner 根据配置 contentScanner.driver 创建扫描实现，默认使用 clamd
+func NewContentScanner() IContentScanner {
+	ctx := context.Background()
+	switch driver := g.Cfg().MustGet(ctx, "contentScanner.driver", ContentScannerDriverClamd).String(); driver {
+	case ContentScannerDriverFake:
+		g.Log().Warning(ctx, "使用本地模拟的内容扫描，只识别 EICAR 测试文件，不要在生产环境使用")
+		return NewFakeContentScanner()
+	case ContentScannerDriverClamd:
+		return NewClamdScanner(
+			g.Cfg().MustGet(ctx, "contentScanner.clamd.address", "127.0.0.1:3310").String(),
+			g.Cfg().MustGet(ctx, "contentScanner.clamd.timeout", 10*time.Minute).Duration(),
+		)
+	default:
+		panic("unknown contentScanner.driver: " + driver)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+const clamdChunkSize = 64 << 10
+
+// clamdScanner 通过 clamd 的 TCP 协议(INSTREAM 命令)扫描文件内容
+// clamd 默认的 StreamMaxLength 为 25M，扫描APK需要在 clamd.conf 中调大，否则返回 size limit exceeded 错误
+type clamdScanner struct {
+	address string
+	timeout time.Duration
+}
+
+func NewClamdScanner(address string, timeout time.Duration) IContentScanner {
+	if timeout <= 0 {
+		timeout = 10 * time.Minute
+	}
+	return &clamdScanner{
+		address: address,
+		timeout: timeout,
+	}
+}
+
+func (s *clamdScanner) Scan(ctx context.Context, r io.Reader) (out *model.ContentScanResult, err error) {
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, fmt.Errorf("连接clamd失败: %w", err)
+	}
+	defer conn.Close()
+
+	// 超时或 ctx 取消时中断读写
+	if err = conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		return
+	}
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	// 命令以 z 开头表示以 \0 结尾，之后发送若干个 长度(4字节大端) + 数据 的分块，长度为 0 的分块表示结束
+	readErr, writeErr := s.stream(conn, r)
+	if readErr != nil {
+		// 没有发送结束分块，clamd 会一直等待数据，不等待扫描结果直接关闭连接
+		return nil, fmt.Errorf("读取待扫描的文件内容失败: %w", readErr)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		if writeErr != nil {
+			return nil, fmt.Errorf("发送文件内容到clamd失败: %w", writeErr)
+		}
+		return nil, fmt.Errorf("读取clamd扫描结果失败: %w", err)
+	}
+	return parseClamdReply(reply)
+}
+
+// stream 发送文件内容，readErr 为读取 r 失败，writeErr 为发送失败(clamd 可能已返回错误并关闭连接，如超过大小限制)
+func (s *clamdScanner) stream(conn net.Conn, r io.Reader) (readErr, writeErr error) {
+	if _, writeErr = conn.Write([]byte("zINSTREAM\x00")); writeErr != nil {
+		return
+	}
+
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := r.Read(buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, writeErr = conn.Write(buf[:4+n]); writeErr != nil {
+				return
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err, nil
+		}
+	}
+
+	_, writeErr = conn.Write([]byte{0, 0, 0, 0})
+	return
+}
+
+// parseClamdReply 解析扫描结果：stream: OK / stream: <特征名称> FOUND / <错误信息> ERROR
+func parseClamdReply(reply string) (out *model.ContentScanResult, err error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case result == "OK":
+		return &model.ContentScanResult{Engine: ContentScannerDriverClamd}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &model.ContentScanResult{
+			Infected:  true,
+			Signature: strings.TrimSpace(strings.TrimSuffix(result, " FOUND")),
+			Engine:    ContentScannerDriverClamd,
+		}, nil
+	default:
+		return nil, fmt.Errorf("clamd扫描失败: %s", reply)
+	}
+}
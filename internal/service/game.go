@@ -26,14 +26,43 @@ type IGame interface {
 	ListInReview(ctx context.Context, pageReq *model.PageReq) (out []*model.Game, pageRes *model.PageRes, err error)
 	// 游戏审核
 	// 审核中 -> 审核通过/审核不通过
-	Approve(ctx context.Context, id int64) (err error)
-	Reject(ctx context.Context, id int64) (err error)
+	// 开启双人审核时，返回还需要的审核通过次数
+	Approve(ctx context.Context, id int64) (pendingApprovals int, err error)
+	Reject(ctx context.Context, id int64, reason string, issues []*model.ReviewIssue) (err error)
 	// 发布游戏/游戏预约发布
 	// 审核通过 -> 可预约/已发布
 	PublishGameImmediately(ctx context.Context, id int64) (err error)
 	PreRegisterGame(ctx context.Context, id int64, publishTime *gtime.Time) (err error)
 	// 下架游戏
 	UnpublishGame(ctx context.Context, id int64, unpublishReason string) (err error)
+	// 游戏状态变更历史
+	ListStatusHistory(ctx context.Context, gameID int64, pageReq *model.PageReq) (outs []*model.GameStatusHistory, pageRes *model.PageRes, err error)
+
+	// 游戏草稿
+	// 可预约/已上架的游戏修改信息时写入草稿，草稿审核通过后覆盖线上数据
+	GetDraft(ctx context.Context, gameID int64) (out *model.GameDraft, err error)
+	GetDraftMediaInfo(ctx context.Context, gameID int64) (out []*model.GameMediaInfo, err error)
+	DiscardDraft(ctx context.Context, gameID int64) (err error)
+	SubmitDraftForReview(ctx context.Context, gameID int64) (err error)
+	ApproveDraft(ctx context.Context, gameID int64) (err error)
+	RejectDraft(ctx context.Context, gameID int64, reason string) (err error)
+	ListDraftInReview(ctx context.Context, pageReq *model.PageReq) (outs []*model.GameDraft, pageRes *model.PageRes, err error)
+	// 草稿与线上数据的差异
+	DiffDraft(ctx context.Context, gameID int64) (out *model.GameDraftDiff, err error)
+
+	// APK游戏构建版本
+	// 构建版本有独立的审核状态，切换当前版本和回滚不影响游戏状态
+	CreateBuild(ctx context.Context, in *model.GameBuild) (id int64, err error)
+	GetBuild(ctx context.Context, gameID, buildID int64) (out *model.GameBuild, err error)
+	ListBuilds(ctx context.Context, gameID int64, pageReq *model.PageReq) (outs []*model.GameBuild, pageRes *model.PageRes, err error)
+	GetCurrentBuild(ctx context.Context, gameID int64) (out *model.GameBuild, err error)
+	SubmitBuildForReview(ctx context.Context, gameID, buildID int64) (err error)
+	ApproveBuild(ctx context.Context, gameID, buildID int64) (err error)
+	RejectBuild(ctx context.Context, gameID, buildID int64, reason string) (err error)
+	SetCurrentBuild(ctx context.Context, gameID, buildID int64) (err error)
+	RollbackBuild(ctx context.Context, gameID, buildID int64) (out *model.GameBuild, err error)
+	// 获取游戏下载使用的文件ID
+	ResolveDownloadFileID(ctx context.Context, gameID int64) (fileID string, err error)
 
 	// 事件驱动的状态流转
 	HandleGameEvent(ctx context.Context, gameID int64, event model.GameEvent, data interface{}) error
@@ -41,30 +70,52 @@ type IGame interface {
 	UpdateGameInfo(ctx context.Context, gameID int64) error
 	UpdateGameVersion(ctx context.Context, gameID int64) error
 
-	HandleGameAutoPublish(ctx context.Context, task *model.AsyncTask) error
-	NotifyReservedUsers(ctx context.Context, task *model.AsyncTask) error
+	HandleGameAutoPublish(ctx context.Context, task *model.GameAutoPublishTask) error
+	NotifyReservedUsers(ctx context.Context, task *model.GameNotifyReservedUsersTask) error
+
+	// 状态停留时长SLA
+	GetSLAStats(ctx context.Context, status *model.GameStatus, days int) (outs []*model.GameSLAStats, err error)
+	HandleSLACheck(ctx context.Context) (err error)
 
 	// 批量状态更新（定时任务用）
 	BatchUpdateGameStatus(ctx context.Context) error
 
 	// 游戏搜索
 	SearchGameByGameName(ctx context.Context, name string, page, size int) (out []*model.Game, pageRes *model.PageRes, err error)
+	// 按关键词和筛选条件搜索游戏，返回各筛选项的游戏数量；搜索索引未加载完成时返回 model.ErrSearchIndexNotReady
+	SearchGames(ctx context.Context, in *model.GameSearchQuery) (out []*model.Game, pageRes *model.PageRes, facets *model.GameSearchFacets, err error)
 
 	// 游戏媒体管理
 	AddMediaInfo(ctx context.Context, mediaInfo *model.GameMediaInfo) (err error)
 	GetMediaInfo(ctx context.Context, gameID int64) (out []*model.GameMediaInfo, err error)
 	UpdateMediaInfoByGameID(ctx context.Context, gameID int64, mediaInfos []*model.GameMediaInfo) (err error)
 	UpdateMediaInfoStatusByFileID(ctx context.Context, fileID string, status model.GameMediaStatus) (err error)
+	// 文件上传结果，客户端上报或文件引擎发送消息，只更新等待上传的文件
+	ApplyUploadResult(ctx context.Context, fileID string, success bool) (applied bool, err error)
+	HandleFileUploadResult(ctx context.Context, e *model.FileUploadResultEvent) (err error)
+	// APK安装包解析结果
+	GetApkInfo(ctx context.Context, gameID int64, fileID string) (out *model.GameApkInfo, err error)
+	HandleApkParse(ctx context.Context, task *model.GameApkParseTask) (err error)
+	// 上传文件内容安全扫描
+	HandleContentScan(ctx context.Context, task *model.MediaContentScanTask) (err error)
+	RescanMediaFile(ctx context.Context, fileID string) (err error)
 	// 设置H5链接
 	SetH5Link(ctx context.Context, gameID int64, link string) (err error)
 	// 游戏提交审核时，检查必要的媒体文件是否上传
 	CheckMediaInfo(ctx context.Context, gameInfo *model.Game) (err error)
 
+	// 提交审核检查
+	RegisterValidator(name string, validator model.GameValidator)
+	Validate(ctx context.Context, in *model.ValidationSubject) (findings []*model.ValidationFinding, err error)
+	ListValidationWarnings(ctx context.Context, gameIDs []int64, draft bool) (out map[int64][]*model.ValidationFinding, err error)
+
 	// 游戏收藏
 	AddFavorite(ctx context.Context, gameID, userID int64) error
 	RemoveFavorite(ctx context.Context, gameID, userID int64) error
 	GetUserFavorites(ctx context.Context, userID int64, pageReq *model.PageReq) (out []*model.Game, pageRes *model.PageRes, err error)
 	IsUserFavorited(ctx context.Context, gameID, userID int64) (bool, error)
+	// 用户注销时删除用户的收藏和评分
+	PurgeUserData(ctx context.Context, userID int64) error
 
 	// 游戏评分
 	AddRating(ctx context.Context, gameID, userID int64, rating int) error
@@ -1,15 +1,31 @@
 package service
 
 import (
+	"GameEngine/internal/model"
 	"context"
-	"log"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
-	mqsdk "github.com/yyboo586/MQSDK"
+	"github.com/gogf/gf/v2/frame/g"
 )
 
 type IMQ interface {
 	Publish(ctx context.Context, topic string, message interface{}) error
+
+	// 指定消息ID发送，消费方按消息ID去重
+	PublishWithID(ctx context.Context, topic string, id string, message interface{}) error
+
+	// 订阅主题，同一 channel(NSQ channel、Kafka 消费组)的多个实例分摊消息，不同 channel 各自收到全部消息
+	// 后台持续消费，连接失败时按 mq.reconnectInterval 重新连接；处理函数返回 nil 后才确认消息，返回错误或进程退出时消息重新投递(至少一次)
+	Subscribe(topic string, channel string, handler model.MQHandler) error
+
+	// 停止所有订阅，等待处理中的消息完成；ctx 到期后直接返回，未确认的消息重新投递
+	StopConsumers(ctx context.Context) error
+
+	// 关闭生产者，停机时在所有消息发送完成后调用
+	Close() error
 }
 
 var localMQ IMQ
@@ -25,42 +41,245 @@ func RegisterMQ(i IMQ) {
 	localMQ = i
 }
 
+const (
+	MQDriverNSQ    = "nsq"
+	MQDriverKafka  = "kafka"
+	MQDriverMemory = "memory"
+
+	defaultMQReconnectInterval = 5 * time.Second
+)
+
 var (
-	mqOnce     sync.Once
-	mqInstance *mq
+	// ErrMQClosed 消息队列生产者已关闭
+	ErrMQClosed = errors.New("消息队列生产者已关闭")
+	// ErrMQConsumersStopped 已停止订阅
+	ErrMQConsumersStopped = errors.New("消息队列已停止订阅")
 )
 
-var config = &mqsdk.NSQConfig{
-	Type:     "nsq",
-	NSQDAddr: "124.221.243.128:4150",
-	// 不使用NSQLookup，直接连接NSQD
-	// NSQLookup: []string{},
+// outgoingMessage 发送到消息队列的消息
+type outgoingMessage struct {
+	ID   string      // 消息ID，为空时由驱动生成
+	Body interface{} // 消息体，序列化为JSON
 }
 
+// mqDriver 消息队列驱动，实现需要支持并发调用
+type mqDriver interface {
+	Publish(ctx context.Context, topic string, message *outgoingMessage) error
+
+	// Consume 消费主题在 channel 上的消息，最多 concurrency 条同时处理；ctx 取消后等待处理中的消息完成再返回
+	// 处理函数返回错误的消息不确认，由消息队列重新投递；连接失败等错误直接返回，由调用方重新消费
+	Consume(ctx context.Context, topic string, channel string, concurrency int, handler model.MQHandler) error
+
+	Close() error
+}
+
+var (
+	mqOnce     sync.Once
+	mqInstance *mq
+)
+
 type mq struct {
-	producer mqsdk.Producer
+	driver              *lazyMQDriver
+	topics              map[string]string // 主题映射，未配置的主题使用代码中的名称
+	consumerConcurrency int               // 每个订阅同时处理的消息数量
+
+	consumeCtx  context.Context // 停止订阅时取消
+	stopConsume context.CancelFunc
+	consumers   sync.WaitGroup
 }
 
+// NewMQ 根据配置 mq.driver 创建消息队列生产者，默认使用 nsq
+// 启动时连接失败不影响服务启动，发送消息时返回错误并按 mq.reconnectInterval 重新连接
 func NewMQ() *mq {
 	mqOnce.Do(func() {
-		factory := mqsdk.NewFactory()
+		ctx := context.Background()
 
-		// 创建生产者
-		producer, err := factory.NewProducer(config)
-		if err != nil {
-			log.Fatalf("Failed to create producer: %v", err)
+		var connect func() (mqDriver, error)
+		switch driver := g.Cfg().MustGet(ctx, "mq.driver", MQDriverNSQ).String(); driver {
+		case MQDriverNSQ:
+			address := g.Cfg().MustGet(ctx, "mq.nsq.address", "127.0.0.1:4150").String()
+			connect = func() (mqDriver, error) {
+				return newNSQDriver(address)
+			}
+		case MQDriverKafka:
+			config := &kafkaDriverConfig{
+				Brokers:  g.Cfg().MustGet(ctx, "mq.kafka.brokers").Strings(),
+				ClientID: g.Cfg().MustGet(ctx, "mq.kafka.clientID", "game-engine").String(),
+				Version:  g.Cfg().MustGet(ctx, "mq.kafka.version").String(),
+				Timeout:  g.Cfg().MustGet(ctx, "mq.kafka.timeout", 10*time.Second).Duration(),
+			}
+			connect = func() (mqDriver, error) {
+				return newKafkaDriver(config)
+			}
+		case MQDriverMemory:
+			g.Log().Warning(ctx, "使用进程内的消息队列，消息不会发送到其他服务，不要在生产环境使用")
+			bufferSize := g.Cfg().MustGet(ctx, "mq.memory.bufferSize", defaultMemoryMQBufferSize).Int()
+			connect = func() (mqDriver, error) {
+				return newMemoryDriver(bufferSize), nil
+			}
+		default:
+			panic("unknown mq.driver: " + driver)
 		}
 
+		reconnectInterval := g.Cfg().MustGet(ctx, "mq.reconnectInterval", defaultMQReconnectInterval).Duration()
+		consumerConcurrency := g.Cfg().MustGet(ctx, "mq.consumerConcurrency", 1).Int()
+		if consumerConcurrency <= 0 {
+			consumerConcurrency = 1
+		}
+		consumeCtx, stopConsume := context.WithCancel(context.Background())
 		mqInstance = &mq{
-			producer: producer,
+			driver:              newLazyMQDriver(connect, reconnectInterval),
+			topics:              g.Cfg().MustGet(ctx, "mq.topics").MapStrStr(),
+			consumerConcurrency: consumerConcurrency,
+			consumeCtx:          consumeCtx,
+			stopConsume:         stopConsume,
+		}
+		if err := mqInstance.driver.connect(); err != nil {
+			g.Log().Errorf(ctx, "%v，发送消息时重新连接", err)
 		}
 	})
 	return mqInstance
 }
 
 func (m *mq) Publish(ctx context.Context, topic string, message interface{}) error {
-	msg := &mqsdk.Message{
+	return m.PublishWithID(ctx, topic, "", message)
+}
+
+func (m *mq) PublishWithID(ctx context.Context, topic string, id string, message interface{}) error {
+	return m.driver.Publish(ctx, m.mapTopic(topic), &outgoingMessage{
+		ID:   id,
 		Body: message,
+	})
+}
+
+func (m *mq) Subscribe(topic string, channel string, handler model.MQHandler) error {
+	if topic == "" || channel == "" {
+		return fmt.Errorf("订阅的主题和 channel 不能为空")
+	}
+	if m.consumeCtx.Err() != nil {
+		return ErrMQConsumersStopped
+	}
+
+	m.consumers.Add(1)
+	go m.consume(m.mapTopic(topic), channel, handler)
+	return nil
+}
+
+// consume 持续消费，连接失败或消费中断后等待 reconnectInterval 再重新消费
+func (m *mq) consume(topic string, channel string, handler model.MQHandler) {
+	defer m.consumers.Done()
+
+	g.Log().Infof(m.consumeCtx, "[MQ] subscribe topic=%s, channel=%s", topic, channel)
+	for m.consumeCtx.Err() == nil {
+		driver, err := m.driver.get()
+		if err == nil {
+			err = driver.Consume(m.consumeCtx, topic, channel, m.consumerConcurrency, handler)
+		}
+		if m.consumeCtx.Err() != nil {
+			break
+		}
+		g.Log().Errorf(m.consumeCtx, "[MQ] consume topic=%s, channel=%s error: %v, retry after %s", topic, channel, err, m.driver.reconnectInterval)
+		select {
+		case <-m.consumeCtx.Done():
+		case <-time.After(m.driver.reconnectInterval):
+		}
+	}
+	g.Log().Infof(m.consumeCtx, "[MQ] unsubscribe topic=%s, channel=%s", topic, channel)
+}
+
+func (m *mq) StopConsumers(ctx context.Context) error {
+	m.stopConsume()
+
+	done := make(chan struct{})
+	go func() {
+		m.consumers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait mq consumers exit: %w", ctx.Err())
+	}
+}
+
+func (m *mq) Close() error {
+	return m.driver.Close()
+}
+
+func (m *mq) mapTopic(topic string) string {
+	if mapped, ok := m.topics[topic]; ok && mapped != "" {
+		return mapped
+	}
+	return topic
+}
+
+// lazyMQDriver 连接成功后才创建驱动；连接失败时发送消息直接返回错误，距上次连接超过 reconnectInterval 后再次连接
+type lazyMQDriver struct {
+	dial              func() (mqDriver, error)
+	reconnectInterval time.Duration
+
+	mutex       sync.Mutex
+	driver      mqDriver
+	closed      bool
+	lastErr     error
+	lastAttempt time.Time
+}
+
+func newLazyMQDriver(dial func() (mqDriver, error), reconnectInterval time.Duration) *lazyMQDriver {
+	if reconnectInterval <= 0 {
+		reconnectInterval = defaultMQReconnectInterval
+	}
+	return &lazyMQDriver{
+		dial:              dial,
+		reconnectInterval: reconnectInterval,
+	}
+}
+
+func (d *lazyMQDriver) connect() error {
+	_, err := d.get()
+	return err
+}
+
+func (d *lazyMQDriver) get() (mqDriver, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.closed {
+		return nil, ErrMQClosed
+	}
+	if d.driver != nil {
+		return d.driver, nil
+	}
+	if d.lastErr != nil && time.Since(d.lastAttempt) < d.reconnectInterval {
+		return nil, d.lastErr
+	}
+
+	d.lastAttempt = time.Now()
+	driver, err := d.dial()
+	if err != nil {
+		d.lastErr = fmt.Errorf("连接消息队列失败: %v", err)
+		return nil, d.lastErr
+	}
+	d.driver, d.lastErr = driver, nil
+	return d.driver, nil
+}
+
+func (d *lazyMQDriver) Publish(ctx context.Context, topic string, message *outgoingMessage) error {
+	driver, err := d.get()
+	if err != nil {
+		return fmt.Errorf("发送消息到主题 %s 失败: %w", topic, err)
+	}
+	return driver.Publish(ctx, topic, message)
+}
+
+func (d *lazyMQDriver) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.closed = true
+	if d.driver == nil {
+		return nil
 	}
-	return m.producer.Publish(ctx, topic, msg)
+	return d.driver.Close()
 }
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+)
+
+// IMQConsumer 订阅其他服务的消息，按消息ID去重后交给处理函数
+type IMQConsumer interface {
+	// 注册主题的处理函数，需要在 Start 之前调用；处理函数与去重记录在同一事务中执行，数据库操作需要使用传入的 ctx
+	RegisterHandler(topic string, handler model.MQHandler)
+
+	// 订阅所有已注册的主题
+	Start()
+
+	// 停止订阅，等待处理中的消息完成；ctx 到期后直接返回，未处理完成的消息重新投递
+	Shutdown(ctx context.Context) error
+
+	// 删除超过保留时长的去重记录
+	CleanupConsumed(ctx context.Context) error
+}
+
+var (
+	localMQConsumer IMQConsumer
+)
+
+func MQConsumer() IMQConsumer {
+	if localMQConsumer == nil {
+		panic("implement not found for interface IMQConsumer, forgot register?")
+	}
+	return localMQConsumer
+}
+
+func RegisterMQConsumer(i IMQConsumer) {
+	localMQConsumer = i
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// kafkaMessageIDHeader 消息ID所在的消息头，同时作为分区键
+const kafkaMessageIDHeader = "message_id"
+
+type kafkaDriverConfig struct {
+	Brokers  []string
+	ClientID string
+	Version  string // Kafka 版本，如 2.8.0，为空时使用 sarama 默认版本
+	Timeout  time.Duration
+}
+
+// kafkaDriver 通过 sarama 同步发送到 Kafka，所有副本写入成功后返回；channel 作为消费组
+type kafkaDriver struct {
+	config   *kafkaDriverConfig
+	producer sarama.SyncProducer
+}
+
+func newKafkaDriver(in *kafkaDriverConfig) (mqDriver, error) {
+	if len(in.Brokers) == 0 {
+		return nil, fmt.Errorf("未配置 mq.kafka.brokers")
+	}
+
+	config, err := newSaramaConfig(in)
+	if err != nil {
+		return nil, err
+	}
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(in.Brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaDriver{
+		config:   in,
+		producer: producer,
+	}, nil
+}
+
+func newSaramaConfig(in *kafkaDriverConfig) (*sarama.Config, error) {
+	config := sarama.NewConfig()
+	if in.ClientID != "" {
+		config.ClientID = in.ClientID
+	}
+	if in.Timeout > 0 {
+		config.Net.DialTimeout = in.Timeout
+		config.Producer.Timeout = in.Timeout
+	}
+	if in.Version != "" {
+		version, err := sarama.ParseKafkaVersion(in.Version)
+		if err != nil {
+			return nil, fmt.Errorf("mq.kafka.version 格式错误: %v", err)
+		}
+		config.Version = version
+	}
+	return config, nil
+}
+
+func (d *kafkaDriver) Publish(ctx context.Context, topic string, message *outgoingMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	value, err := json.Marshal(message.Body)
+	if err != nil {
+		return fmt.Errorf("序列化消息失败: %v", err)
+	}
+	id := message.ID
+	if id == "" {
+		id = guid.S()
+	}
+
+	_, _, err = d.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(id),
+		Value: sarama.ByteEncoder(value),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(kafkaMessageIDHeader), Value: []byte(id)},
+		},
+	})
+	return err
+}
+
+// Consume 同一分区的消息按顺序逐条处理，concurrency 不生效，并发度取决于分区数量
+// 新的消费组从最早的消息开始消费
+func (d *kafkaDriver) Consume(ctx context.Context, topic string, channel string, concurrency int, handler model.MQHandler) error {
+	config, err := newSaramaConfig(d.config)
+	if err != nil {
+		return err
+	}
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	group, err := sarama.NewConsumerGroup(d.config.Brokers, channel, config)
+	if err != nil {
+		return err
+	}
+	defer group.Close()
+
+	groupHandler := &kafkaGroupHandler{
+		ctx:     context.WithoutCancel(ctx),
+		handler: handler,
+	}
+	// 分区重新分配后 Consume 返回，需要再次调用
+	for ctx.Err() == nil {
+		if err = group.Consume(ctx, []string{topic}, groupHandler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *kafkaDriver) Close() error {
+	return d.producer.Close()
+}
+
+type kafkaGroupHandler struct {
+	ctx     context.Context
+	handler model.MQHandler
+}
+
+func (h *kafkaGroupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (h *kafkaGroupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+// ConsumeClaim 处理成功后提交位移；处理失败时不提交并退出，重新分配后从该消息重新消费
+func (h *kafkaGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			if err := h.handler(h.ctx, decodeKafkaMessage(msg)); err != nil {
+				return err
+			}
+			session.MarkMessage(msg, "")
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// decodeKafkaMessage 消息ID依次取消息头、分区键，都没有时使用分区和位移
+func decodeKafkaMessage(msg *sarama.ConsumerMessage) *model.MQMessage {
+	message := &model.MQMessage{
+		Topic: msg.Topic,
+		Body:  msg.Value,
+	}
+	for _, header := range msg.Headers {
+		if string(header.Key) == kafkaMessageIDHeader {
+			message.ID = string(header.Value)
+		}
+	}
+	if message.ID == "" {
+		message.ID = string(msg.Key)
+	}
+	if message.ID == "" {
+		message.ID = fmt.Sprintf("%s-%d-%d", msg.Topic, msg.Partition, msg.Offset)
+	}
+	return message
+}
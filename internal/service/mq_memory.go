@@ -0,0 +1,112 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+const defaultMemoryMQBufferSize = 1000
+
+// memoryDriver 进程内的消息队列，用于本地开发和测试：每个主题的每个 channel 一个有缓冲的 Go channel，
+// 发送时复制到主题的所有 channel，缓冲区满时丢弃最早的消息；没有订阅的主题直接丢弃消息
+type memoryDriver struct {
+	bufferSize int
+
+	mutex  sync.Mutex
+	topics map[string]map[string]chan *model.MQMessage
+}
+
+func newMemoryDriver(bufferSize int) *memoryDriver {
+	if bufferSize <= 0 {
+		bufferSize = defaultMemoryMQBufferSize
+	}
+	return &memoryDriver{
+		bufferSize: bufferSize,
+		topics:     make(map[string]map[string]chan *model.MQMessage),
+	}
+}
+
+func (d *memoryDriver) Publish(ctx context.Context, topic string, message *outgoingMessage) error {
+	body, err := json.Marshal(message.Body)
+	if err != nil {
+		return fmt.Errorf("序列化消息失败: %v", err)
+	}
+	id := message.ID
+	if id == "" {
+		id = guid.S()
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	g.Log().Debugf(ctx, "[MQ] memory publish: topic=%s, id=%s, channels=%d", topic, id, len(d.topics[topic]))
+	for _, ch := range d.topics[topic] {
+		d.enqueue(ctx, ch, &model.MQMessage{ID: id, Topic: topic, Body: body})
+	}
+	return nil
+}
+
+// enqueue 缓冲区已满时丢弃最早的消息
+func (d *memoryDriver) enqueue(ctx context.Context, ch chan *model.MQMessage, message *model.MQMessage) {
+	for {
+		select {
+		case ch <- message:
+			return
+		default:
+		}
+		select {
+		case dropped := <-ch:
+			g.Log().Warningf(ctx, "[MQ] memory topic %s is full, drop message %s", dropped.Topic, dropped.ID)
+		default:
+		}
+	}
+}
+
+func (d *memoryDriver) Consume(ctx context.Context, topic string, channel string, concurrency int, handler model.MQHandler) error {
+	d.mutex.Lock()
+	channels, ok := d.topics[topic]
+	if !ok {
+		channels = make(map[string]chan *model.MQMessage)
+		d.topics[topic] = channels
+	}
+	ch, ok := channels[channel]
+	if !ok {
+		ch = make(chan *model.MQMessage, d.bufferSize)
+		channels[channel] = ch
+	}
+	d.mutex.Unlock()
+
+	handlerCtx := context.WithoutCancel(ctx)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case message := <-ch:
+					if err := handler(handlerCtx, message); err != nil {
+						// 放回队列重新投递
+						d.mutex.Lock()
+						d.enqueue(handlerCtx, ch, message)
+						d.mutex.Unlock()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (d *memoryDriver) Close() error {
+	return nil
+}
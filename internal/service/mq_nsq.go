@@ -0,0 +1,95 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nsqio/go-nsq"
+	mqsdk "github.com/yyboo586/MQSDK"
+)
+
+// nsqDriver 通过 MQSDK 发送到 NSQ，通过 go-nsq 消费，都直接连接 nsqd
+type nsqDriver struct {
+	address  string
+	producer mqsdk.Producer
+}
+
+func newNSQDriver(address string) (mqDriver, error) {
+	producer, err := mqsdk.NewFactory().NewProducer(&mqsdk.NSQConfig{
+		Type:     MQDriverNSQ,
+		NSQDAddr: address,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &nsqDriver{
+		address:  address,
+		producer: producer,
+	}, nil
+}
+
+func (d *nsqDriver) Publish(ctx context.Context, topic string, message *outgoingMessage) error {
+	return d.producer.Publish(ctx, topic, &mqsdk.Message{
+		ID:   message.ID,
+		Body: message.Body,
+	})
+}
+
+func (d *nsqDriver) Consume(ctx context.Context, topic string, channel string, concurrency int, handler model.MQHandler) error {
+	config := nsq.NewConfig()
+	config.MaxInFlight = concurrency
+	config.MaxAttempts = 0 // 不限制投递次数，放弃处理由处理函数决定
+	consumer, err := nsq.NewConsumer(topic, channel, config)
+	if err != nil {
+		return err
+	}
+	consumer.SetLoggerLevel(nsq.LogLevelWarning)
+
+	handlerCtx := context.WithoutCancel(ctx)
+	consumer.AddConcurrentHandlers(nsq.HandlerFunc(func(m *nsq.Message) error {
+		return handler(handlerCtx, decodeNSQMessage(topic, m))
+	}), concurrency)
+
+	// 连接断开后 go-nsq 会自动重连
+	if err = consumer.ConnectToNSQD(d.address); err != nil {
+		consumer.Stop()
+		<-consumer.StopChan
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-consumer.StopChan:
+		return fmt.Errorf("nsq consumer stopped")
+	}
+	consumer.Stop()
+	<-consumer.StopChan
+	return nil
+}
+
+// decodeNSQMessage MQSDK 发送的消息带有消息ID和消息体，其他消息整体作为消息体，使用 NSQ 的消息ID
+func decodeNSQMessage(topic string, m *nsq.Message) *model.MQMessage {
+	message := &model.MQMessage{
+		ID:    string(m.ID[:]),
+		Topic: topic,
+		Body:  m.Body,
+	}
+
+	var envelope struct {
+		ID   string          `json:"id"`
+		Body json.RawMessage `json:"body"`
+	}
+	if json.Unmarshal(m.Body, &envelope) == nil && len(envelope.Body) > 0 {
+		if envelope.ID != "" {
+			message.ID = envelope.ID
+		}
+		message.Body = envelope.Body
+	}
+	return message
+}
+
+func (d *nsqDriver) Close() error {
+	return d.producer.Close()
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+
+	"GameEngine/internal/model/events"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// IOutbox 事务性发件箱接口
+type IOutbox interface {
+	// 写入领域事件，按 CloudEvents 格式发送到事件类型对应的主题，去重键作为事件ID；需要与业务数据处于同一事务中，同一去重键只写入一次
+	AddEvent(ctx context.Context, tx gdb.TX, dedupKey string, e events.Event) error
+
+	// 写入发送到指定主题的消息，需要与业务数据处于同一事务中；同一去重键只写入一次
+	AddMessage(ctx context.Context, tx gdb.TX, topic string, dedupKey string, body interface{}) error
+
+	// 唤醒发送线程
+	WakeUp()
+
+	// 启动发送线程
+	Start()
+
+	// 停止发送线程，等待正在发送的消息完成
+	Shutdown(ctx context.Context) error
+
+	// 删除超过保留时长的已发送消息
+	CleanupPublished(ctx context.Context) error
+}
+
+var (
+	localOutbox IOutbox
+)
+
+func Outbox() IOutbox {
+	if localOutbox == nil {
+		panic("implement not found for interface IOutbox, forgot register?")
+	}
+	return localOutbox
+}
+
+func RegisterOutbox(i IOutbox) {
+	localOutbox = i
+}
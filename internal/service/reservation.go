@@ -13,6 +13,9 @@ type IReservation interface {
 	// 取消预约
 	CancelReservation(ctx context.Context, userID, gameID int64) error
 
+	// 用户注销时删除用户的所有预约
+	PurgeUserReservations(ctx context.Context, userID int64) error
+
 	// 获取用户预约列表
 	GetUserReservations(ctx context.Context, userID int64, pageReq *model.PageReq) (outs []*model.Game, pageRes *model.PageRes, err error)
 
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+)
+
+// IReview 审核反馈服务接口
+type IReview interface {
+	// 拒绝原因目录管理
+	CreateRejectCode(ctx context.Context, in *model.ReviewRejectCode) (id int64, err error)
+	UpdateRejectCode(ctx context.Context, in *model.ReviewRejectCode) (err error)
+	DeleteRejectCode(ctx context.Context, id int64) (err error)
+	ListRejectCodes(ctx context.Context, onlyEnabled bool) (outs []*model.ReviewRejectCode, err error)
+
+	// 审核问题
+	// 审核拒绝时记录问题，需要与状态变更处于同一事务中
+	AddIssues(ctx context.Context, gameID int64, issues []*model.ReviewIssue) (err error)
+	ListIssues(ctx context.Context, gameID int64, onlyOpen bool) (outs []*model.ReviewIssue, err error)
+	ResolveIssue(ctx context.Context, gameID, issueID int64) (err error)
+	AddComment(ctx context.Context, gameID, issueID int64, content string) (id int64, err error)
+	// 审核拒绝后通知开发者
+	NotifyRejected(ctx context.Context, gameInfo *model.Game, reason string, issues []*model.ReviewIssue) (err error)
+
+	// 审核队列
+	// 审核人员领取游戏后在租约期内独占审核，租约到期自动释放
+	ClaimNext(ctx context.Context) (out *model.ReviewClaim, err error)
+	Claim(ctx context.Context, gameID int64) (out *model.ReviewClaim, err error)
+	ReleaseClaim(ctx context.Context, gameID int64) (err error)
+	HandoffClaim(ctx context.Context, gameID, toReviewerID int64, toReviewerName string) (err error)
+	ListMyClaims(ctx context.Context, pageReq *model.PageReq) (outs []*model.ReviewClaim, pageRes *model.PageRes, err error)
+	GetClaim(ctx context.Context, gameID int64) (out *model.ReviewClaim, err error)
+	// 审核通过/拒绝前检查当前用户是否持有审核任务
+	AssertClaimHolder(ctx context.Context, gameID int64) (claim *model.ReviewClaim, err error)
+	// 记录审核通过，返回还需要的审核通过次数
+	AddApproval(ctx context.Context, gameInfo *model.Game) (pendingApprovals int, err error)
+	// 审核结束时清理领取记录和审核通过记录
+	ClearReviewState(ctx context.Context, gameID int64) (err error)
+	HandleClaimExpire(ctx context.Context, task *model.ReviewClaimExpireTask) (err error)
+}
+
+var localReview IReview
+
+func Review() IReview {
+	if localReview == nil {
+		panic("implement not found for interface IReview, forgot register?")
+	}
+	return localReview
+}
+
+func RegisterReview(i IReview) {
+	localReview = i
+}
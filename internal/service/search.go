@@ -0,0 +1,38 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+)
+
+// ISearch 游戏全文搜索服务接口
+// 索引保存在进程内存中，各实例启动时从数据库加载，并定期同步其他实例的修改
+type ISearch interface {
+	// 加载索引，并定期同步游戏变更
+	Start(ctx context.Context)
+	// 停止同步索引，等待执行中的同步完成
+	Shutdown(ctx context.Context) error
+	// 从数据库重建索引
+	Rebuild(ctx context.Context) (stats *model.SearchIndexStats, err error)
+	// 重新索引指定游戏，已删除的游戏从索引中移除；在游戏数据的事务提交后调用
+	Refresh(ctx context.Context, gameIDs ...int64)
+	// 搜索游戏，索引未加载完成时返回 ErrSearchIndexNotReady
+	Search(ctx context.Context, in *model.GameSearchQuery) (out *model.GameSearchResult, err error)
+	// 搜索建议：按前缀匹配线上游戏名称、开发商和热门搜索词，按热度排序
+	Suggest(ctx context.Context, prefix string, limit int) (out []*model.SearchSuggestion, err error)
+	// 索引状态
+	Stats(ctx context.Context) (stats *model.SearchIndexStats)
+}
+
+var localSearch ISearch
+
+func Search() ISearch {
+	if localSearch == nil {
+		panic("implement not found for interface ISearch, forgot register?")
+	}
+	return localSearch
+}
+
+func RegisterSearch(i ISearch) {
+	localSearch = i
+}
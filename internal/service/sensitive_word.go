@@ -0,0 +1,42 @@
+package service
+
+import (
+	"GameEngine/internal/model"
+	"context"
+)
+
+// ISensitiveWord 敏感词过滤服务接口
+// 不同调用场景选择不同的处理方式：拒绝(Reject)、打码(Mask)、标记后交由人工处理(Match)
+type ISensitiveWord interface {
+	// 词库管理，修改后立即重新加载
+	CreateWord(ctx context.Context, in *model.SensitiveWord) (id int64, err error)
+	UpdateWord(ctx context.Context, in *model.SensitiveWord) (err error)
+	DeleteWord(ctx context.Context, id int64) (err error)
+	ListWords(ctx context.Context, keyword string, pageReq *model.PageReq) (outs []*model.SensitiveWord, pageRes *model.PageRes, err error)
+	// 从数据库重新加载词库
+	Reload(ctx context.Context) (err error)
+	// 加载词库，并定期检查词库变更后热更新
+	Start(ctx context.Context)
+	// 停止检查词库变更，等待执行中的检查完成
+	Shutdown(ctx context.Context) error
+
+	// 拒绝：包含敏感词时返回 SensitiveWordError，field 为错误提示中的字段名称
+	Reject(ctx context.Context, field, text string) (err error)
+	// 打码：敏感词替换为 *
+	Mask(ctx context.Context, text string) (out string)
+	// 标记：返回命中的敏感词，由调用方决定如何处理
+	Match(ctx context.Context, text string) (hits []string)
+}
+
+var localSensitiveWord ISensitiveWord
+
+func SensitiveWord() ISensitiveWord {
+	if localSensitiveWord == nil {
+		panic("implement not found for interface ISensitiveWord, forgot register?")
+	}
+	return localSensitiveWord
+}
+
+func RegisterSensitiveWord(i ISensitiveWord) {
+	localSensitiveWord = i
+}
@@ -15,6 +15,9 @@ type IUserBehavior interface {
 
 	// 玩过游戏历史管理
 	GetPlayHistory(ctx context.Context, userID int64, pageReq *model.PageReq) ([]*model.UserBehavior, *model.PageRes, error)
+
+	// 用户注销时删除用户的所有行为记录
+	PurgeUserBehavior(ctx context.Context, userID int64) error
 }
 
 var localUserBehavior IUserBehavior
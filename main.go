@@ -8,13 +8,19 @@ import (
 	"GameEngine/internal/logics/ranking"
 	"GameEngine/internal/logics/recommendation"
 	"GameEngine/internal/logics/reservation"
+	"GameEngine/internal/logics/review"
+	"GameEngine/internal/logics/search"
+	"GameEngine/internal/logics/sensitive"
 	"GameEngine/internal/model"
 	"GameEngine/internal/service"
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/glog"
 
 	_ "github.com/gogf/gf/contrib/drivers/mysql/v2"
@@ -28,6 +34,8 @@ func main() {
 
 	logicsGame := game.NewGame()
 	logicsAsyncTask := logics.NewAsyncTask()
+	logicsReview := review.NewReview()
+	logicsSensitiveWord := sensitive.NewSensitiveWord()
 
 	service.RegisterAdminService(service.NewAdminService())
 	service.RegisterFileEngine()
@@ -36,15 +44,52 @@ func main() {
 	service.RegisterRanking(ranking.NewRanking())
 	service.RegisterRecommendation(recommendation.NewRecommendation())
 	service.RegisterReservation(reservation.NewReservation())
+	service.RegisterReview(logicsReview)
+	service.RegisterSearch(search.NewSearch())
+	service.RegisterSensitiveWord(logicsSensitiveWord)
 	service.RegisterUserBehavior(logics.NewUserBehavier())
 	service.RegisterMQ(service.NewMQ())
+	service.RegisterContentScanner(service.NewContentScanner())
 	service.RegisterAsyncTask(logics.NewAsyncTask())
+	service.RegisterOutbox(logics.NewOutbox())
+	service.RegisterMQConsumer(logics.NewMQConsumer())
 
 	// 注册异步任务处理器
-	logicsAsyncTask.RegisterHandler(model.AsyncTaskTypeGameAutoPublish, logicsGame.HandleGameAutoPublish)
-	logicsAsyncTask.RegisterHandler(model.AsyncTaskTypeGameNotifyReservedUsers, logicsGame.NotifyReservedUsers)
+	service.RegisterTyped(model.AsyncTaskTypeGameAutoPublish, logicsGame.HandleGameAutoPublish)
+	service.RegisterTyped(model.AsyncTaskTypeGameNotifyReservedUsers, logicsGame.NotifyReservedUsers)
+	service.RegisterTyped(model.AsyncTaskTypeReviewClaimExpire, logicsReview.HandleClaimExpire)
+	service.RegisterTyped(model.AsyncTaskTypeGameApkParse, logicsGame.HandleApkParse)
+	service.RegisterTyped(model.AsyncTaskTypeMediaContentScan, logicsGame.HandleContentScan)
+
+	// 注册周期任务，兜底处理自动上架任务遗漏的预约游戏
+	logicsAsyncTask.RegisterRecurring("game_batch_update_status", "*/5 * * * *", func(ctx context.Context, task *model.AsyncTask) error {
+		return logicsGame.BatchUpdateGameStatus(ctx)
+	})
+	logicsAsyncTask.RegisterRecurring("outbox_cleanup", "@hourly", func(ctx context.Context, task *model.AsyncTask) error {
+		return service.Outbox().CleanupPublished(ctx)
+	})
+	logicsAsyncTask.RegisterRecurring("mq_dedup_cleanup", "@hourly", func(ctx context.Context, task *model.AsyncTask) error {
+		return service.MQConsumer().CleanupConsumed(ctx)
+	})
+	logicsAsyncTask.RegisterRecurring("game_sla_check", "*/10 * * * *", func(ctx context.Context, task *model.AsyncTask) error {
+		return logicsGame.HandleSLACheck(ctx)
+	})
 	logicsAsyncTask.Start()
 
+	// 启动发件箱发送线程
+	service.Outbox().Start()
+
+	// 订阅其他服务的消息
+	service.MQConsumer().RegisterHandler(model.MQTopicFileUploadResult, model.TypedMQHandler(logicsGame.HandleFileUploadResult))
+	service.MQConsumer().RegisterHandler(model.MQTopicUserDeleted, model.TypedMQHandler(logics.HandleUserDeleted))
+	service.MQConsumer().Start()
+
+	// 加载敏感词库，并定期检查变更
+	logicsSensitiveWord.Start(gctx.GetInitCtx())
+
+	// 加载搜索索引，并定期同步游戏变更
+	service.Search().Start(gctx.GetInitCtx())
+
 	s.Group("/api/v1/game-engine", func(group *ghttp.RouterGroup) {
 		group.Middleware(CORS)
 		group.Middleware(ghttp.MiddlewareHandlerResponse)
@@ -56,13 +101,78 @@ func main() {
 			controller.RankingController,
 			// controller.RecommendationController,
 			controller.ReservationController,
+			controller.ReviewController,
+			controller.SearchController,
+			controller.SensitiveWordController,
+			controller.AsyncTaskController,
+			controller.EventCatalogController,
 			controller.UserBehavierController,
 		)
 	})
 
+	// 收到 SIGTERM/SIGINT 后停止接收新请求，等待执行中的请求完成(最多 shutdown.timeout)后返回
+	shutdownTimeout := g.Cfg().MustGet(gctx.GetInitCtx(), "shutdown.timeout", defaultShutdownTimeout).Duration()
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	s.SetGracefulShutdownTimeout(int(shutdownTimeout.Seconds()))
 	s.Run()
+
+	gracefulShutdown(shutdownTimeout)
 }
 
+// gracefulShutdown HTTP服务停止后，停止订阅消息，等待执行中的异步任务和正在发送的发件箱消息完成，停止搜索索引的同步和敏感词库的变更检查，关闭消息队列生产者，最后写完异步日志
+func gracefulShutdown(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(gctx.New(), timeout)
+	defer cancel()
+
+	g.Log().Infof(ctx, "开始停机，等待处理中的消息和执行中的异步任务完成: timeout=%s", timeout)
+	if err := service.MQConsumer().Shutdown(ctx); err != nil {
+		g.Log().Errorf(ctx, "停止订阅消息失败: %v", err)
+	}
+	if err := service.AsyncTask().Shutdown(ctx); err != nil {
+		g.Log().Errorf(ctx, "停止异步任务失败: %v", err)
+	}
+	if err := service.Outbox().Shutdown(ctx); err != nil {
+		g.Log().Errorf(ctx, "停止发件箱发送线程失败: %v", err)
+	}
+	if err := service.Search().Shutdown(ctx); err != nil {
+		g.Log().Errorf(ctx, "停止同步搜索索引失败: %v", err)
+	}
+	if err := service.SensitiveWord().Shutdown(ctx); err != nil {
+		g.Log().Errorf(ctx, "停止检查敏感词库变更失败: %v", err)
+	}
+	if err := service.MQ().Close(); err != nil {
+		g.Log().Errorf(ctx, "关闭消息队列生产者失败: %v", err)
+	}
+	g.Log().Info(ctx, "停机完成")
+
+	flushLogs(flushLogsTimeout)
+}
+
+// flushLogs 异步日志由单个后台协程按顺序写入，写入一条标记日志并等待其被处理，即可确认之前的日志都已写入
+func flushLogs(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(gctx.New(), timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	logger := g.Log().Clone()
+	logger.SetHandlers(func(ctx context.Context, in *glog.HandlerInput) {
+		close(done)
+	})
+	logger.Print(ctx, "flush")
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+const (
+	defaultShutdownTimeout = 30 * time.Second
+	flushLogsTimeout       = 5 * time.Second
+)
+
 func CORS(r *ghttp.Request) {
 	corsOptions := r.Response.DefaultCORSOptions()
 	r.Response.CORS(corsOptions)